@@ -0,0 +1,160 @@
+// Command botclient is a minimal external bot. It connects to the bot
+// WebSocket with a bot token and answers every turn it is offered with a
+// simple legal move: the most valuable card it can buy, otherwise the first
+// gem take, otherwise whatever comes first.
+//
+//	go run ./cmd/botclient -server ws://localhost:8080 -token sbt_...
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"net/url"
+	"os"
+	"os/signal"
+
+	"github.com/gorilla/websocket"
+)
+
+type message struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+type action struct {
+	Type        string         `json:"type"`
+	Gems        map[string]int `json:"gems,omitempty"`
+	CardID      int64          `json:"card_id,omitempty"`
+	FromReserve bool           `json:"from_reserve,omitempty"`
+	Tier        int            `json:"tier,omitempty"`
+}
+
+type turn struct {
+	GameID       int64    `json:"game_id"`
+	TurnNumber   int      `json:"turn_number"`
+	LegalActions []action `json:"legal_actions"`
+	State        struct {
+		GameState struct {
+			VisibleCardsTier1 []card `json:"visible_cards_tier1"`
+			VisibleCardsTier2 []card `json:"visible_cards_tier2"`
+			VisibleCardsTier3 []card `json:"visible_cards_tier3"`
+		} `json:"game_state"`
+		PlayerStates map[string]struct {
+			ReservedCards []card `json:"reserved_cards"`
+		} `json:"player_states"`
+	} `json:"state"`
+}
+
+type card struct {
+	ID            int64 `json:"id"`
+	VictoryPoints int   `json:"victory_points"`
+}
+
+func main() {
+	server := flag.String("server", "ws://localhost:8080", "server base URL")
+	token := flag.String("token", os.Getenv("BOT_TOKEN"), "bot token (or BOT_TOKEN)")
+	flag.Parse()
+
+	if *token == "" {
+		log.Fatal("a bot token is required")
+	}
+
+	u, err := url.Parse(*server + "/api/v1/bot/ws")
+	if err != nil {
+		log.Fatalf("invalid server URL: %v", err)
+	}
+	u.RawQuery = url.Values{"token": {*token}}.Encode()
+
+	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
+	if err != nil {
+		log.Fatalf("failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		conn.Close()
+	}()
+
+	for {
+		var msg message
+		if err := conn.ReadJSON(&msg); err != nil {
+			log.Printf("connection closed: %v", err)
+			return
+		}
+
+		switch msg.Type {
+		case "connected":
+			log.Printf("connected: %s", msg.Payload)
+
+		case "your_turn":
+			var t turn
+			if err := json.Unmarshal(msg.Payload, &t); err != nil {
+				log.Printf("bad turn: %v", err)
+				continue
+			}
+			if len(t.LegalActions) == 0 {
+				continue
+			}
+
+			move := chooseMove(&t)
+			log.Printf("game %d turn %d: playing %s", t.GameID, t.TurnNumber, move.Type)
+
+			err := conn.WriteJSON(map[string]any{
+				"type": "move",
+				"payload": map[string]any{
+					"game_id":     t.GameID,
+					"turn_number": t.TurnNumber,
+					"action":      move,
+				},
+			})
+			if err != nil {
+				log.Printf("failed to send move: %v", err)
+				return
+			}
+
+		case "move_accepted", "move_error":
+			log.Printf("%s: %s", msg.Type, msg.Payload)
+		}
+	}
+}
+
+// chooseMove buys the card worth the most points, otherwise takes gems
+func chooseMove(t *turn) action {
+	points := map[int64]int{}
+	for _, cards := range [][]card{
+		t.State.GameState.VisibleCardsTier1,
+		t.State.GameState.VisibleCardsTier2,
+		t.State.GameState.VisibleCardsTier3,
+	} {
+		for _, c := range cards {
+			points[c.ID] = c.VictoryPoints
+		}
+	}
+	for _, ps := range t.State.PlayerStates {
+		for _, c := range ps.ReservedCards {
+			points[c.ID] = c.VictoryPoints
+		}
+	}
+
+	best := -1
+	for i, a := range t.LegalActions {
+		if a.Type == "purchase_card" && (best < 0 || points[a.CardID] > points[t.LegalActions[best].CardID]) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		return t.LegalActions[best]
+	}
+
+	for _, a := range t.LegalActions {
+		if a.Type == "take_gems" {
+			return a
+		}
+	}
+	return t.LegalActions[0]
+}
@@ -26,7 +26,16 @@ func main() {
 	defer db.Close()
 
 	// Initialize WebSocket hub
-	hub := websocket.NewHub()
+	var backplane websocket.Backplane
+	switch cfg.WSBackplane {
+	case "postgres":
+		backplane = websocket.NewPostgresBackplane(db)
+	default:
+		backplane = websocket.NewLocalBackplane()
+	}
+	defer backplane.Close()
+
+	hub := websocket.NewHub(backplane)
 	go hub.Run()
 
 	// Set up Gin router
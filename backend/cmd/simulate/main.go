@@ -0,0 +1,341 @@
+// Command simulate plays bot-vs-bot games in memory with the real rules and
+// writes aggregated results as JSON. It is used to rank bot strategies and to
+// check the balance of the card data.
+//
+//	go run ./cmd/simulate -strategies hard,medium -players 2,3,4 -games 1000 -out results.json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"splendor-backend/internal/bot"
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+)
+
+type options struct {
+	strategies []string
+	players    []int
+	games      int
+	seed       int64
+	budget     time.Duration
+	maxMoves   int
+	workers    int
+	rotate     bool
+}
+
+// catalog serves the parsed seed cards to strategies that need them
+type catalog struct {
+	cards []models.DevelopmentCard
+}
+
+func (c *catalog) GetAllCards(ctx context.Context) ([]models.DevelopmentCard, error) {
+	return c.cards, nil
+}
+
+func main() {
+	strategies := flag.String("strategies", "hard,medium,easy", "comma-separated strategies, cycled to fill the seats")
+	players := flag.String("players", "2,3,4", "comma-separated player counts to simulate")
+	games := flag.Int("games", 1000, "games per player count")
+	seed := flag.Int64("seed", 1, "seed of the first game; game i is dealt with seed+i")
+	budget := flag.Duration("budget", 50*time.Millisecond, "thinking time per move for medium and hard")
+	maxMoves := flag.Int("max-moves", 400, "moves after which a game is abandoned as unfinished")
+	workers := flag.Int("workers", runtime.NumCPU(), "games played in parallel")
+	rotate := flag.Bool("rotate", true, "rotate strategies through the seats from game to game")
+	seedFile := flag.String("seed-file", "migrations/002_seed_cards_and_nobles.sql", "SQL file with the card and noble data")
+	out := flag.String("out", "", "write results to this file instead of stdout")
+	flag.Parse()
+
+	cards, nobles, err := loadSeedData(*seedFile)
+	if err != nil {
+		log.Fatalf("Failed to load seed data: %v", err)
+	}
+
+	bot.RegisterBuiltins(&catalog{cards: cards}, *budget)
+
+	opts := options{
+		games:    *games,
+		seed:     *seed,
+		budget:   *budget,
+		maxMoves: *maxMoves,
+		workers:  max(1, *workers),
+		rotate:   *rotate,
+	}
+	for _, name := range strings.Split(*strategies, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := bot.Lookup(name); !ok {
+			log.Fatalf("Unknown strategy %q (available: %s)", name, strings.Join(bot.Names(), ", "))
+		}
+		opts.strategies = append(opts.strategies, name)
+	}
+	for _, field := range strings.Split(*players, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 2 || n > 4 {
+			log.Fatalf("Invalid player count %q", field)
+		}
+		opts.players = append(opts.players, n)
+	}
+
+	report := &Report{
+		SeedFile: *seedFile,
+		Seed:     opts.seed,
+		Games:    opts.games,
+		Budget:   opts.budget.String(),
+	}
+	for _, numPlayers := range opts.players {
+		log.Printf("Simulating %d %d-player games", opts.games, numPlayers)
+		lineup := simulateLineup(opts, numPlayers, cards, nobles)
+		log.Printf("  %d finished (%d in stalemate), %d stalled, %d unfinished",
+			lineup.Finished, lineup.Stalemates, lineup.Stalled, lineup.Unfinished)
+		if lineup.Withheld != "" {
+			log.Printf("  Strategy results withheld: %s", lineup.Withheld)
+		}
+		report.Lineups = append(report.Lineups, lineup)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode results: %v", err)
+	}
+
+	if *out == "" {
+		fmt.Println(string(data))
+		return
+	}
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		log.Fatalf("Failed to write results: %v", err)
+	}
+	log.Printf("Results written to %s", *out)
+}
+
+// simulateLineup plays every game for one player count across the workers
+func simulateLineup(opts options, numPlayers int, cards []models.DevelopmentCard, nobles []models.Noble) *LineupReport {
+	lineup := make([]string, numPlayers)
+	for i := range lineup {
+		lineup[i] = opts.strategies[i%len(opts.strategies)]
+	}
+
+	jobs := make(chan int)
+	results := make(chan *gameResult)
+
+	var wg sync.WaitGroup
+	for w := 0; w < opts.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				seats := lineup
+				if opts.rotate {
+					seats = rotated(lineup, i)
+				}
+				results <- playGame(opts.seed+int64(i), seats, cards, nobles, opts.maxMoves)
+			}
+		}()
+	}
+
+	go func() {
+		for i := 0; i < opts.games; i++ {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	report := newLineupReport(lineup, cards, nobles)
+	done := 0
+	for result := range results {
+		report.add(result)
+		done++
+		if step := max(1, opts.games/10); done%step == 0 {
+			log.Printf("  %d/%d games", done, opts.games)
+		}
+	}
+	report.finish()
+
+	return report
+}
+
+func rotated(lineup []string, by int) []string {
+	seats := make([]string, len(lineup))
+	for i := range lineup {
+		seats[i] = lineup[(i+by)%len(lineup)]
+	}
+	return seats
+}
+
+// gameResult records what happened in one simulated game
+type gameResult struct {
+	seats         []string
+	outcome       string
+	winnerSeat    int
+	moves         int
+	winningPoints int
+	stalemate     bool
+
+	noblesOffered []int64
+	noblesClaimed []int64
+	tierPurchases map[int]int
+	tierReserves  map[int]int
+	cardsSeen     map[int64]bool
+	cardsBought   []int64
+}
+
+// Game outcomes
+const (
+	outcomeFinished   = "finished"
+	outcomeStalled    = "stalled"
+	outcomeUnfinished = "unfinished"
+)
+
+// playGame deals a game from seed and lets the seated strategies play it out
+func playGame(seed int64, seats []string, cards []models.DevelopmentCard, nobles []models.Noble, maxMoves int) *gameResult {
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(seed))
+
+	first := int64(1)
+	state := &models.FullGameState{
+		Game: &models.Game{
+			ID:                  seed,
+			Status:              models.GameStatusInProgress,
+			NumPlayers:          len(seats),
+			CurrentTurnPlayerID: &first,
+		},
+		GameState:    gamelogic.DealBoard(seed, cards, nobles, len(seats), rng),
+		PlayerStates: make(map[int64]*models.PlayerState, len(seats)),
+	}
+	for i := range seats {
+		userID := int64(i + 1)
+		state.Players = append(state.Players, &models.GamePlayer{
+			ID:             userID,
+			UserID:         userID,
+			PlayerPosition: i,
+			IsActive:       true,
+		})
+		state.PlayerStates[userID] = gamelogic.NewPlayerState(userID)
+	}
+
+	result := &gameResult{
+		seats:         seats,
+		outcome:       outcomeUnfinished,
+		winnerSeat:    -1,
+		tierPurchases: map[int]int{},
+		tierReserves:  map[int]int{},
+		cardsSeen:     map[int64]bool{},
+	}
+	for _, noble := range state.GameState.AvailableNobles {
+		result.noblesOffered = append(result.noblesOffered, noble.ID)
+	}
+	markSeen(result, state.GameState)
+
+	// A full round in which every player passed ends the game as it stands
+	passes := 0
+	for result.moves < maxMoves {
+		userID := *state.Game.CurrentTurnPlayerID
+		seat := int(userID - 1)
+		view := gamelogic.ViewFor(state, userID)
+
+		legal := gamelogic.LegalActions(view, userID)
+		if len(legal) == 0 {
+			result.outcome = outcomeStalled
+			break
+		}
+
+		strategy, _ := bot.Lookup(seats[seat])
+		action, err := strategy.ChooseAction(ctx, view, userID, legal)
+		if err != nil || action == nil {
+			action = &legal[0]
+		}
+
+		recordAction(result, state, userID, action)
+		if err := gamelogic.SimulateAction(state, action); err != nil {
+			log.Printf("Game %d: %s played an illegal move: %v", seed, seats[seat], err)
+			result.outcome = outcomeStalled
+			break
+		}
+		result.moves++
+		markSeen(result, state.GameState)
+
+		if action.Type == gamelogic.ActionPass {
+			passes++
+		} else {
+			passes = 0
+		}
+		if passes == len(seats) {
+			gamelogic.FinishStalemate(state)
+			result.stalemate = true
+		}
+
+		if state.Game.Status == models.GameStatusCompleted {
+			result.outcome = outcomeFinished
+			result.winnerSeat = int(*state.Game.WinnerID - 1)
+			result.winningPoints = state.Players[result.winnerSeat].VictoryPoints
+			break
+		}
+	}
+
+	for _, playerState := range state.PlayerStates {
+		for _, noble := range playerState.Nobles {
+			result.noblesClaimed = append(result.noblesClaimed, noble.ID)
+		}
+	}
+
+	return result
+}
+
+// recordAction counts purchases and reserves by tier before the action is
+// applied, while the card is still where the action expects it
+func recordAction(result *gameResult, state *models.FullGameState, userID int64, action *gamelogic.Action) {
+	switch action.Type {
+	case gamelogic.ActionPurchaseCard:
+		if card := findCard(state, userID, action.CardID); card != nil {
+			result.tierPurchases[card.Tier]++
+			result.cardsBought = append(result.cardsBought, card.ID)
+		}
+	case gamelogic.ActionReserveCard:
+		tier := action.Tier
+		if card := findCard(state, userID, action.CardID); card != nil {
+			tier = card.Tier
+		}
+		result.tierReserves[tier]++
+	}
+}
+
+func findCard(state *models.FullGameState, userID, cardID int64) *models.DevelopmentCard {
+	for _, cards := range [][]models.DevelopmentCard{
+		state.GameState.VisibleCardsTier1,
+		state.GameState.VisibleCardsTier2,
+		state.GameState.VisibleCardsTier3,
+		state.PlayerStates[userID].ReservedCards,
+	} {
+		for i := range cards {
+			if cards[i].ID == cardID {
+				return &cards[i]
+			}
+		}
+	}
+	return nil
+}
+
+func markSeen(result *gameResult, gameState *models.GameState) {
+	for _, cards := range [][]models.DevelopmentCard{
+		gameState.VisibleCardsTier1,
+		gameState.VisibleCardsTier2,
+		gameState.VisibleCardsTier3,
+	} {
+		for _, card := range cards {
+			result.cardsSeen[card.ID] = true
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"splendor-backend/internal/bot"
+)
+
+// TestGamesFinish plays short runs of every lineup and fails if any game
+// stalls or runs out of moves
+func TestGamesFinish(t *testing.T) {
+	if testing.Short() {
+		t.Skip("plays full games")
+	}
+
+	cards, nobles, err := loadSeedData("../../migrations/002_seed_cards_and_nobles.sql")
+	if err != nil {
+		t.Fatalf("failed to load seed data: %v", err)
+	}
+
+	budget := 2 * time.Millisecond
+	bot.RegisterBuiltins(&catalog{cards: cards}, budget)
+
+	lineups := [][]string{
+		{"medium"},
+		{"hard", "medium", "easy"},
+	}
+	for _, strategies := range lineups {
+		opts := options{
+			strategies: strategies,
+			games:      20,
+			seed:       1,
+			budget:     budget,
+			maxMoves:   400,
+			workers:    runtime.NumCPU(),
+			rotate:     true,
+		}
+		for _, numPlayers := range []int{2, 3, 4} {
+			report := simulateLineup(opts, numPlayers, cards, nobles)
+			if report.Stalled > 0 || report.Unfinished > 0 {
+				t.Errorf("%v with %d players: %d stalled and %d unfinished of %d games",
+					strategies, numPlayers, report.Stalled, report.Unfinished, report.Games)
+			}
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+
+	"splendor-backend/internal/domain/models"
+)
+
+// Report is the JSON document written by the simulator
+type Report struct {
+	SeedFile string          `json:"seed_file"`
+	Seed     int64           `json:"seed"`
+	Games    int             `json:"games_per_lineup"`
+	Budget   string          `json:"budget"`
+	Lineups  []*LineupReport `json:"lineups"`
+}
+
+// LineupReport aggregates the games played at one player count
+type LineupReport struct {
+	Players    int      `json:"players"`
+	Strategies []string `json:"strategies"`
+
+	Games      int `json:"games"`
+	Finished   int `json:"finished"`
+	Stalemates int `json:"stalemates"` // Finished games ended by a round of passes
+	Stalled    int `json:"stalled"`
+	Unfinished int `json:"unfinished"`
+
+	AverageMoves         float64 `json:"average_moves"`
+	AverageRounds        float64 `json:"average_rounds"`
+	AverageWinningPoints float64 `json:"average_winning_points"`
+
+	// Win rates over finished games, by seat in turn order and by strategy.
+	// Strategy results are withheld if any game stalled or was unfinished,
+	// as the strategies would be ranked on the games they could finish.
+	SeatWinRate     []float64                `json:"seat_win_rate"`
+	StrategyResults map[string]*StrategyStat `json:"strategy_results,omitempty"`
+	Withheld        string                   `json:"strategy_results_withheld,omitempty"`
+
+	Nobles        []*NobleStat `json:"nobles"`
+	TierPurchases map[int]int  `json:"tier_purchases"`
+	TierReserves  map[int]int  `json:"tier_reserves"`
+	Cards         []*CardStat  `json:"cards"`
+
+	seatWins    []int
+	totalMoves  int
+	totalPoints int
+	nobles      map[int64]*NobleStat
+	cards       map[int64]*CardStat
+}
+
+// StrategyStat counts one strategy's results; a strategy in two seats
+// plays two seat-games per game
+type StrategyStat struct {
+	SeatGames int     `json:"seat_games"`
+	Wins      int     `json:"wins"`
+	WinRate   float64 `json:"win_rate"`
+}
+
+// NobleStat counts how often a noble was dealt and claimed
+type NobleStat struct {
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Offered   int     `json:"offered"`
+	Claimed   int     `json:"claimed"`
+	ClaimRate float64 `json:"claim_rate"`
+}
+
+// CardStat counts how often a card reached the board and was bought
+type CardStat struct {
+	ID            int64          `json:"id"`
+	Tier          int            `json:"tier"`
+	GemType       string         `json:"gem_type"`
+	VictoryPoints int            `json:"victory_points"`
+	Cost          map[string]int `json:"cost"`
+	Seen          int            `json:"seen"`
+	Purchased     int            `json:"purchased"`
+	PurchaseRate  float64        `json:"purchase_rate"`
+}
+
+func newLineupReport(lineup []string, cards []models.DevelopmentCard, nobles []models.Noble) *LineupReport {
+	report := &LineupReport{
+		Players:         len(lineup),
+		Strategies:      lineup,
+		SeatWinRate:     make([]float64, len(lineup)),
+		StrategyResults: map[string]*StrategyStat{},
+		TierPurchases:   map[int]int{},
+		TierReserves:    map[int]int{},
+		seatWins:        make([]int, len(lineup)),
+		nobles:          map[int64]*NobleStat{},
+		cards:           map[int64]*CardStat{},
+	}
+
+	for _, name := range lineup {
+		report.StrategyResults[name] = &StrategyStat{}
+	}
+	for _, noble := range nobles {
+		stat := &NobleStat{ID: noble.ID, Name: noble.Name}
+		report.nobles[noble.ID] = stat
+		report.Nobles = append(report.Nobles, stat)
+	}
+	for _, card := range cards {
+		stat := &CardStat{
+			ID:            card.ID,
+			Tier:          card.Tier,
+			GemType:       card.GemType,
+			VictoryPoints: card.VictoryPoints,
+			Cost:          card.Cost,
+		}
+		report.cards[card.ID] = stat
+		report.Cards = append(report.Cards, stat)
+	}
+
+	return report
+}
+
+func (r *LineupReport) add(result *gameResult) {
+	r.Games++
+	switch result.outcome {
+	case outcomeFinished:
+		r.Finished++
+		if result.stalemate {
+			r.Stalemates++
+		}
+	case outcomeStalled:
+		r.Stalled++
+	default:
+		r.Unfinished++
+	}
+
+	for seat, name := range result.seats {
+		stat := r.StrategyResults[name]
+		if result.outcome == outcomeFinished {
+			stat.SeatGames++
+			if seat == result.winnerSeat {
+				stat.Wins++
+			}
+		}
+	}
+
+	if result.outcome == outcomeFinished {
+		r.seatWins[result.winnerSeat]++
+		r.totalMoves += result.moves
+		r.totalPoints += result.winningPoints
+	}
+
+	for _, id := range result.noblesOffered {
+		r.nobles[id].Offered++
+	}
+	for _, id := range result.noblesClaimed {
+		r.nobles[id].Claimed++
+	}
+	for tier, count := range result.tierPurchases {
+		r.TierPurchases[tier] += count
+	}
+	for tier, count := range result.tierReserves {
+		r.TierReserves[tier] += count
+	}
+	for id := range result.cardsSeen {
+		r.cards[id].Seen++
+	}
+	for _, id := range result.cardsBought {
+		r.cards[id].Purchased++
+	}
+}
+
+// finish turns the counters into averages and rates
+func (r *LineupReport) finish() {
+	if r.Finished > 0 {
+		r.AverageMoves = float64(r.totalMoves) / float64(r.Finished)
+		r.AverageRounds = r.AverageMoves / float64(r.Players)
+		r.AverageWinningPoints = float64(r.totalPoints) / float64(r.Finished)
+		for seat, wins := range r.seatWins {
+			r.SeatWinRate[seat] = float64(wins) / float64(r.Finished)
+		}
+	}
+
+	for _, stat := range r.StrategyResults {
+		if stat.SeatGames > 0 {
+			stat.WinRate = float64(stat.Wins) / float64(stat.SeatGames)
+		}
+	}
+	if r.Stalled > 0 || r.Unfinished > 0 {
+		r.StrategyResults = nil
+		r.Withheld = fmt.Sprintf("%d stalled and %d unfinished of %d games", r.Stalled, r.Unfinished, r.Games)
+	}
+	for _, stat := range r.Nobles {
+		if stat.Offered > 0 {
+			stat.ClaimRate = float64(stat.Claimed) / float64(stat.Offered)
+		}
+	}
+	for _, stat := range r.Cards {
+		if stat.Seen > 0 {
+			stat.PurchaseRate = float64(stat.Purchased) / float64(stat.Seen)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"splendor-backend/internal/domain/models"
+)
+
+var insertPattern = regexp.MustCompile(`(?is)INSERT INTO\s+(\w+)\s*\(([^)]*)\)\s*VALUES(.*?);`)
+
+// loadSeedData reads the development cards and nobles from the seed
+// migration. IDs are assigned in insert order, as BIGSERIAL would.
+func loadSeedData(path string) ([]models.DevelopmentCard, []models.Noble, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cards := []models.DevelopmentCard{}
+	nobles := []models.Noble{}
+
+	for _, match := range insertPattern.FindAllStringSubmatch(stripComments(string(data)), -1) {
+		table := strings.ToLower(match[1])
+		columns := splitFields(match[2])
+
+		for _, row := range splitRows(match[3]) {
+			values := splitFields(row)
+			if len(values) != len(columns) {
+				return nil, nil, fmt.Errorf("%s row has %d values for %d columns: %s", table, len(values), len(columns), row)
+			}
+
+			fields := make(map[string]string, len(columns))
+			for i, column := range columns {
+				fields[column] = values[i]
+			}
+
+			switch table {
+			case "development_cards":
+				card := models.DevelopmentCard{
+					ID:            int64(len(cards) + 1),
+					Tier:          atoi(fields["tier"]),
+					GemType:       fields["gem_type"],
+					VictoryPoints: atoi(fields["victory_points"]),
+					Cost:          gemFields(fields, "cost_"),
+				}
+				cards = append(cards, card)
+			case "nobles":
+				noble := models.Noble{
+					ID:            int64(len(nobles) + 1),
+					Name:          fields["name"],
+					VictoryPoints: atoi(fields["victory_points"]),
+					Required:      gemFields(fields, "required_"),
+				}
+				nobles = append(nobles, noble)
+			}
+		}
+	}
+
+	if len(cards) == 0 || len(nobles) == 0 {
+		return nil, nil, fmt.Errorf("no cards or nobles found in %s", path)
+	}
+
+	return cards, nobles, nil
+}
+
+func gemFields(fields map[string]string, prefix string) map[string]int {
+	gems := map[string]int{}
+	for _, gemType := range []string{"diamond", "sapphire", "emerald", "ruby", "onyx"} {
+		if count := atoi(fields[prefix+gemType]); count > 0 {
+			gems[gemType] = count
+		}
+	}
+	return gems
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// stripComments removes -- comments outside string literals
+func stripComments(sql string) string {
+	var out strings.Builder
+	for _, line := range strings.Split(sql, "\n") {
+		inString := false
+		for i := 0; i < len(line); i++ {
+			if line[i] == '\'' {
+				inString = !inString
+			}
+			if !inString && strings.HasPrefix(line[i:], "--") {
+				line = line[:i]
+				break
+			}
+		}
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+	return out.String()
+}
+
+// splitRows returns the contents of each parenthesized tuple
+func splitRows(values string) []string {
+	rows := []string{}
+	depth, start, inString := 0, 0, false
+	for i := 0; i < len(values); i++ {
+		switch c := values[i]; {
+		case c == '\'':
+			inString = !inString
+		case inString:
+		case c == '(':
+			if depth == 0 {
+				start = i + 1
+			}
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				rows = append(rows, values[start:i])
+			}
+		}
+	}
+	return rows
+}
+
+// splitFields splits on commas outside string literals and unquotes values
+func splitFields(s string) []string {
+	fields := []string{}
+	var current strings.Builder
+	inString := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\'' && inString && i+1 < len(s) && s[i+1] == '\'':
+			current.WriteByte('\'')
+			i++
+		case c == '\'':
+			inString = !inString
+		case c == ',' && !inString:
+			fields = append(fields, strings.TrimSpace(current.String()))
+			current.Reset()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	return append(fields, strings.TrimSpace(current.String()))
+}
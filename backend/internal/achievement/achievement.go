@@ -0,0 +1,69 @@
+// Package achievement defines the achievements players can unlock. Each
+// achievement is checked either after every move a player makes or once
+// for each player when a game ends.
+package achievement
+
+import (
+	"sync"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+)
+
+// GameEnd is one player's view of a finished game
+type GameEnd struct {
+	Game       *models.Game
+	Result     *models.GameResult
+	Reserves   int // Cards the player reserved during the game
+	RankedWins int // The player's ranked wins, including this game
+}
+
+// Definition is an achievement and the check that unlocks it. Exactly one
+// of OnMove and OnGameEnd is set.
+type Definition struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	OnMove    func(event *gamelogic.MoveEvent) bool `json:"-"`
+	OnGameEnd func(end *GameEnd) bool               `json:"-"`
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*Definition{}
+	order      []string
+)
+
+// Register adds an achievement under its key. Achievements are listed in
+// the order they were registered.
+func Register(def *Definition) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[def.Key]; !ok {
+		order = append(order, def.Key)
+	}
+	registry[def.Key] = def
+}
+
+// Lookup returns the achievement registered under key
+func Lookup(key string) (*Definition, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	def, ok := registry[key]
+	return def, ok
+}
+
+// All returns every registered achievement in registration order
+func All() []*Definition {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	defs := make([]*Definition, 0, len(order))
+	for _, key := range order {
+		defs = append(defs, registry[key])
+	}
+	return defs
+}
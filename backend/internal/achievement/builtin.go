@@ -0,0 +1,54 @@
+package achievement
+
+import "splendor-backend/internal/gamelogic"
+
+// RegisterBuiltins registers the standard achievements
+func RegisterBuiltins() {
+	Register(&Definition{
+		Key:         "first_win",
+		Name:        "First Victory",
+		Description: "Win a game",
+		OnGameEnd: func(end *GameEnd) bool {
+			return end.Result.Placement == 1
+		},
+	})
+
+	Register(&Definition{
+		Key:         "clean_win",
+		Name:        "Nothing Up My Sleeve",
+		Description: "Win a game without reserving a card",
+		OnGameEnd: func(end *GameEnd) bool {
+			return end.Result.Placement == 1 && end.Reserves == 0
+		},
+	})
+
+	Register(&Definition{
+		Key:         "noble_court",
+		Name:        "Royal Court",
+		Description: "Be visited by 3 nobles in one game",
+		OnMove: func(event *gamelogic.MoveEvent) bool {
+			return len(event.State.Nobles) >= 3
+		},
+	})
+
+	Register(&Definition{
+		Key:         "self_made",
+		Name:        "Self-Made",
+		Description: "Buy a tier 3 card paying with bonuses only",
+		OnMove: func(event *gamelogic.MoveEvent) bool {
+			if event.Action.Type != gamelogic.ActionPurchaseCard || event.Card == nil || event.Card.Tier != 3 {
+				return false
+			}
+			return len(event.Paid) == 0
+		},
+	})
+
+	Register(&Definition{
+		Key:         "ranked_veteran",
+		Name:        "Ranked Veteran",
+		Description: "Win 10 ranked games",
+		OnGameEnd: func(end *GameEnd) bool {
+			return end.Game.Ranked && end.RankedWins >= 10
+		},
+	})
+}
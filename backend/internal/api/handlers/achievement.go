@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"splendor-backend/internal/achievement"
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/service"
+	"splendor-backend/pkg/websocket"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AchievementService serves the achievements players can unlock
+type AchievementService interface {
+	ListAchievements() []*achievement.Definition
+	GetUserAchievements(ctx context.Context, userID int64) (*models.UserAchievementsResponse, error)
+}
+
+type AchievementHandler struct {
+	achievementService AchievementService
+	hub                *websocket.Hub
+}
+
+func NewAchievementHandler(achievementService AchievementService, hub *websocket.Hub) *AchievementHandler {
+	return &AchievementHandler{
+		achievementService: achievementService,
+		hub:                hub,
+	}
+}
+
+// ListAchievements returns every achievement that can be unlocked
+func (h *AchievementHandler) ListAchievements(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"achievements": h.achievementService.ListAchievements()})
+}
+
+// GetUserAchievements returns a player's unlocked and locked achievements
+func (h *AchievementHandler) GetUserAchievements(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	resp, err := h.achievementService.GetUserAchievements(c.Request.Context(), userID)
+	if err != nil {
+		if err == service.ErrUserNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get achievements"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// AnnounceUnlock tells a player about an achievement they just unlocked,
+// in the game they unlocked it in
+func (h *AchievementHandler) AnnounceUnlock(ctx context.Context, unlocked *models.UserAchievement) {
+	if unlocked.GameID == nil {
+		return
+	}
+
+	payload := map[string]interface{}{
+		"key":         unlocked.Key,
+		"unlocked_at": unlocked.UnlockedAt,
+		"game_id":     *unlocked.GameID,
+	}
+	if def, ok := achievement.Lookup(unlocked.Key); ok {
+		payload["name"] = def.Name
+		payload["description"] = def.Description
+	}
+
+	messageBytes, err := json.Marshal(&websocket.Message{
+		Type:    "achievement_unlocked",
+		Payload: payload,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal achievement message: %v", err)
+		return
+	}
+
+	h.hub.SendToUser(strconv.FormatInt(*unlocked.GameID, 10), unlocked.UserID, messageBytes)
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminService is the subset of the admin service used by AdminHandler
+type AdminService interface {
+	ListSnapshots(ctx context.Context, gameID int64) ([]*models.GameSnapshot, error)
+	CreateSnapshot(ctx context.Context, adminID, gameID int64) (*models.GameSnapshot, error)
+	RestoreSnapshot(ctx context.Context, adminID, gameID, snapshotID int64, reason string) (*models.GameSnapshot, error)
+	StartSeason(ctx context.Context, adminID int64, name string) (*models.Season, error)
+	EndSeason(ctx context.Context, adminID int64) (*models.Season, error)
+	ListAudit(ctx context.Context, limit, offset int) ([]*models.AuditEntry, error)
+}
+
+type AdminHandler struct {
+	adminService AdminService
+	states       StateBroadcaster
+	bots         BotNotifier
+}
+
+func NewAdminHandler(adminService AdminService, states StateBroadcaster, bots BotNotifier) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+		states:       states,
+		bots:         bots,
+	}
+}
+
+// ListSnapshots lists a game's snapshots
+func (h *AdminHandler) ListSnapshots(c *gin.Context) {
+	gameID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	snapshots, err := h.adminService.ListSnapshots(c.Request.Context(), gameID)
+	if err != nil {
+		if err == service.ErrGameNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list snapshots"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"snapshots": snapshots})
+}
+
+// CreateSnapshot takes a manual snapshot of a game
+func (h *AdminHandler) CreateSnapshot(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	gameID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	snapshot, err := h.adminService.CreateSnapshot(c.Request.Context(), userID.(int64), gameID)
+	if err != nil {
+		switch err {
+		case service.ErrGameNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		case service.ErrGameNotInProgress:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Game has not started"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create snapshot"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, snapshot)
+}
+
+// RestoreSnapshot restores a game to a snapshot and pushes the restored
+// state to everyone watching it
+func (h *AdminHandler) RestoreSnapshot(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	gameID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	snapshotID, err := strconv.ParseInt(c.Param("snapshotId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid snapshot ID"})
+		return
+	}
+
+	var req models.RestoreSnapshotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	snapshot, err := h.adminService.RestoreSnapshot(c.Request.Context(), userID.(int64), gameID, snapshotID, req.Reason)
+	if err != nil {
+		switch err {
+		case service.ErrGameNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		case service.ErrSnapshotNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Snapshot not found"})
+		case service.ErrRestoreFinished:
+			c.JSON(http.StatusConflict, gin.H{"error": "Finished games cannot be restored"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restore snapshot"})
+		}
+		return
+	}
+
+	h.states.BroadcastState(c.Request.Context(), gameID)
+	h.bots.Notify(gameID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Game restored",
+		"snapshot": snapshot,
+	})
+}
+
+// StartSeason starts a new ranked season
+func (h *AdminHandler) StartSeason(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req models.StartSeasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	season, err := h.adminService.StartSeason(c.Request.Context(), userID.(int64), req.Name)
+	if err != nil {
+		if err == service.ErrSeasonActive {
+			c.JSON(http.StatusConflict, gin.H{"error": "A season is already running"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start season"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, season)
+}
+
+// EndSeason ends the running season and archives its standings
+func (h *AdminHandler) EndSeason(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	season, err := h.adminService.EndSeason(c.Request.Context(), userID.(int64))
+	if err != nil {
+		if err == service.ErrNoActiveSeason {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No season is running"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to end season"})
+		return
+	}
+
+	c.JSON(http.StatusOK, season)
+}
+
+// GetAuditLog lists recent admin actions
+func (h *AdminHandler) GetAuditLog(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	entries, err := h.adminService.ListAudit(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get audit log"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": entries})
+}
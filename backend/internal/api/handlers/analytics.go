@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsService serves metrics derived from players' move logs
+type AnalyticsService interface {
+	GetUserAnalytics(ctx context.Context, userID int64, limit int) (*models.UserAnalytics, error)
+}
+
+type AnalyticsHandler struct {
+	analyticsService AnalyticsService
+}
+
+func NewAnalyticsHandler(analyticsService AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// GetUserAnalytics returns a player's play-style metrics and their most
+// recently analyzed games
+func (h *AnalyticsHandler) GetUserAnalytics(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	analytics, err := h.analyticsService.GetUserAnalytics(c.Request.Context(), userID, limit)
+	if err != nil {
+		if err == service.ErrUserNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get analytics"})
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
@@ -64,6 +64,53 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// Guest starts a guest session
+func (h *AuthHandler) Guest(c *gin.Context) {
+	var req models.GuestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authService.Guest(c.Request.Context(), &req)
+	if err != nil {
+		if err == service.ErrUsernameAlreadyExists {
+			c.JSON(http.StatusConflict, gin.H{"error": "Username already exists"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start guest session"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// UpgradeGuest turns the authenticated guest into a registered account
+func (h *AuthHandler) UpgradeGuest(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req models.UpgradeGuestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authService.UpgradeGuest(c.Request.Context(), userID.(int64), &req)
+	if err != nil {
+		switch err {
+		case service.ErrNotGuest:
+			c.JSON(http.StatusConflict, gin.H{"error": "Account is already registered"})
+		case service.ErrEmailAlreadyExists:
+			c.JSON(http.StatusConflict, gin.H{"error": "Email already exists"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upgrade account"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
 // RefreshToken handles token refresh
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req struct {
@@ -77,13 +124,55 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 	resp, err := h.authService.RefreshToken(c.Request.Context(), req.RefreshToken)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token"})
+		switch err {
+		case service.ErrInvalidRefreshToken:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token"})
+		case service.ErrRefreshTokenReused:
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token was already used; please log in again"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		}
 		return
 	}
 
 	c.JSON(http.StatusOK, resp)
 }
 
+// Logout revokes the login a refresh token belongs to
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authService.Logout(c.Request.Context(), req.RefreshToken); err != nil {
+		if err == service.ErrInvalidRefreshToken {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to logout"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+}
+
+// LogoutAll revokes every login of the authenticated user
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	if err := h.authService.LogoutAll(c.Request.Context(), userID.(int64)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to logout"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out everywhere"})
+}
+
 // GetCurrentUser returns the authenticated user's information
 func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/service"
+	wshub "splendor-backend/pkg/websocket"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// maxTurnWait caps how long a bot's long poll for turns may block
+const maxTurnWait = 30 * time.Second
+
+// BotHandler serves the external bot API: bot registration for users, and
+// turns and moves for bots authenticated with a bot token
+type BotHandler struct {
+	botService *service.BotService
+	hub        *wshub.Hub
+}
+
+func NewBotHandler(botService *service.BotService, hub *wshub.Hub) *BotHandler {
+	return &BotHandler{
+		botService: botService,
+		hub:        hub,
+	}
+}
+
+// CreateBot registers a bot account for the current user and returns its token
+func (h *BotHandler) CreateBot(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req models.CreateBotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.botService.CreateBot(c.Request.Context(), userID.(int64), req.Username)
+	if err != nil {
+		switch err {
+		case service.ErrUsernameAlreadyExists:
+			c.JSON(http.StatusConflict, gin.H{"error": "Username already exists"})
+		case service.ErrBotCannotOwnBots:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Bot accounts cannot register bots"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create bot"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListBots lists the bots registered by the current user
+func (h *BotHandler) ListBots(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	bots, err := h.botService.ListBots(c.Request.Context(), userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list bots"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"bots": bots})
+}
+
+// RotateToken revokes a bot's tokens and returns a new one
+func (h *BotHandler) RotateToken(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	botID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bot ID"})
+		return
+	}
+
+	token, err := h.botService.RotateToken(c.Request.Context(), userID.(int64), botID)
+	if err != nil {
+		if err == service.ErrBotNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Bot not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rotate token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token})
+}
+
+// GetTurns returns the turns waiting for the bot's move. With ?wait=N it
+// long-polls for up to N seconds until a turn is available.
+func (h *BotHandler) GetTurns(c *gin.Context) {
+	botID, _ := c.Get("botID")
+
+	waitSeconds, _ := strconv.Atoi(c.DefaultQuery("wait", "0"))
+	wait := min(time.Duration(waitSeconds)*time.Second, maxTurnWait)
+
+	var turns []*models.BotTurn
+	var err error
+	if wait > 0 {
+		turns, err = h.botService.WaitForTurns(c.Request.Context(), botID.(int64), wait)
+	} else {
+		turns, err = h.botService.PendingTurns(c.Request.Context(), botID.(int64))
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get turns"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"turns": turns})
+}
+
+// SubmitMove plays the bot's move for a pending turn
+func (h *BotHandler) SubmitMove(c *gin.Context) {
+	botID, _ := c.Get("botID")
+	gameID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	var req models.BotMoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	action, err := h.botService.SubmitMove(c.Request.Context(), botID.(int64), gameID, req.TurnNumber, req.Action)
+	if err != nil {
+		switch err {
+		case service.ErrTurnNotPending:
+			c.JSON(http.StatusConflict, gin.H{"error": "Turn is not waiting for a move"})
+		case service.ErrIllegalMove:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Move is not one of the legal actions"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit move"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"action": action})
+}
+
+// NotifyTurn pushes a turn to the bot's WebSocket connections
+func (h *BotHandler) NotifyTurn(turn *models.BotTurn) {
+	msgBytes, err := json.Marshal(&wshub.Message{Type: "your_turn", Payload: turn})
+	if err != nil {
+		log.Printf("Failed to marshal message: %v", err)
+		return
+	}
+
+	h.hub.SendToUser(wshub.BotRoom(turn.UserID), turn.UserID, msgBytes)
+}
+
+// HandleConnection opens a bot's WebSocket. The bot receives "your_turn"
+// messages and may answer them with "move" messages instead of over HTTP.
+func (h *BotHandler) HandleConnection(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token required"})
+		return
+	}
+
+	botUser, err := h.botService.AuthenticateBot(c.Request.Context(), token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid bot token"})
+		return
+	}
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade WebSocket: %v", err)
+		return
+	}
+
+	client := &wshub.Client{
+		ID:       strconv.FormatInt(botUser.ID, 10),
+		GameID:   wshub.BotRoom(botUser.ID),
+		UserID:   botUser.ID,
+		Username: botUser.Username,
+		Conn:     conn,
+		Send:     make(chan []byte, 256),
+		Hub:      h.hub,
+	}
+
+	h.hub.RegisterClient(client)
+
+	go writePump(client, conn)
+	go h.readPump(client, conn)
+
+	h.sendToClient(client, &wshub.Message{
+		Type: "connected",
+		Payload: map[string]interface{}{
+			"message": "Connected as bot",
+			"user_id": botUser.ID,
+		},
+	})
+
+	// Turns offered before the bot connected are still waiting for it
+	turns, err := h.botService.PendingTurns(c.Request.Context(), botUser.ID)
+	if err != nil {
+		log.Printf("Failed to get pending turns for bot %d: %v", botUser.ID, err)
+		return
+	}
+	for _, turn := range turns {
+		h.sendToClient(client, &wshub.Message{Type: "your_turn", Payload: turn})
+	}
+}
+
+func (h *BotHandler) readPump(client *wshub.Client, conn *websocket.Conn) {
+	defer func() {
+		h.hub.UnregisterClient(client)
+		conn.Close()
+	}()
+
+	conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+		return nil
+	})
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				log.Printf("WebSocket error: %v", err)
+			}
+			break
+		}
+
+		var msg struct {
+			Type    string          `json:"type"`
+			Payload json.RawMessage `json:"payload"`
+		}
+		if err := json.Unmarshal(message, &msg); err != nil {
+			log.Printf("Failed to unmarshal message: %v", err)
+			continue
+		}
+
+		if msg.Type != "move" {
+			log.Printf("Unknown bot message type: %s", msg.Type)
+			continue
+		}
+		h.handleMove(client, msg.Payload)
+	}
+}
+
+// handleMove submits a move sent over the bot's WebSocket and reports back
+// whether it was accepted
+func (h *BotHandler) handleMove(client *wshub.Client, payload json.RawMessage) {
+	var move struct {
+		GameID int64 `json:"game_id"`
+		models.BotMoveRequest
+	}
+	if err := json.Unmarshal(payload, &move); err != nil {
+		h.sendToClient(client, &wshub.Message{
+			Type:    "move_error",
+			Payload: map[string]interface{}{"error": "Invalid move payload"},
+		})
+		return
+	}
+
+	action, err := h.botService.SubmitMove(context.Background(), client.UserID, move.GameID, move.TurnNumber, move.Action)
+	if err != nil {
+		h.sendToClient(client, &wshub.Message{
+			Type: "move_error",
+			Payload: map[string]interface{}{
+				"game_id":     move.GameID,
+				"turn_number": move.TurnNumber,
+				"error":       err.Error(),
+			},
+		})
+		return
+	}
+
+	h.sendToClient(client, &wshub.Message{
+		Type: "move_accepted",
+		Payload: map[string]interface{}{
+			"game_id":     move.GameID,
+			"turn_number": move.TurnNumber,
+			"action":      action,
+		},
+	})
+}
+
+// sendToClient queues a message for a single client without blocking
+func (h *BotHandler) sendToClient(client *wshub.Client, msg *wshub.Message) {
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("Failed to marshal message: %v", err)
+		return
+	}
+
+	h.hub.SendToClient(client, msgBytes)
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/service"
+	"splendor-backend/pkg/websocket"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ChatHandler struct {
+	chatService ChatService
+	hub         *websocket.Hub
+}
+
+func NewChatHandler(chatService ChatService, hub *websocket.Hub) *ChatHandler {
+	return &ChatHandler{
+		chatService: chatService,
+		hub:         hub,
+	}
+}
+
+// GetHistory returns the chat history for a game
+func (h *ChatHandler) GetHistory(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	gameID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	before, _ := strconv.ParseInt(c.DefaultQuery("before", "0"), 10, 64)
+
+	messages, err := h.chatService.GetHistory(c.Request.Context(), gameID, userID.(int64), before, limit)
+	if err != nil {
+		if err == service.ErrGameNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get chat history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"messages": messages})
+}
+
+// MutePlayer mutes another player's chat for the current user
+func (h *ChatHandler) MutePlayer(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	gameIDStr := c.Param("id")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	var req models.MuteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.chatService.MutePlayer(c.Request.Context(), gameID, userID.(int64), req.UserID); err != nil {
+		switch err {
+		case service.ErrCannotMuteSelf:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot mute yourself"})
+		case service.ErrNotInGame:
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not a player in this game"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mute player"})
+		}
+		return
+	}
+
+	h.hub.UpdateMute(gameIDStr, userID.(int64), req.UserID, true)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Player muted"})
+}
+
+// UnmutePlayer removes a chat mute
+func (h *ChatHandler) UnmutePlayer(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	gameIDStr := c.Param("id")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	mutedUserID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.chatService.UnmutePlayer(c.Request.Context(), gameID, userID.(int64), mutedUserID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unmute player"})
+		return
+	}
+
+	h.hub.UpdateMute(gameIDStr, userID.(int64), mutedUserID, false)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Player unmuted"})
+}
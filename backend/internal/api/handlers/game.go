@@ -1,22 +1,30 @@
 package handlers
 
 import (
+	"io"
 	"net/http"
 	"strconv"
 
+	"splendor-backend/internal/bot"
 	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
 	"splendor-backend/internal/service"
+	"splendor-backend/pkg/websocket"
 
 	"github.com/gin-gonic/gin"
 )
 
 type GameHandler struct {
 	gameService *service.GameService
+	hub         *websocket.Hub
+	bots        BotNotifier
 }
 
-func NewGameHandler(gameService *service.GameService) *GameHandler {
+func NewGameHandler(gameService *service.GameService, hub *websocket.Hub, bots BotNotifier) *GameHandler {
 	return &GameHandler{
 		gameService: gameService,
+		hub:         hub,
+		bots:        bots,
 	}
 }
 
@@ -30,12 +38,14 @@ func (h *GameHandler) CreateGame(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.gameService.CreateGame(c.Request.Context(), userID.(int64), req.NumPlayers)
+	resp, err := h.gameService.CreateGame(c.Request.Context(), userID.(int64), &req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create game"})
 		return
 	}
 
+	publishLobbyEvent(h.hub, LobbyGameCreated, resp.Game, userID.(int64))
+
 	c.JSON(http.StatusCreated, resp)
 }
 
@@ -107,12 +117,16 @@ func (h *GameHandler) JoinGame(c *gin.Context) {
 			c.JSON(http.StatusConflict, gin.H{"error": "Game has already started"})
 		case service.ErrAlreadyInGame:
 			c.JSON(http.StatusConflict, gin.H{"error": "You are already in this game"})
+		case service.ErrGuestRanked:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Guests cannot play ranked games"})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to join game"})
 		}
 		return
 	}
 
+	publishLobbyEvent(h.hub, LobbyPlayerJoined, game, userID.(int64))
+
 	c.JSON(http.StatusOK, gin.H{"game": game})
 }
 
@@ -133,6 +147,10 @@ func (h *GameHandler) LeaveGame(c *gin.Context) {
 		return
 	}
 
+	if game, err := h.gameService.GetGameByID(c.Request.Context(), gameID); err == nil {
+		publishLobbyEvent(h.hub, LobbyPlayerLeft, game, userID.(int64))
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Left game successfully"})
 }
 
@@ -162,5 +180,138 @@ func (h *GameHandler) StartGame(c *gin.Context) {
 		return
 	}
 
+	publishLobbyEvent(h.hub, LobbyGameStarted, game, userID.(int64))
+
+	// A bot may hold the first turn
+	h.bots.Notify(game.ID)
+
+	c.JSON(http.StatusOK, gin.H{"game": game})
+}
+
+// AddBot seats a bot in a waiting game
+func (h *GameHandler) AddBot(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	gameID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	var req models.AddBotRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	game, err := h.gameService.AddBot(c.Request.Context(), gameID, userID.(int64), req.Strategy, req.BotUserID)
+	if err != nil {
+		switch err {
+		case service.ErrUnknownStrategy:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown bot strategy"})
+		case service.ErrNotABot:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "External bots need a registered bot account"})
+		case service.ErrNotBotOwner:
+			c.JSON(http.StatusForbidden, gin.H{"error": "You can only seat bots you registered"})
+		case service.ErrAlreadyInGame:
+			c.JSON(http.StatusConflict, gin.H{"error": "Bot is already in this game"})
+		case service.ErrGameNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		case service.ErrNotGameCreator:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the game creator can add bots"})
+		case service.ErrGameStarted:
+			c.JSON(http.StatusConflict, gin.H{"error": "Game has already started"})
+		case service.ErrGameFull:
+			c.JSON(http.StatusConflict, gin.H{"error": "Game is full"})
+		case service.ErrBotsUnranked:
+			c.JSON(http.StatusConflict, gin.H{"error": "Bots cannot be seated in ranked games"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add bot"})
+		}
+		return
+	}
+
+	publishLobbyEvent(h.hub, LobbyPlayerJoined, game, 0)
+
+	c.JSON(http.StatusOK, gin.H{"game": game})
+}
+
+// RemoveBot unseats a bot from a waiting game
+func (h *GameHandler) RemoveBot(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	gameID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	botUserID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	game, err := h.gameService.RemoveBot(c.Request.Context(), gameID, userID.(int64), botUserID)
+	if err != nil {
+		switch err {
+		case service.ErrGameNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		case service.ErrNotGameCreator:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the game creator can remove bots"})
+		case service.ErrGameStarted:
+			c.JSON(http.StatusConflict, gin.H{"error": "Game has already started"})
+		case service.ErrNotABot:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Bot not found in this game"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove bot"})
+		}
+		return
+	}
+
+	publishLobbyEvent(h.hub, LobbyPlayerLeft, game, botUserID)
+
 	c.JSON(http.StatusOK, gin.H{"game": game})
 }
+
+// GetHints suggests the caller's best moves in a game with hints enabled
+func (h *GameHandler) GetHints(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	gameID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "3"))
+	if limit < 1 || limit > 10 {
+		limit = 3
+	}
+
+	analysis, err := h.gameService.GetHints(c.Request.Context(), gameID, userID.(int64), limit)
+	if err != nil {
+		switch err {
+		case service.ErrGameNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		case service.ErrHintsDisabled:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Hints are not enabled for this game"})
+		case service.ErrNotAPlayer:
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not playing in this game"})
+		case service.ErrGameNotInProgress:
+			c.JSON(http.StatusConflict, gin.H{"error": "Game is not in progress"})
+		case gamelogic.ErrNotYourTurn:
+			c.JSON(http.StatusConflict, gin.H{"error": "It's not your turn"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get hints"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, analysis)
+}
+
+// ListBotStrategies lists the strategies bots can be seated with
+func (h *GameHandler) ListBotStrategies(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"strategies": bot.Names(),
+		"default":    bot.DefaultStrategy,
+	})
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/service"
+	"splendor-backend/pkg/websocket"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GameOverService records and serves the results of finished games
+type GameOverService interface {
+	FinishGame(ctx context.Context, gameID int64) (*models.FinalStandings, error)
+	FinishPending(ctx context.Context) ([]*models.FinalStandings, error)
+	GetStandings(ctx context.Context, gameID int64) (*models.FinalStandings, error)
+}
+
+type GameOverHandler struct {
+	gameOverService GameOverService
+	hub             *websocket.Hub
+}
+
+func NewGameOverHandler(gameOverService GameOverService, hub *websocket.Hub) *GameOverHandler {
+	return &GameOverHandler{
+		gameOverService: gameOverService,
+		hub:             hub,
+	}
+}
+
+// OnGameFinished runs the game-over pipeline for a game that just ended and
+// sends its players and spectators the final scoreboard
+func (h *GameOverHandler) OnGameFinished(ctx context.Context, gameID int64) {
+	standings, err := h.gameOverService.FinishGame(ctx, gameID)
+	if err == service.ErrAlreadyFinalized {
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to finish game %d: %v", gameID, err)
+		return
+	}
+
+	h.announce(standings)
+}
+
+// Resume finishes games that ended while the server was stopping, then
+// retries games whose pipeline failed every interval until ctx is cancelled
+func (h *GameOverHandler) Resume(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		finished, err := h.gameOverService.FinishPending(ctx)
+		if err != nil {
+			log.Printf("Failed to finish pending games: %v", err)
+		}
+
+		for _, standings := range finished {
+			h.announce(standings)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetResults returns the final scoreboard of a finished game
+func (h *GameOverHandler) GetResults(c *gin.Context) {
+	gameID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	standings, err := h.gameOverService.GetStandings(c.Request.Context(), gameID)
+	if err != nil {
+		switch err {
+		case service.ErrGameNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		case service.ErrGameNotFinished:
+			c.JSON(http.StatusConflict, gin.H{"error": "Game is not finished"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get results"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, standings)
+}
+
+func (h *GameOverHandler) announce(standings *models.FinalStandings) {
+	messageBytes, err := json.Marshal(&websocket.Message{
+		Type:    "game_over",
+		Payload: standings,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal game over message: %v", err)
+		return
+	}
+
+	h.hub.BroadcastToGame(strconv.FormatInt(standings.GameID, 10), messageBytes)
+}
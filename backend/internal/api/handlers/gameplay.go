@@ -3,9 +3,12 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"log"
 	"net/http"
 	"strconv"
 
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
 	"splendor-backend/pkg/websocket"
 
 	"github.com/gin-gonic/gin"
@@ -14,18 +17,27 @@ import (
 type GameplayHandler struct {
 	engine GameplayEngine
 	hub    *websocket.Hub
+	bots   BotNotifier
 }
 
 type GameplayEngine interface {
 	TakeGems(ctx context.Context, gameID, userID int64, gems map[string]int) error
 	PurchaseCard(ctx context.Context, gameID, userID int64, cardID int64, fromReserve bool) error
 	ReserveCard(ctx context.Context, gameID, userID int64, cardID int64, tier int) error
+	Pass(ctx context.Context, gameID, userID int64) error
+	GetGameStateViews(ctx context.Context, gameID int64) (map[int64]*models.FullGameState, error)
 }
 
-func NewGameplayHandler(engine GameplayEngine, hub *websocket.Hub) *GameplayHandler {
+// BotNotifier is told when a game's turn may have passed to a bot
+type BotNotifier interface {
+	Notify(gameID int64)
+}
+
+func NewGameplayHandler(engine GameplayEngine, hub *websocket.Hub, bots BotNotifier) *GameplayHandler {
 	return &GameplayHandler{
 		engine: engine,
 		hub:    hub,
+		bots:   bots,
 	}
 }
 
@@ -65,7 +77,7 @@ func (h *GameplayHandler) TakeGems(c *gin.Context) {
 	}
 
 	// Broadcast game update to all connected clients
-	h.broadcastGameUpdate(gameIDStr, "game_update", gin.H{
+	h.announceMove(c.Request.Context(), gameID, gin.H{
 		"action": "take_gems",
 		"user_id": userID,
 	})
@@ -95,7 +107,7 @@ func (h *GameplayHandler) PurchaseCard(c *gin.Context) {
 	}
 
 	// Broadcast game update to all connected clients
-	h.broadcastGameUpdate(gameIDStr, "game_update", gin.H{
+	h.announceMove(c.Request.Context(), gameID, gin.H{
 		"action": "purchase_card",
 		"user_id": userID,
 		"card_id": req.CardID,
@@ -126,7 +138,7 @@ func (h *GameplayHandler) ReserveCard(c *gin.Context) {
 	}
 
 	// Broadcast game update to all connected clients
-	h.broadcastGameUpdate(gameIDStr, "game_update", gin.H{
+	h.announceMove(c.Request.Context(), gameID, gin.H{
 		"action": "reserve_card",
 		"user_id": userID,
 		"card_id": req.CardID,
@@ -135,6 +147,64 @@ func (h *GameplayHandler) ReserveCard(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Card reserved successfully"})
 }
 
+// Pass handles the pass action, allowed only when no other move is possible
+func (h *GameplayHandler) Pass(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	gameIDStr := c.Param("id")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	if err := h.engine.Pass(c.Request.Context(), gameID, userID.(int64)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Broadcast game update to all connected clients
+	h.announceMove(c.Request.Context(), gameID, gin.H{
+		"action": "pass",
+		"user_id": userID,
+	})
+
+	c.JSON(http.StatusOK, gin.H{"message": "Turn passed successfully"})
+}
+
+// BroadcastBotMove announces a move played by a server-side bot
+func (h *GameplayHandler) BroadcastBotMove(ctx context.Context, gameID, userID int64, action *gamelogic.Action) {
+	h.announceMove(ctx, gameID, gin.H{
+		"action":  action.Type,
+		"user_id": userID,
+		"card_id": action.CardID,
+		"bot":     true,
+	})
+}
+
+// BroadcastState pushes the current state of a game to its players and
+// spectators, e.g. after a move was taken back
+func (h *GameplayHandler) BroadcastState(ctx context.Context, gameID int64) {
+	h.broadcastStateViews(ctx, gameID, strconv.FormatInt(gameID, 10))
+}
+
+// announceMove broadcasts a move and the resulting state, announces the end
+// of the game if the move finished it, and wakes the bot runner
+func (h *GameplayHandler) announceMove(ctx context.Context, gameID int64, payload gin.H) {
+	gameIDStr := strconv.FormatInt(gameID, 10)
+
+	h.broadcastGameUpdate(gameIDStr, "game_update", payload)
+	state := h.broadcastStateViews(ctx, gameID, gameIDStr)
+
+	// A purchase can end the game
+	if state != nil && state.Game.Status == models.GameStatusCompleted {
+		state.Game.Players = state.Players
+		publishLobbyEvent(h.hub, LobbyGameFinished, state.Game, 0)
+		return
+	}
+
+	h.bots.Notify(gameID)
+}
+
 // broadcastGameUpdate broadcasts a game update message to all connected clients
 func (h *GameplayHandler) broadcastGameUpdate(gameID string, msgType string, payload gin.H) {
 	message := map[string]interface{}{
@@ -149,3 +219,39 @@ func (h *GameplayHandler) broadcastGameUpdate(gameID string, msgType string, pay
 
 	h.hub.BroadcastToGame(gameID, messageBytes)
 }
+
+// broadcastStateViews pushes each seated player their own view of the board
+// and the redacted view to spectators. It returns the spectator view, or nil
+// if the state could not be loaded.
+func (h *GameplayHandler) broadcastStateViews(ctx context.Context, gameID int64, gameIDStr string) *models.FullGameState {
+	views, err := h.engine.GetGameStateViews(ctx, gameID)
+	if err != nil {
+		log.Printf("Failed to get game state views: %v", err)
+		return nil
+	}
+
+	for userID, view := range views {
+		if userID == 0 {
+			continue
+		}
+
+		messageBytes, err := json.Marshal(&websocket.Message{
+			Type:    "state",
+			Payload: view,
+		})
+		if err != nil {
+			continue
+		}
+		h.hub.SendToUser(gameIDStr, userID, messageBytes)
+	}
+
+	messageBytes, err := json.Marshal(&websocket.Message{
+		Type:    "spectator_state",
+		Payload: views[0],
+	})
+	if err == nil {
+		h.hub.BroadcastToSpectators(gameIDStr, messageBytes)
+	}
+
+	return views[0]
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HistoryService serves finished games
+type HistoryService interface {
+	ListUserGames(ctx context.Context, userID int64, filter *models.MatchHistoryFilter) (*models.MatchHistoryResponse, error)
+	GetSummary(ctx context.Context, gameID int64) (*models.GameSummary, error)
+	GetHeadToHead(ctx context.Context, userID, opponentID int64) (*models.HeadToHead, error)
+	GetRivals(ctx context.Context, userID int64, limit int) ([]*models.HeadToHead, error)
+}
+
+type HistoryHandler struct {
+	historyService HistoryService
+}
+
+func NewHistoryHandler(historyService HistoryService) *HistoryHandler {
+	return &HistoryHandler{
+		historyService: historyService,
+	}
+}
+
+// GetUserGames lists a user's finished games. It takes optional filters:
+// players (seat count), from and to (RFC 3339 times or YYYY-MM-DD dates,
+// to is inclusive for dates) and opponent (a user ID).
+func (h *HistoryHandler) GetUserGames(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	filter := &models.MatchHistoryFilter{}
+	filter.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "20"))
+	filter.Offset, _ = strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	if players := c.Query("players"); players != "" {
+		filter.NumPlayers, err = strconv.Atoi(players)
+		if err != nil || filter.NumPlayers < 2 || filter.NumPlayers > 4 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "players must be between 2 and 4"})
+			return
+		}
+	}
+
+	if opponent := c.Query("opponent"); opponent != "" {
+		filter.OpponentID, err = strconv.ParseInt(opponent, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid opponent ID"})
+			return
+		}
+	}
+
+	if from := c.Query("from"); from != "" {
+		t, _, err := parseHistoryDate(from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date"})
+			return
+		}
+		filter.From = &t
+	}
+
+	if to := c.Query("to"); to != "" {
+		t, dateOnly, err := parseHistoryDate(to)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date"})
+			return
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		filter.To = &t
+	}
+
+	resp, err := h.historyService.ListUserGames(c.Request.Context(), userID, filter)
+	if err != nil {
+		if err == service.ErrUserNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get match history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetSummary returns a finished game's scoreboard and timeline
+func (h *HistoryHandler) GetSummary(c *gin.Context) {
+	gameID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
+		return
+	}
+
+	summary, err := h.historyService.GetSummary(c.Request.Context(), gameID)
+	if err != nil {
+		switch err {
+		case service.ErrGameNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		case service.ErrGameNotFinished:
+			c.JSON(http.StatusConflict, gin.H{"error": "Game is not finished"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get game summary"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// GetVersus returns a user's head-to-head record against another user
+func (h *HistoryHandler) GetVersus(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	opponentID, err := strconv.ParseInt(c.Param("otherId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid opponent ID"})
+		return
+	}
+
+	record, err := h.historyService.GetHeadToHead(c.Request.Context(), userID, opponentID)
+	if err != nil {
+		switch err {
+		case service.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		case service.ErrSameUser:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get head-to-head record"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+// GetRivals lists a user's most-played human opponents with their records
+func (h *HistoryHandler) GetRivals(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	rivals, err := h.historyService.GetRivals(c.Request.Context(), userID, limit)
+	if err != nil {
+		if err == service.ErrUserNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get rivals"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rivals": rivals})
+}
+
+// parseHistoryDate accepts an RFC 3339 time or a YYYY-MM-DD date (UTC),
+// reporting which one it got
+func parseHistoryDate(value string) (time.Time, bool, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"strconv"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/pkg/websocket"
+)
+
+// Lobby event types pushed on the lobby channel
+const (
+	LobbyGameCreated  = "game_created"
+	LobbyPlayerJoined = "player_joined"
+	LobbyPlayerLeft   = "player_left"
+	LobbyGameStarted  = "game_started"
+	LobbyGameFinished = "game_finished"
+)
+
+// publishLobbyEvent announces a game change on the lobby channel. Events that
+// change a room's seating are also sent to the game's own room so waiting
+// players see them.
+func publishLobbyEvent(hub *websocket.Hub, eventType string, game *models.Game, userID int64) {
+	payload := map[string]interface{}{
+		"game": lobbyGame(game),
+	}
+	if userID != 0 {
+		payload["user_id"] = userID
+	}
+
+	messageBytes, err := json.Marshal(&websocket.Message{
+		Type:    eventType,
+		Payload: payload,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal lobby event: %v", err)
+		return
+	}
+
+	hub.BroadcastToLobby(messageBytes)
+
+	switch eventType {
+	case LobbyPlayerJoined, LobbyPlayerLeft, LobbyGameStarted:
+		hub.BroadcastToGame(strconv.FormatInt(game.ID, 10), messageBytes)
+	}
+}
+
+// lobbyGame summarizes a game for the lobby channel
+func lobbyGame(game *models.Game) *models.LobbyGame {
+	summary := &models.LobbyGame{
+		ID:         game.ID,
+		Status:     game.Status,
+		NumPlayers: game.NumPlayers,
+		Players:    []string{},
+		Ranked:     game.Ranked,
+		WinnerID:   game.WinnerID,
+	}
+
+	for _, player := range game.Players {
+		if !player.IsActive {
+			continue
+		}
+		summary.SeatedPlayers++
+		if player.User != nil {
+			summary.Players = append(summary.Players, player.User.Username)
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/service"
+	"splendor-backend/pkg/websocket"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MatchmakingService runs the matchmaking queue
+type MatchmakingService interface {
+	Join(ctx context.Context, userID int64, req *models.JoinQueueRequest) (*models.QueueStatus, error)
+	Leave(ctx context.Context, userID int64) error
+	Status(ctx context.Context, userID int64) (*models.QueueStatus, error)
+}
+
+type MatchmakingHandler struct {
+	matchmakingService MatchmakingService
+	hub                *websocket.Hub
+}
+
+func NewMatchmakingHandler(matchmakingService MatchmakingService, hub *websocket.Hub) *MatchmakingHandler {
+	return &MatchmakingHandler{
+		matchmakingService: matchmakingService,
+		hub:                hub,
+	}
+}
+
+// JoinQueue puts the current user in the matchmaking queue
+func (h *MatchmakingHandler) JoinQueue(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req models.JoinQueueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status, err := h.matchmakingService.Join(c.Request.Context(), userID.(int64), &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to join queue"})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// GetQueueStatus reports the current user's place in the queue, or the game
+// they were matched into
+func (h *MatchmakingHandler) GetQueueStatus(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	status, err := h.matchmakingService.Status(c.Request.Context(), userID.(int64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get queue status"})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// LeaveQueue takes the current user out of the queue
+func (h *MatchmakingHandler) LeaveQueue(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	if err := h.matchmakingService.Leave(c.Request.Context(), userID.(int64)); err != nil {
+		if err == service.ErrNotQueued {
+			c.JSON(http.StatusNotFound, gin.H{"error": "You are not in the queue"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to leave queue"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Left queue"})
+}
+
+// AnnounceMatch tells each matched player about their game over their lobby
+// connection and announces the new game to the lobby, which sees it created
+// and started at once
+func (h *MatchmakingHandler) AnnounceMatch(ctx context.Context, game *models.Game) {
+	messageBytes, err := json.Marshal(&websocket.Message{
+		Type: "match_found",
+		Payload: map[string]interface{}{
+			"game_id":   game.ID,
+			"room_code": game.RoomCode,
+			"game":      game,
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to marshal match message: %v", err)
+		return
+	}
+
+	for _, player := range game.Players {
+		h.hub.SendToUser(websocket.LobbyRoom, player.UserID, messageBytes)
+	}
+
+	publishLobbyEvent(h.hub, LobbyGameCreated, game, 0)
+	publishLobbyEvent(h.hub, LobbyGameStarted, game, 0)
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RatingService serves players' skill ratings
+type RatingService interface {
+	GetUserRating(ctx context.Context, userID int64, limit int) (*models.RatingResponse, error)
+}
+
+type RatingHandler struct {
+	ratingService RatingService
+}
+
+func NewRatingHandler(ratingService RatingService) *RatingHandler {
+	return &RatingHandler{
+		ratingService: ratingService,
+	}
+}
+
+// GetUserRating returns a player's rating and recent rating history
+func (h *RatingHandler) GetUserRating(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	resp, err := h.ratingService.GetUserRating(c.Request.Context(), userID, limit)
+	if err != nil {
+		if err == service.ErrUserNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get rating"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
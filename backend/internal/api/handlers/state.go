@@ -15,7 +15,7 @@ type StateHandler struct {
 }
 
 type GameStateEngine interface {
-	GetGameState(ctx context.Context, gameID int64) (*models.FullGameState, error)
+	GetGameState(ctx context.Context, gameID, viewerID int64) (*models.FullGameState, error)
 }
 
 func NewStateHandler(engine GameStateEngine) *StateHandler {
@@ -24,8 +24,16 @@ func NewStateHandler(engine GameStateEngine) *StateHandler {
 	}
 }
 
-// GetGameState retrieves the full game state
+// GetGameState retrieves the game state as seen by the caller. Anonymous
+// callers and users who are not seated get the spectator view, unless the
+// game is running with a spectator delay: they follow it over the WebSocket,
+// which holds the board back for the delay.
 func (h *StateHandler) GetGameState(c *gin.Context) {
+	var viewerID int64
+	if userID, exists := c.Get("userID"); exists {
+		viewerID = userID.(int64)
+	}
+
 	gameIDStr := c.Param("id")
 	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
 	if err != nil {
@@ -33,11 +41,29 @@ func (h *StateHandler) GetGameState(c *gin.Context) {
 		return
 	}
 
-	state, err := h.engine.GetGameState(c.Request.Context(), gameID)
+	state, err := h.engine.GetGameState(c.Request.Context(), gameID, viewerID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get game state"})
 		return
 	}
 
+	if state.Game.Status == models.GameStatusInProgress && state.Game.SpectatorDelaySeconds > 0 && !isSeated(state, viewerID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Spectators of this game must watch over the WebSocket"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"state": state})
 }
+
+// isSeated reports whether viewerID has an active seat in the game
+func isSeated(state *models.FullGameState, viewerID int64) bool {
+	if viewerID == 0 {
+		return false
+	}
+	for _, player := range state.Players {
+		if player.UserID == viewerID && player.IsActive {
+			return true
+		}
+	}
+	return false
+}
@@ -6,22 +6,32 @@ import (
 	"strconv"
 
 	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
 
 type StatsHandler struct {
-	statsService StatsService
+	statsService  StatsService
+	seasonService SeasonService
 }
 
 type StatsService interface {
 	GetUserStats(ctx context.Context, userID int64) (*models.GameStatistics, error)
 	GetLeaderboard(ctx context.Context, limit, offset int) ([]*models.LeaderboardEntry, error)
+	GetCardStats(ctx context.Context) (*models.CardStatsResponse, error)
 }
 
-func NewStatsHandler(statsService StatsService) *StatsHandler {
+// SeasonService serves ranked seasons and their standings
+type SeasonService interface {
+	ListSeasons(ctx context.Context) ([]*models.Season, error)
+	GetLeaderboard(ctx context.Context, season string, limit, offset int) (*models.Season, []*models.LeaderboardEntry, error)
+}
+
+func NewStatsHandler(statsService StatsService, seasonService SeasonService) *StatsHandler {
 	return &StatsHandler{
-		statsService: statsService,
+		statsService:  statsService,
+		seasonService: seasonService,
 	}
 }
 
@@ -51,6 +61,25 @@ func (h *StatsHandler) GetLeaderboard(c *gin.Context) {
 	limit, _ := strconv.Atoi(limitStr)
 	offset, _ := strconv.Atoi(offsetStr)
 
+	// A season's standings, by ID or "current"
+	if season := c.Query("season"); season != "" {
+		found, leaderboard, err := h.seasonService.GetLeaderboard(c.Request.Context(), season, limit, offset)
+		if err != nil {
+			switch err {
+			case service.ErrSeasonNotFound:
+				c.JSON(http.StatusNotFound, gin.H{"error": "Season not found"})
+			case service.ErrNoActiveSeason:
+				c.JSON(http.StatusNotFound, gin.H{"error": "No season is running"})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get leaderboard"})
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"season": found, "leaderboard": leaderboard})
+		return
+	}
+
 	leaderboard, err := h.statsService.GetLeaderboard(c.Request.Context(), limit, offset)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get leaderboard"})
@@ -59,3 +88,26 @@ func (h *StatsHandler) GetLeaderboard(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"leaderboard": leaderboard})
 }
+
+// ListSeasons lists ranked seasons, newest first
+func (h *StatsHandler) ListSeasons(c *gin.Context) {
+	seasons, err := h.seasonService.ListSeasons(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list seasons"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"seasons": seasons})
+}
+
+// GetCardStats returns card, noble and seat statistics across all finished
+// games
+func (h *StatsHandler) GetCardStats(c *gin.Context) {
+	stats, err := h.statsService.GetCardStats(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get card stats"})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/service"
+	"splendor-backend/pkg/websocket"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TournamentService organizes tournaments
+type TournamentService interface {
+	Create(ctx context.Context, userID int64, req *models.CreateTournamentRequest) (*models.Tournament, error)
+	List(ctx context.Context, status string, limit, offset int) ([]*models.Tournament, error)
+	Get(ctx context.Context, tournamentID int64) (*models.Tournament, error)
+	Register(ctx context.Context, tournamentID, userID int64) (*models.Tournament, error)
+	Unregister(ctx context.Context, tournamentID, userID int64) error
+	Start(ctx context.Context, tournamentID, userID int64) (*models.Tournament, error)
+	GetStandings(ctx context.Context, tournamentID int64) ([]*models.TournamentStanding, error)
+}
+
+type TournamentHandler struct {
+	tournamentService TournamentService
+	hub               *websocket.Hub
+}
+
+func NewTournamentHandler(tournamentService TournamentService, hub *websocket.Hub) *TournamentHandler {
+	return &TournamentHandler{
+		tournamentService: tournamentService,
+		hub:               hub,
+	}
+}
+
+// CreateTournament opens a tournament for registration, organized by the
+// current user
+func (h *TournamentHandler) CreateTournament(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var req models.CreateTournamentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tournament, err := h.tournamentService.Create(c.Request.Context(), userID.(int64), &req)
+	if err != nil {
+		if err == service.ErrInvalidTournamentRules {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create tournament"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, tournament)
+}
+
+// ListTournaments lists tournaments, optionally filtered by status
+func (h *TournamentHandler) ListTournaments(c *gin.Context) {
+	status := c.Query("status")
+	switch status {
+	case "", models.TournamentRegistration, models.TournamentInProgress, models.TournamentCompleted:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	tournaments, err := h.tournamentService.List(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list tournaments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tournaments": tournaments})
+}
+
+// GetTournament returns a tournament with its players and tables
+func (h *TournamentHandler) GetTournament(c *gin.Context) {
+	tournamentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+		return
+	}
+
+	tournament, err := h.tournamentService.Get(c.Request.Context(), tournamentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tournament)
+}
+
+// Register signs the current user up for a tournament
+func (h *TournamentHandler) Register(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	tournamentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+		return
+	}
+
+	tournament, err := h.tournamentService.Register(c.Request.Context(), tournamentID, userID.(int64))
+	if err != nil {
+		switch err {
+		case service.ErrTournamentNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+		case service.ErrTournamentStarted, service.ErrAlreadyRegistered:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tournament)
+}
+
+// Unregister withdraws the current user from a tournament
+func (h *TournamentHandler) Unregister(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	tournamentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+		return
+	}
+
+	err = h.tournamentService.Unregister(c.Request.Context(), tournamentID, userID.(int64))
+	if err != nil {
+		switch err {
+		case service.ErrTournamentNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+		case service.ErrNotRegistered:
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case service.ErrTournamentStarted:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unregister"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Unregistered"})
+}
+
+// StartTournament closes registration and seats the first round
+func (h *TournamentHandler) StartTournament(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	tournamentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+		return
+	}
+
+	tournament, err := h.tournamentService.Start(c.Request.Context(), tournamentID, userID.(int64))
+	if err != nil {
+		switch err {
+		case service.ErrTournamentNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+		case service.ErrNotOrganizer:
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case service.ErrTournamentStarted, service.ErrNotEnoughPlayers:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start tournament"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tournament)
+}
+
+// GetStandings returns a tournament's current standings
+func (h *TournamentHandler) GetStandings(c *gin.Context) {
+	tournamentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tournament ID"})
+		return
+	}
+
+	standings, err := h.tournamentService.GetStandings(c.Request.Context(), tournamentID)
+	if err != nil {
+		switch err {
+		case service.ErrTournamentNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Tournament not found"})
+		case service.ErrTournamentNotStarted:
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get standings"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"standings": standings})
+}
+
+// AnnounceTable tells each player seated at a tournament table about their
+// game over their lobby connection and announces the new game to the lobby,
+// which sees it created and started at once
+func (h *TournamentHandler) AnnounceTable(ctx context.Context, tournament *models.Tournament, game *models.Game) {
+	messageBytes, err := json.Marshal(&websocket.Message{
+		Type: "tournament_table",
+		Payload: map[string]interface{}{
+			"tournament_id": tournament.ID,
+			"round":         tournament.CurrentRound,
+			"game_id":       game.ID,
+			"room_code":     game.RoomCode,
+			"game":          game,
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to marshal tournament table message: %v", err)
+		return
+	}
+
+	for _, player := range game.Players {
+		h.hub.SendToUser(websocket.LobbyRoom, player.UserID, messageBytes)
+	}
+
+	publishLobbyEvent(h.hub, LobbyGameCreated, game, 0)
+	publishLobbyEvent(h.hub, LobbyGameStarted, game, 0)
+}
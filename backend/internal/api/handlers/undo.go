@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"splendor-backend/internal/domain/models"
+	wshub "splendor-backend/pkg/websocket"
+)
+
+type UndoService interface {
+	RequestUndo(ctx context.Context, gameID, userID int64) (*models.UndoRequest, error)
+	RespondUndo(ctx context.Context, gameID, userID int64, approve bool) (*models.UndoRequest, error)
+}
+
+// StateBroadcaster pushes a game's current state to everyone watching it
+type StateBroadcaster interface {
+	BroadcastState(ctx context.Context, gameID int64)
+}
+
+// handleUndoRequest opens a take-back request for the sender's last move
+func (h *WebSocketHandler) handleUndoRequest(client *wshub.Client) {
+	gameID, err := strconv.ParseInt(client.GameID, 10, 64)
+	if err != nil || client.Spectator {
+		return
+	}
+
+	req, err := h.undoService.RequestUndo(context.Background(), gameID, client.UserID)
+	if err != nil {
+		h.sendUndoError(client, err)
+		return
+	}
+
+	h.announceUndo(gameID, client, req)
+}
+
+// handleUndoResponse records the sender's approval or denial of the open
+// take-back request
+func (h *WebSocketHandler) handleUndoResponse(client *wshub.Client, msg *wshub.Message) {
+	gameID, err := strconv.ParseInt(client.GameID, 10, 64)
+	if err != nil || client.Spectator {
+		return
+	}
+
+	var payload struct {
+		Approve bool `json:"approve"`
+	}
+	if raw, err := json.Marshal(msg.Payload); err == nil {
+		json.Unmarshal(raw, &payload)
+	}
+
+	req, err := h.undoService.RespondUndo(context.Background(), gameID, client.UserID, payload.Approve)
+	if err != nil {
+		h.sendUndoError(client, err)
+		return
+	}
+
+	h.announceUndo(gameID, client, req)
+}
+
+// announceUndo tells the game how a request stands and, once it has been
+// applied, pushes the restored state
+func (h *WebSocketHandler) announceUndo(gameID int64, client *wshub.Client, req *models.UndoRequest) {
+	msgType := "undo_requested"
+	switch req.Status {
+	case models.UndoApplied:
+		msgType = "undo_applied"
+	case models.UndoDenied:
+		msgType = "undo_denied"
+	}
+
+	h.broadcastToGame(client.GameID, &wshub.Message{
+		Type: msgType,
+		Payload: map[string]interface{}{
+			"request": req,
+			"user_id": client.UserID,
+		},
+	})
+
+	if req.Status == models.UndoApplied {
+		h.states.BroadcastState(context.Background(), gameID)
+	}
+}
+
+func (h *WebSocketHandler) sendUndoError(client *wshub.Client, err error) {
+	h.sendToClient(client, &wshub.Message{
+		Type:    "undo_error",
+		Payload: map[string]interface{}{"error": err.Error()},
+	})
+}
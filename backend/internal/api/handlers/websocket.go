@@ -1,12 +1,14 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"net/http"
 	"strconv"
 	"time"
 
+	"splendor-backend/internal/domain/models"
 	"splendor-backend/pkg/jwt"
 	wshub "splendor-backend/pkg/websocket"
 
@@ -24,14 +26,36 @@ var upgrader = websocket.Upgrader{
 }
 
 type WebSocketHandler struct {
-	hub       *wshub.Hub
-	jwtSecret string
+	hub         *wshub.Hub
+	games       GameLookup
+	engine      GameStateEngine
+	chatService ChatService
+	undoService UndoService
+	states      StateBroadcaster
+	jwtSecret   string
 }
 
-func NewWebSocketHandler(hub *wshub.Hub, jwtSecret string) *WebSocketHandler {
+type GameLookup interface {
+	GetGameByID(ctx context.Context, gameID int64) (*models.Game, error)
+}
+
+type ChatService interface {
+	SendMessage(ctx context.Context, gameID, userID int64, username, text string) (*models.ChatMessage, error)
+	GetHistory(ctx context.Context, gameID, viewerID, beforeID int64, limit int) ([]*models.ChatMessage, error)
+	MutePlayer(ctx context.Context, gameID, userID, mutedUserID int64) error
+	UnmutePlayer(ctx context.Context, gameID, userID, mutedUserID int64) error
+	GetMutedUsers(ctx context.Context, gameID, userID int64) ([]int64, error)
+}
+
+func NewWebSocketHandler(hub *wshub.Hub, games GameLookup, engine GameStateEngine, chatService ChatService, undoService UndoService, states StateBroadcaster, jwtSecret string) *WebSocketHandler {
 	return &WebSocketHandler{
-		hub:       hub,
-		jwtSecret: jwtSecret,
+		hub:         hub,
+		games:       games,
+		engine:      engine,
+		chatService: chatService,
+		undoService: undoService,
+		states:      states,
+		jwtSecret:   jwtSecret,
 	}
 }
 
@@ -39,7 +63,7 @@ func NewWebSocketHandler(hub *wshub.Hub, jwtSecret string) *WebSocketHandler {
 func (h *WebSocketHandler) HandleConnection(c *gin.Context) {
 	// Get game ID from URL
 	gameIDStr := c.Param("id")
-	_, err := strconv.ParseInt(gameIDStr, 10, 64)
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid game ID"})
 		return
@@ -59,6 +83,21 @@ func (h *WebSocketHandler) HandleConnection(c *gin.Context) {
 		return
 	}
 
+	game, err := h.games.GetGameByID(c.Request.Context(), gameID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Game not found"})
+		return
+	}
+
+	// Users without an active seat join as spectators
+	spectator := true
+	for _, p := range game.Players {
+		if p.UserID == claims.UserID && p.IsActive {
+			spectator = false
+			break
+		}
+	}
+
 	// Upgrade connection
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
@@ -68,32 +107,143 @@ func (h *WebSocketHandler) HandleConnection(c *gin.Context) {
 
 	// Create client
 	client := &wshub.Client{
-		ID:     strconv.FormatInt(claims.UserID, 10),
-		GameID: gameIDStr,
-		UserID: claims.UserID,
-		Conn:   conn,
-		Send:   make(chan []byte, 256),
-		Hub:    h.hub,
+		ID:       strconv.FormatInt(claims.UserID, 10),
+		GameID:   gameIDStr,
+		UserID:   claims.UserID,
+		Username: claims.Username,
+		Conn:     conn,
+		Send:     make(chan []byte, 256),
+		Hub:      h.hub,
+
+		Spectator: spectator,
 	}
 
+	delay := time.Duration(game.SpectatorDelaySeconds) * time.Second
+	if spectator {
+		h.hub.SetSpectatorDelay(gameIDStr, delay)
+	}
+
+	// Restore the user's chat mutes for this game
+	muted, err := h.chatService.GetMutedUsers(c.Request.Context(), gameID, claims.UserID)
+	if err != nil {
+		log.Printf("Failed to load chat mutes: %v", err)
+	}
+	client.SetMuted(muted)
+
 	// Register client
 	h.hub.RegisterClient(client)
 
 	// Start goroutines
-	go h.writePump(client, conn)
+	go writePump(client, conn)
 	go h.readPump(client, conn)
 
 	// Send welcome message
+	role := "player"
+	if spectator {
+		role = "spectator"
+	}
+
 	welcomeMsg := wshub.Message{
 		Type: "connected",
 		Payload: map[string]interface{}{
 			"message": "Connected to game",
 			"game_id": gameIDStr,
 			"user_id": claims.UserID,
+			"role":    role,
 		},
 	}
 	msgBytes, _ := json.Marshal(welcomeMsg)
-	client.Send <- msgBytes
+	h.hub.SendToClient(client, msgBytes)
+
+	// Players get their own view of the board straight away; spectators get
+	// the redacted board once their delay has passed
+	if game.Status != models.GameStatusWaiting {
+		if spectator {
+			h.sendInitialState(c.Request.Context(), client, gameID, 0, "spectator_state", delay)
+		} else {
+			h.sendInitialState(c.Request.Context(), client, gameID, claims.UserID, "state", 0)
+		}
+	}
+}
+
+// sendInitialState captures viewerID's view of the board now and delivers it
+// to the client after delay, so it lines up with delayed broadcasts
+func (h *WebSocketHandler) sendInitialState(ctx context.Context, client *wshub.Client, gameID, viewerID int64, msgType string, delay time.Duration) {
+	state, err := h.engine.GetGameState(ctx, gameID, viewerID)
+	if err != nil {
+		log.Printf("Failed to get game state: %v", err)
+		return
+	}
+
+	msgBytes, err := json.Marshal(&wshub.Message{
+		Type:    msgType,
+		Payload: state,
+	})
+	if err != nil {
+		log.Printf("Failed to marshal message: %v", err)
+		return
+	}
+
+	if delay == 0 {
+		h.hub.SendToClient(client, msgBytes)
+		return
+	}
+
+	time.AfterFunc(delay, func() {
+		h.hub.SendToClient(client, msgBytes)
+	})
+}
+
+// HandleLobbyConnection subscribes a client to the global lobby channel.
+// A token is optional; anonymous clients receive the same events.
+func (h *WebSocketHandler) HandleLobbyConnection(c *gin.Context) {
+	var userID int64
+	var username string
+	if token := c.Query("token"); token != "" {
+		claims, err := jwt.ValidateToken(token, h.jwtSecret)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			return
+		}
+		userID = claims.UserID
+		username = claims.Username
+	}
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade WebSocket: %v", err)
+		return
+	}
+
+	client := &wshub.Client{
+		ID:       strconv.FormatInt(userID, 10),
+		GameID:   wshub.LobbyRoom,
+		UserID:   userID,
+		Username: username,
+		Conn:     conn,
+		Send:     make(chan []byte, 256),
+		Hub:      h.hub,
+	}
+
+	h.hub.RegisterClient(client)
+
+	go writePump(client, conn)
+	go h.readPump(client, conn)
+
+	welcomeMsg := wshub.Message{
+		Type: "connected",
+		Payload: map[string]interface{}{
+			"message": "Connected to lobby",
+			"user_id": userID,
+		},
+	}
+	msgBytes, _ := json.Marshal(welcomeMsg)
+	h.hub.SendToClient(client, msgBytes)
+}
+
+// GetMetrics returns per-room delivery metrics for this instance
+func (h *WebSocketHandler) GetMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"rooms": h.hub.Metrics()})
 }
 
 func (h *WebSocketHandler) readPump(client *wshub.Client, conn *websocket.Conn) {
@@ -129,7 +279,7 @@ func (h *WebSocketHandler) readPump(client *wshub.Client, conn *websocket.Conn)
 	}
 }
 
-func (h *WebSocketHandler) writePump(client *wshub.Client, conn *websocket.Conn) {
+func writePump(client *wshub.Client, conn *websocket.Conn) {
 	ticker := time.NewTicker(54 * time.Second)
 	defer func() {
 		ticker.Stop()
@@ -161,6 +311,11 @@ func (h *WebSocketHandler) writePump(client *wshub.Client, conn *websocket.Conn)
 func (h *WebSocketHandler) handleMessage(client *wshub.Client, msg *wshub.Message) {
 	log.Printf("Received message from user %d: type=%s", client.UserID, msg.Type)
 
+	// The lobby channel is receive-only
+	if client.GameID == wshub.LobbyRoom {
+		return
+	}
+
 	// Handle different message types
 	switch msg.Type {
 	case "move":
@@ -168,14 +323,63 @@ func (h *WebSocketHandler) handleMessage(client *wshub.Client, msg *wshub.Messag
 		log.Printf("Move message received: %+v", msg.Payload)
 
 	case "chat":
-		// Chat messages (if implemented)
-		h.broadcastToGame(client.GameID, msg)
+		h.handleChat(client, msg)
+
+	case "undo_request":
+		h.handleUndoRequest(client)
+
+	case "undo_response":
+		h.handleUndoResponse(client, msg)
 
 	default:
 		log.Printf("Unknown message type: %s", msg.Type)
 	}
 }
 
+// handleChat stores a chat message and broadcasts it stamped with the
+// authenticated sender. Only the message text is taken from the client.
+func (h *WebSocketHandler) handleChat(client *wshub.Client, msg *wshub.Message) {
+	var payload struct {
+		Message string `json:"message"`
+	}
+	if raw, err := json.Marshal(msg.Payload); err == nil {
+		json.Unmarshal(raw, &payload)
+	}
+
+	gameID, err := strconv.ParseInt(client.GameID, 10, 64)
+	if err != nil {
+		return
+	}
+
+	chatMsg, err := h.chatService.SendMessage(context.Background(), gameID, client.UserID, client.Username, payload.Message)
+	if err != nil {
+		h.sendToClient(client, &wshub.Message{
+			Type:    "chat_error",
+			Payload: map[string]interface{}{"error": err.Error()},
+		})
+		return
+	}
+
+	msgBytes, err := json.Marshal(&wshub.Message{Type: "chat", Payload: chatMsg})
+	if err != nil {
+		log.Printf("Failed to marshal message: %v", err)
+		return
+	}
+
+	h.hub.BroadcastFromUser(client.GameID, client.UserID, msgBytes)
+}
+
+// sendToClient queues a message for a single client without blocking
+func (h *WebSocketHandler) sendToClient(client *wshub.Client, msg *wshub.Message) {
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("Failed to marshal message: %v", err)
+		return
+	}
+
+	h.hub.SendToClient(client, msgBytes)
+}
+
 func (h *WebSocketHandler) broadcastToGame(gameID string, msg *wshub.Message) {
 	msgBytes, err := json.Marshal(msg)
 	if err != nil {
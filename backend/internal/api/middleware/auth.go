@@ -1,9 +1,11 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
+	"splendor-backend/internal/domain/models"
 	"splendor-backend/pkg/jwt"
 
 	"github.com/gin-gonic/gin"
@@ -45,6 +47,7 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 		// Set user info in context
 		c.Set("userID", claims.UserID)
 		c.Set("username", claims.Username)
+		c.Set("guest", claims.Guest)
 
 		c.Next()
 	}
@@ -66,9 +69,79 @@ func OptionalAuthMiddleware(jwtSecret string) gin.HandlerFunc {
 			if err == nil {
 				c.Set("userID", claims.UserID)
 				c.Set("username", claims.Username)
+				c.Set("guest", claims.Guest)
 			}
 		}
 
 		c.Next()
 	}
 }
+
+// RegisteredMiddleware turns guests away. It must run after AuthMiddleware.
+func RegisteredMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool("guest") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "A registered account is required"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// BotAuthenticator resolves a bot token to its bot account
+type BotAuthenticator interface {
+	AuthenticateBot(ctx context.Context, token string) (*models.User, error)
+}
+
+// BotAuthMiddleware validates bot tokens sent as 'Bot <token>'
+func BotAuthMiddleware(bots BotAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) != 2 || parts[0] != "Bot" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization format. Use 'Bot <token>'"})
+			c.Abort()
+			return
+		}
+
+		botUser, err := bots.AuthenticateBot(c.Request.Context(), parts[1])
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid bot token"})
+			c.Abort()
+			return
+		}
+
+		c.Set("botID", botUser.ID)
+		c.Set("username", botUser.Username)
+
+		c.Next()
+	}
+}
+
+// AdminChecker reports whether a user is an admin
+type AdminChecker interface {
+	IsAdmin(ctx context.Context, userID int64) (bool, error)
+}
+
+// AdminMiddleware only lets admins through. It must run after AuthMiddleware.
+func AdminMiddleware(admins AdminChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("userID")
+		id, ok := userID.(int64)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			c.Abort()
+			return
+		}
+
+		isAdmin, err := admins.IsAdmin(c.Request.Context(), id)
+		if err != nil || !isAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
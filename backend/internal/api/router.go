@@ -1,10 +1,16 @@
 package api
 
 import (
+	"context"
+	"time"
+
+	"splendor-backend/internal/achievement"
 	"splendor-backend/internal/api/handlers"
 	"splendor-backend/internal/api/middleware"
+	"splendor-backend/internal/bot"
 	"splendor-backend/internal/config"
 	"splendor-backend/internal/gamelogic"
+	"splendor-backend/internal/rating"
 	"splendor-backend/internal/repository/postgres"
 	"splendor-backend/internal/service"
 	"splendor-backend/pkg/database"
@@ -21,22 +27,110 @@ func SetupRoutes(router *gin.Engine, db *database.DB, hub *websocket.Hub, cfg *c
 	cardRepo := postgres.NewCardRepository(db)
 	stateRepo := postgres.NewStateRepository(db)
 	statsRepo := postgres.NewStatsRepository(db)
+	chatRepo := postgres.NewChatRepository(db)
+	botRepo := postgres.NewBotRepository(db)
+	moveRepo := postgres.NewMoveRepository(db)
+	snapshotRepo := postgres.NewSnapshotRepository(db)
+	auditRepo := postgres.NewAuditRepository(db)
+	resultRepo := postgres.NewResultRepository(db)
+	ratingRepo := postgres.NewRatingRepository(db)
+	seasonRepo := postgres.NewSeasonRepository(db)
+	tournamentRepo := postgres.NewTournamentRepository(db)
+	analyticsRepo := postgres.NewAnalyticsRepository(db)
+	achievementRepo := postgres.NewAchievementRepository(db)
+	refreshTokenRepo := postgres.NewRefreshTokenRepository(db)
+	matchmakingRepo := postgres.NewMatchmakingRepository(db)
 
-	// Initialize game engine
-	gameEngine := gamelogic.NewGameEngine(gameRepo, cardRepo, stateRepo)
+	// Initialize game engine and bot runner
+	gameEngine := gamelogic.NewGameEngine(db, gameRepo, cardRepo, stateRepo, moveRepo, snapshotRepo, cfg.SnapshotInterval)
+	bot.RegisterBuiltins(cardRepo, time.Duration(cfg.BotThinkTime)*time.Millisecond)
+	botRunner := bot.NewRunner(gameEngine, gameRepo, time.Duration(cfg.BotMoveDelay)*time.Millisecond)
 
 	// Initialize services
-	authService := service.NewAuthService(userRepo, cfg.JWTSecret, cfg.JWTAccessExpiry, cfg.JWTRefreshExpiry)
-	gameService := service.NewGameService(gameRepo, userRepo, gameEngine)
-	statsService := service.NewStatsService(statsRepo)
+	authService := service.NewAuthService(userRepo, refreshTokenRepo, cfg.JWTSecret, cfg.JWTAccessExpiry, cfg.JWTRefreshExpiry, cfg.GuestRefreshExpiry)
+	gameService := service.NewGameService(db, gameRepo, userRepo, cardRepo, gameEngine, hub)
+	ratingConfig := rating.Config{
+		Initial:          cfg.RatingInitial,
+		KFactor:          cfg.RatingKFactor,
+		ProvisionalK:     cfg.RatingProvisionalK,
+		ProvisionalGames: cfg.RatingProvisionalGames,
+	}
+	statsService := service.NewStatsService(statsRepo, ratingConfig, cfg.LeaderboardMinGames)
+	seasonService := service.NewSeasonService(db, seasonRepo, ratingRepo, cfg.RatingInitial, cfg.SeasonResetFactor, cfg.LeaderboardMinGames)
+	ratingService := service.NewRatingService(ratingRepo, userRepo, seasonService, ratingConfig)
+	botService := service.NewBotService(userRepo, botRepo, gameEngine)
+	undoService := service.NewUndoService(gameRepo, moveRepo, gameEngine)
+	achievementService := service.NewAchievementService(achievementRepo, userRepo, moveRepo, resultRepo)
+	tournamentService := service.NewTournamentService(db, tournamentRepo, gameService, ratingService)
+	gameOverService := service.NewGameOverService(db, gameRepo, stateRepo, moveRepo, resultRepo, statsService, ratingService, achievementService, tournamentService)
+	matchmakingService := service.NewMatchmakingService(db, matchmakingRepo, gameService, ratingService, service.MatchmakingConfig{
+		BaseWindow:   cfg.MatchBaseWindow,
+		WindowGrowth: cfg.MatchWindowGrowth,
+		MaxWindow:    cfg.MatchMaxWindow,
+		Interval:     time.Duration(cfg.MatchInterval) * time.Millisecond,
+	})
+	historyService := service.NewHistoryService(gameRepo, userRepo, moveRepo, resultRepo)
+	analyticsService := service.NewAnalyticsService(db, gameRepo, userRepo, stateRepo, moveRepo, analyticsRepo)
+	adminService := service.NewAdminService(db, userRepo, gameRepo, snapshotRepo, auditRepo, gameEngine, seasonService)
+	chatService := service.NewChatService(
+		chatRepo,
+		gameRepo,
+		service.NewWordListFilter(cfg.ChatBlockedWords),
+		cfg.ChatMaxLength,
+		cfg.ChatRateLimit,
+		time.Duration(cfg.ChatRateWindow)*time.Second,
+	)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
-	gameHandler := handlers.NewGameHandler(gameService)
-	wsHandler := handlers.NewWebSocketHandler(hub, cfg.JWTSecret)
+	gameHandler := handlers.NewGameHandler(gameService, hub, botRunner)
 	stateHandler := handlers.NewStateHandler(gameEngine)
-	gameplayHandler := handlers.NewGameplayHandler(gameEngine, hub)
-	statsHandler := handlers.NewStatsHandler(statsService)
+	gameplayHandler := handlers.NewGameplayHandler(gameEngine, hub, botRunner)
+	wsHandler := handlers.NewWebSocketHandler(hub, gameService, gameEngine, chatService, undoService, gameplayHandler, cfg.JWTSecret)
+	statsHandler := handlers.NewStatsHandler(statsService, seasonService)
+	chatHandler := handlers.NewChatHandler(chatService, hub)
+	botHandler := handlers.NewBotHandler(botService, hub)
+	adminHandler := handlers.NewAdminHandler(adminService, gameplayHandler, botRunner)
+	gameOverHandler := handlers.NewGameOverHandler(gameOverService, hub)
+	historyHandler := handlers.NewHistoryHandler(historyService)
+	ratingHandler := handlers.NewRatingHandler(ratingService)
+	matchmakingHandler := handlers.NewMatchmakingHandler(matchmakingService, hub)
+	tournamentHandler := handlers.NewTournamentHandler(tournamentService, hub)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService)
+	achievementHandler := handlers.NewAchievementHandler(achievementService, hub)
+
+	// External bots are told about their turns over their own channel
+	bot.Register(bot.NewExternalStrategy(botRepo, botHandler.NotifyTurn, time.Duration(cfg.BotTurnDeadline)*time.Second))
+
+	// Bot moves are broadcast like player moves; pick up games left waiting
+	// on a bot by a restart
+	botRunner.OnMove(gameplayHandler.BroadcastBotMove)
+	go botRunner.Resume(context.Background())
+
+	// Achievements are checked after every move and when a game ends, and
+	// announced to the player who unlocked them
+	achievement.RegisterBuiltins()
+	gameEngine.OnMovePlayed(achievementService.CheckMove)
+	achievementService.OnUnlock(achievementHandler.AnnounceUnlock)
+
+	// Finished games get their standings, statistics and a game_over event
+	gameEngine.OnGameFinished(gameOverHandler.OnGameFinished)
+	go gameOverHandler.Resume(context.Background(), time.Duration(cfg.FinalizeInterval)*time.Second)
+
+	// Matched players are told over their lobby connection
+	matchmakingService.OnMatch(matchmakingHandler.AnnounceMatch)
+	go matchmakingService.Run(context.Background())
+
+	// Tournament players are told about their tables the same way
+	tournamentService.OnTableStarted(tournamentHandler.AnnounceTable)
+	go tournamentService.Run(context.Background(), time.Duration(cfg.TournamentInterval)*time.Second)
+
+	// Finished games are analyzed from their move logs in the background
+	go analyticsService.Run(context.Background(), time.Duration(cfg.AnalyticsInterval)*time.Second)
+
+	// Expired refresh tokens are deleted in the background
+	go authService.Run(context.Background(), time.Duration(cfg.TokenCleanupInterval)*time.Second)
+
 	// CORS middleware
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     cfg.AllowedOrigins,
@@ -62,7 +156,11 @@ func SetupRoutes(router *gin.Engine, db *database.DB, hub *websocket.Hub, cfg *c
 		{
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/login", authHandler.Login)
+			auth.POST("/guest", authHandler.Guest)
+			auth.POST("/upgrade", middleware.AuthMiddleware(cfg.JWTSecret), authHandler.UpgradeGuest)
 			auth.POST("/refresh", authHandler.RefreshToken)
+			auth.POST("/logout", authHandler.Logout)
+			auth.POST("/logout-all", middleware.AuthMiddleware(cfg.JWTSecret), authHandler.LogoutAll)
 			auth.GET("/me", middleware.AuthMiddleware(cfg.JWTSecret), authHandler.GetCurrentUser)
 		}
 
@@ -70,27 +168,104 @@ func SetupRoutes(router *gin.Engine, db *database.DB, hub *websocket.Hub, cfg *c
 		games := v1.Group("/games")
 		{
 			games.GET("", gameHandler.ListGames)
-			games.POST("", middleware.AuthMiddleware(cfg.JWTSecret), gameHandler.CreateGame)
+			games.POST("", middleware.AuthMiddleware(cfg.JWTSecret), middleware.RegisteredMiddleware(), gameHandler.CreateGame)
 			games.POST("/join", middleware.AuthMiddleware(cfg.JWTSecret), gameHandler.JoinGame)
 			games.GET("/:id", gameHandler.GetGame)
-			games.GET("/:id/state", stateHandler.GetGameState)
+			games.GET("/:id/state", middleware.OptionalAuthMiddleware(cfg.JWTSecret), stateHandler.GetGameState)
 			games.POST("/:id/leave", middleware.AuthMiddleware(cfg.JWTSecret), gameHandler.LeaveGame)
 			games.POST("/:id/start", middleware.AuthMiddleware(cfg.JWTSecret), gameHandler.StartGame)
+			games.GET("/:id/hints", middleware.AuthMiddleware(cfg.JWTSecret), gameHandler.GetHints)
+			games.GET("/:id/results", gameOverHandler.GetResults)
+			games.GET("/:id/summary", historyHandler.GetSummary)
+
+			// Bot seats
+			games.GET("/bots/strategies", gameHandler.ListBotStrategies)
+			games.POST("/:id/bots", middleware.AuthMiddleware(cfg.JWTSecret), gameHandler.AddBot)
+			games.DELETE("/:id/bots/:userId", middleware.AuthMiddleware(cfg.JWTSecret), gameHandler.RemoveBot)
 
 			// Gameplay actions
 			games.POST("/:id/take-gems", middleware.AuthMiddleware(cfg.JWTSecret), gameplayHandler.TakeGems)
 			games.POST("/:id/purchase-card", middleware.AuthMiddleware(cfg.JWTSecret), gameplayHandler.PurchaseCard)
 			games.POST("/:id/reserve-card", middleware.AuthMiddleware(cfg.JWTSecret), gameplayHandler.ReserveCard)
+			games.POST("/:id/pass", middleware.AuthMiddleware(cfg.JWTSecret), gameplayHandler.Pass)
+
+			// Chat
+			games.GET("/:id/chat", middleware.AuthMiddleware(cfg.JWTSecret), chatHandler.GetHistory)
+			games.POST("/:id/chat/mutes", middleware.AuthMiddleware(cfg.JWTSecret), chatHandler.MutePlayer)
+			games.DELETE("/:id/chat/mutes/:userId", middleware.AuthMiddleware(cfg.JWTSecret), chatHandler.UnmutePlayer)
+		}
+
+		// Matchmaking routes
+		matchmaking := v1.Group("/matchmaking", middleware.AuthMiddleware(cfg.JWTSecret), middleware.RegisteredMiddleware())
+		{
+			matchmaking.POST("/queue", matchmakingHandler.JoinQueue)
+			matchmaking.GET("/queue", matchmakingHandler.GetQueueStatus)
+			matchmaking.DELETE("/queue", matchmakingHandler.LeaveQueue)
+		}
+
+		// Tournament routes
+		tournaments := v1.Group("/tournaments")
+		{
+			tournaments.GET("", tournamentHandler.ListTournaments)
+			tournaments.POST("", middleware.AuthMiddleware(cfg.JWTSecret), middleware.RegisteredMiddleware(), tournamentHandler.CreateTournament)
+			tournaments.GET("/:id", tournamentHandler.GetTournament)
+			tournaments.GET("/:id/standings", tournamentHandler.GetStandings)
+			tournaments.POST("/:id/register", middleware.AuthMiddleware(cfg.JWTSecret), middleware.RegisteredMiddleware(), tournamentHandler.Register)
+			tournaments.DELETE("/:id/register", middleware.AuthMiddleware(cfg.JWTSecret), tournamentHandler.Unregister)
+			tournaments.POST("/:id/start", middleware.AuthMiddleware(cfg.JWTSecret), tournamentHandler.StartTournament)
+		}
+
+		// User routes
+		users := v1.Group("/users")
+		{
+			users.GET("/:id/games", historyHandler.GetUserGames)
+			users.GET("/:id/rating", ratingHandler.GetUserRating)
+			users.GET("/:id/achievements", achievementHandler.GetUserAchievements)
+		}
+
+		// Bot registration, for users
+		bots := v1.Group("/bots", middleware.AuthMiddleware(cfg.JWTSecret), middleware.RegisteredMiddleware())
+		{
+			bots.POST("", botHandler.CreateBot)
+			bots.GET("", botHandler.ListBots)
+			bots.POST("/:id/token", botHandler.RotateToken)
+		}
+
+		// Bot API, for bots holding a bot token
+		botAPI := v1.Group("/bot")
+		{
+			botAPI.GET("/turns", middleware.BotAuthMiddleware(botService), botHandler.GetTurns)
+			botAPI.POST("/games/:id/move", middleware.BotAuthMiddleware(botService), botHandler.SubmitMove)
+			botAPI.GET("/ws", botHandler.HandleConnection)
+		}
+
+		// Admin routes
+		admin := v1.Group("/admin", middleware.AuthMiddleware(cfg.JWTSecret), middleware.AdminMiddleware(adminService))
+		{
+			admin.GET("/games/:id/snapshots", adminHandler.ListSnapshots)
+			admin.POST("/games/:id/snapshots", adminHandler.CreateSnapshot)
+			admin.POST("/games/:id/snapshots/:snapshotId/restore", adminHandler.RestoreSnapshot)
+			admin.POST("/seasons", adminHandler.StartSeason)
+			admin.POST("/seasons/current/end", adminHandler.EndSeason)
+			admin.GET("/audit", adminHandler.GetAuditLog)
 		}
 
 		// WebSocket route
 		v1.GET("/ws/games/:id", wsHandler.HandleConnection)
+		v1.GET("/ws/lobby", wsHandler.HandleLobbyConnection)
+		v1.GET("/ws/metrics", middleware.AuthMiddleware(cfg.JWTSecret), middleware.AdminMiddleware(adminService), wsHandler.GetMetrics)
 
 		// Stats routes
 		stats := v1.Group("/stats")
 		{
 			stats.GET("/users/:id", statsHandler.GetUserStats)
+			stats.GET("/users/:id/analytics", analyticsHandler.GetUserAnalytics)
+			stats.GET("/users/:id/versus/:otherId", historyHandler.GetVersus)
+			stats.GET("/users/:id/rivals", historyHandler.GetRivals)
 			stats.GET("/leaderboard", statsHandler.GetLeaderboard)
+			stats.GET("/seasons", statsHandler.ListSeasons)
+			stats.GET("/cards", statsHandler.GetCardStats)
+			stats.GET("/achievements", achievementHandler.ListAchievements)
 		}
 	}
 }
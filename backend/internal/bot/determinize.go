@@ -0,0 +1,110 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"sync"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+)
+
+// determinize copies userID's view of the game and deals every card the
+// player cannot see at random: opponents' blind reserves get cards of the
+// right tier and the rest are shuffled into the decks
+func determinize(view *models.FullGameState, userID int64, cards []models.DevelopmentCard, rng *rand.Rand) *models.FullGameState {
+	state := gamelogic.CloneState(view)
+	gameState := state.GameState
+
+	seen := make(map[int64]bool)
+	for _, tier := range [][]models.DevelopmentCard{
+		gameState.VisibleCardsTier1,
+		gameState.VisibleCardsTier2,
+		gameState.VisibleCardsTier3,
+	} {
+		for _, card := range tier {
+			seen[card.ID] = true
+		}
+	}
+	for _, playerState := range state.PlayerStates {
+		for _, card := range playerState.PurchasedCards {
+			seen[card.ID] = true
+		}
+		for _, card := range playerState.ReservedCards {
+			seen[card.ID] = true
+		}
+	}
+
+	unseen := map[int][]models.DevelopmentCard{}
+	for _, card := range cards {
+		if !seen[card.ID] {
+			unseen[card.Tier] = append(unseen[card.Tier], card)
+		}
+	}
+	for tier := range unseen {
+		pool := unseen[tier]
+		rng.Shuffle(len(pool), func(i, j int) {
+			pool[i], pool[j] = pool[j], pool[i]
+		})
+	}
+
+	// Opponents' hidden reserves come out of the unseen pool first
+	for playerID, playerState := range state.PlayerStates {
+		if playerID == userID {
+			continue
+		}
+		for _, tier := range playerState.HiddenReservedTiers {
+			pool := unseen[tier]
+			if len(pool) == 0 {
+				continue
+			}
+			playerState.ReservedCards = append(playerState.ReservedCards, pool[0])
+			playerState.BlindReserved = append(playerState.BlindReserved, pool[0].ID)
+			unseen[tier] = pool[1:]
+		}
+		playerState.HiddenReservedTiers = nil
+		playerState.ReservedCount = 0
+	}
+
+	gameState.DeckTier1 = dealDeck(unseen[1], gameState.DeckTier1Count)
+	gameState.DeckTier2 = dealDeck(unseen[2], gameState.DeckTier2Count)
+	gameState.DeckTier3 = dealDeck(unseen[3], gameState.DeckTier3Count)
+	gameState.DeckTier1Count = len(gameState.DeckTier1)
+	gameState.DeckTier2Count = len(gameState.DeckTier2)
+	gameState.DeckTier3Count = len(gameState.DeckTier3)
+
+	return state
+}
+
+// dealDeck takes up to count cards from a shuffled pool
+func dealDeck(pool []models.DevelopmentCard, count int) []models.DevelopmentCard {
+	if count > len(pool) {
+		count = len(pool)
+	}
+	return append([]models.DevelopmentCard{}, pool[:count]...)
+}
+
+// cardCache loads the card catalog once. Without it determinized states just
+// have empty decks.
+type cardCache struct {
+	catalog CardCatalog
+
+	mu    sync.Mutex
+	cards []models.DevelopmentCard
+}
+
+func (c *cardCache) get(ctx context.Context) []models.DevelopmentCard {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cards == nil && c.catalog != nil {
+		cards, err := c.catalog.GetAllCards(ctx)
+		if err != nil {
+			log.Printf("Failed to load card catalog for search: %v", err)
+			return nil
+		}
+		c.cards = cards
+	}
+	return c.cards
+}
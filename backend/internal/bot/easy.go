@@ -0,0 +1,26 @@
+package bot
+
+import (
+	"context"
+	"math/rand"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+)
+
+// RandomStrategy is the easy difficulty: it plays a uniformly random legal
+// action and needs no thinking time
+type RandomStrategy struct{}
+
+func NewRandomStrategy() *RandomStrategy {
+	return &RandomStrategy{}
+}
+
+func (s *RandomStrategy) Name() string {
+	return "easy"
+}
+
+func (s *RandomStrategy) ChooseAction(ctx context.Context, state *models.FullGameState, userID int64, legal []gamelogic.Action) (*gamelogic.Action, error) {
+	action := legal[rand.Intn(len(legal))]
+	return &action, nil
+}
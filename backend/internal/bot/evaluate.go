@@ -0,0 +1,153 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+)
+
+// Evaluation weights
+const (
+	pointWeight       = 10.0
+	bonusWeight       = 1.5
+	tokenWeight       = 0.4
+	goldWeight        = 0.7
+	nobleWeight       = 3.0
+	affordableWeight  = 1.5
+	progressWeight    = 1.0
+	winningEvaluation = 1000.0
+)
+
+// Evaluate scores userID's position: victory points first, then card
+// bonuses, progress toward the nobles still on the board, tokens in hand and
+// how close the player is to affording the cards they can see. Higher is
+// better. It only reads public information and the player's own hand.
+func Evaluate(state *models.FullGameState, userID int64) float64 {
+	playerState, ok := state.PlayerStates[userID]
+	if !ok {
+		return 0
+	}
+
+	score := 0.0
+	for _, player := range state.Players {
+		if player.UserID == userID {
+			score += float64(player.VictoryPoints) * pointWeight
+		}
+	}
+
+	if state.Game != nil && state.Game.WinnerID != nil && *state.Game.WinnerID == userID {
+		score += winningEvaluation
+	}
+
+	for _, count := range playerState.PermanentGems {
+		score += float64(count) * bonusWeight
+	}
+
+	for gemType, count := range playerState.Gems {
+		if gemType == "gold" {
+			score += float64(count) * goldWeight
+		} else {
+			score += float64(count) * tokenWeight
+		}
+	}
+
+	if state.GameState != nil {
+		for _, noble := range state.GameState.AvailableNobles {
+			progress := nobleProgress(playerState, &noble)
+			score += progress * progress * float64(noble.VictoryPoints) * nobleWeight
+		}
+
+		for _, cards := range [][]models.DevelopmentCard{
+			state.GameState.VisibleCardsTier1,
+			state.GameState.VisibleCardsTier2,
+			state.GameState.VisibleCardsTier3,
+		} {
+			for i := range cards {
+				score += cardPotential(playerState, &cards[i]) * 0.5
+			}
+		}
+	}
+
+	for i := range playerState.ReservedCards {
+		score += cardPotential(playerState, &playerState.ReservedCards[i])
+	}
+
+	return score
+}
+
+// Advantage is userID's evaluation minus the best opponent's
+func Advantage(state *models.FullGameState, userID int64) float64 {
+	best := 0.0
+	first := true
+	for _, player := range state.Players {
+		if player.UserID == userID {
+			continue
+		}
+		score := Evaluate(state, player.UserID)
+		if first || score > best {
+			best = score
+			first = false
+		}
+	}
+	return Evaluate(state, userID) - best
+}
+
+// nobleProgress is the fraction of a noble's requirement met by bonuses
+func nobleProgress(playerState *models.PlayerState, noble *models.Noble) float64 {
+	required, met := 0, 0
+	for gemType, count := range noble.Required {
+		required += count
+		met += min(count, playerState.PermanentGems[gemType])
+	}
+	if required == 0 {
+		return 1
+	}
+	return float64(met) / float64(required)
+}
+
+// tokensShort is how many tokens the player still lacks to buy a card once
+// bonuses, gems and gold are counted
+func tokensShort(playerState *models.PlayerState, card *models.DevelopmentCard) int {
+	short := 0
+	for gemType, cost := range card.Cost {
+		have := playerState.PermanentGems[gemType] + playerState.Gems[gemType]
+		if cost > have {
+			short += cost - have
+		}
+	}
+	return max(0, short-playerState.Gems["gold"])
+}
+
+// cardPotential values a card the player could work toward buying
+func cardPotential(playerState *models.PlayerState, card *models.DevelopmentCard) float64 {
+	short := tokensShort(playerState, card)
+	if short == 0 {
+		return float64(card.VictoryPoints)*affordableWeight + 0.5
+	}
+	return float64(card.VictoryPoints) * progressWeight / float64(1+short)
+}
+
+// tokensSpent is how many tokens a purchase costs the player
+func tokensSpent(playerState *models.PlayerState, card *models.DevelopmentCard) int {
+	spent := 0
+	for _, count := range gamelogic.NewGameValidator().CalculateCost(card, playerState) {
+		spent += count
+	}
+	return spent
+}
+
+// actionKey identifies an action independently of map ordering
+func actionKey(action *gamelogic.Action) string {
+	gems := make([]string, 0, len(action.Gems))
+	for gemType, count := range action.Gems {
+		if count > 0 {
+			gems = append(gems, fmt.Sprintf("%s:%d", gemType, count))
+		}
+	}
+	sort.Strings(gems)
+
+	return fmt.Sprintf("%s|%d|%t|%d|%s", action.Type, action.CardID, action.FromReserve, action.Tier, strings.Join(gems, ","))
+}
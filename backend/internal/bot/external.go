@@ -0,0 +1,154 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+)
+
+// ExternalStrategyName is the strategy of seats played by a separate process
+// through the bot API
+const ExternalStrategyName = "external"
+
+// turnPollInterval is how often a pending turn is checked for a reply
+const turnPollInterval = 250 * time.Millisecond
+
+// TurnStore holds turns offered to external bots and their replies
+type TurnStore interface {
+	OfferTurn(ctx context.Context, turn *models.BotTurn) error
+	GetTurnAction(ctx context.Context, gameID int64, turnNumber int) (json.RawMessage, error)
+	CloseTurn(ctx context.Context, gameID int64, turnNumber int) error
+}
+
+// TurnNotifier tells an external bot that a turn is waiting for it
+type TurnNotifier func(turn *models.BotTurn)
+
+// ExternalStrategy hands the turn to an external bot and waits for its reply
+// until the deadline. A bot that does not answer in time has its move chosen
+// by the default strategy.
+type ExternalStrategy struct {
+	turns    TurnStore
+	notify   TurnNotifier
+	deadline time.Duration
+}
+
+func NewExternalStrategy(turns TurnStore, notify TurnNotifier, deadline time.Duration) *ExternalStrategy {
+	return &ExternalStrategy{
+		turns:    turns,
+		notify:   notify,
+		deadline: deadline,
+	}
+}
+
+func (s *ExternalStrategy) Name() string {
+	return ExternalStrategyName
+}
+
+func (s *ExternalStrategy) ChooseAction(ctx context.Context, state *models.FullGameState, userID int64, legal []gamelogic.Action) (*gamelogic.Action, error) {
+	legalJSON, err := json.Marshal(legal)
+	if err != nil {
+		return nil, err
+	}
+
+	turn := &models.BotTurn{
+		GameID:       state.Game.ID,
+		UserID:       userID,
+		TurnNumber:   state.Game.TurnNumber,
+		LegalActions: legalJSON,
+		Deadline:     time.Now().Add(s.deadline),
+		State:        state,
+	}
+	if err := s.turns.OfferTurn(ctx, turn); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := s.turns.CloseTurn(context.Background(), turn.GameID, turn.TurnNumber); err != nil {
+			log.Printf("Failed to close bot turn %d in game %d: %v", turn.TurnNumber, turn.GameID, err)
+		}
+	}()
+
+	if s.notify != nil {
+		s.notify(turn)
+	}
+
+	if action := s.waitForReply(ctx, turn, legal); action != nil {
+		return action, nil
+	}
+
+	log.Printf("Bot %d missed its deadline in game %d, playing a %s move", userID, turn.GameID, DefaultStrategy)
+	fallback, ok := Lookup(DefaultStrategy)
+	if !ok {
+		return &legal[0], nil
+	}
+	return fallback.ChooseAction(ctx, state, userID, legal)
+}
+
+// waitForReply polls for the bot's answer to a turn. It returns nil if no
+// legal answer arrives before the deadline.
+func (s *ExternalStrategy) waitForReply(ctx context.Context, turn *models.BotTurn, legal []gamelogic.Action) *gamelogic.Action {
+	timer := time.NewTimer(time.Until(turn.Deadline))
+	defer timer.Stop()
+
+	ticker := time.NewTicker(turnPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// A reply accepted just before the deadline is still played, so
+		// the turn is checked once more after it passes
+		last := false
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+			last = true
+		case <-ticker.C:
+		}
+
+		reply, err := s.turns.GetTurnAction(ctx, turn.GameID, turn.TurnNumber)
+		if err != nil {
+			log.Printf("Failed to check bot turn %d in game %d: %v", turn.TurnNumber, turn.GameID, err)
+		}
+		if len(reply) == 0 {
+			if last {
+				return nil
+			}
+			continue
+		}
+
+		// Replies are checked when submitted, but the stored move is only
+		// trusted if it is still one of this turn's legal actions
+		var action gamelogic.Action
+		if err := json.Unmarshal(reply, &action); err != nil {
+			return nil
+		}
+		return MatchLegal(legal, &action)
+	}
+}
+
+// MatchLegal returns the legal action equal to action, or nil if it is not
+// legal. The tier of a visible card being reserved may be left out.
+func MatchLegal(legal []gamelogic.Action, action *gamelogic.Action) *gamelogic.Action {
+	key := actionKey(normalizeAction(action))
+	for i := range legal {
+		if actionKey(normalizeAction(&legal[i])) == key {
+			return &legal[i]
+		}
+	}
+	return nil
+}
+
+// normalizeAction drops fields that do not change what an action does
+func normalizeAction(action *gamelogic.Action) *gamelogic.Action {
+	normalized := *action
+	if normalized.Type == gamelogic.ActionReserveCard && normalized.CardID > 0 {
+		normalized.Tier = 0
+	}
+	if normalized.Type != gamelogic.ActionPurchaseCard {
+		normalized.FromReserve = false
+	}
+	return &normalized
+}
@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+)
+
+const (
+	// efficiencyWeight rewards purchases that score many points per token
+	efficiencyWeight = 4.0
+
+	// deadEndPenalty is subtracted from moves that leave the player unable
+	// to do anything but pass on their next turn
+	deadEndPenalty = 20.0
+
+	// replyCandidates is how many top moves are checked against the next
+	// player's best reply when the budget allows
+	replyCandidates = 5
+)
+
+// GreedyStrategy is the medium difficulty. It scores every legal action by
+// the position it leads to, favouring points per token spent and noble
+// progress, then uses any remaining budget to check its best candidates
+// against the next player's best reply. Moves are tried on a determinized
+// copy of its view, like the hard difficulty's, so blind reserves and the
+// cards that refill the board can be played out.
+type GreedyStrategy struct {
+	Budget time.Duration
+	cards  *cardCache
+}
+
+func NewGreedyStrategy(catalog CardCatalog, budget time.Duration) *GreedyStrategy {
+	return &GreedyStrategy{
+		Budget: budget,
+		cards:  &cardCache{catalog: catalog},
+	}
+}
+
+func (s *GreedyStrategy) Name() string {
+	return "medium"
+}
+
+type scoredAction struct {
+	action gamelogic.Action
+	after  *models.FullGameState
+	score  float64
+}
+
+func (s *GreedyStrategy) ChooseAction(ctx context.Context, state *models.FullGameState, userID int64, legal []gamelogic.Action) (*gamelogic.Action, error) {
+	deadline := time.Now().Add(s.Budget)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	// Shuffle first so ties are broken randomly
+	candidates := make([]gamelogic.Action, len(legal))
+	copy(candidates, legal)
+	rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	sim := determinize(state, userID, s.cards.get(ctx), rng)
+	scored := scoreActions(sim, userID, candidates)
+	if len(scored) == 0 {
+		return &candidates[0], nil
+	}
+
+	// Refine the leading moves with one ply of lookahead
+	for i := 0; i < len(scored) && i < replyCandidates; i++ {
+		if time.Now().After(deadline) || ctx.Err() != nil {
+			break
+		}
+		scored[i].score = replyScore(scored[i].after, userID, scored[i].score)
+	}
+	sort.SliceStable(scored[:min(len(scored), replyCandidates)], func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	return &scored[0].action, nil
+}
+
+// scoreActions simulates each action and returns them best first. The
+// state's decks must be filled in, see determinize.
+func scoreActions(state *models.FullGameState, userID int64, actions []gamelogic.Action) []scoredAction {
+	playerState := state.PlayerStates[userID]
+
+	scored := []scoredAction{}
+	for _, action := range actions {
+		after := gamelogic.CloneState(state)
+		if err := gamelogic.SimulateAction(after, &action); err != nil {
+			continue
+		}
+
+		score := Advantage(after, userID)
+		if action.Type == gamelogic.ActionPurchaseCard {
+			if card := lookupCard(state, playerState, &action); card != nil {
+				score += efficiencyWeight * float64(card.VictoryPoints) / float64(max(1, tokensSpent(playerState, card)))
+			}
+		}
+		if after.Game.Status != models.GameStatusCompleted && gamelogic.CanOnlyPass(after, userID) {
+			score -= deadEndPenalty
+		}
+
+		scored = append(scored, scoredAction{action: action, after: after, score: score})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+	return scored
+}
+
+// replyScore rescores a position after the next player's greedy reply. A
+// finished game keeps its score.
+func replyScore(after *models.FullGameState, userID int64, score float64) float64 {
+	if after.Game.Status == models.GameStatusCompleted || after.Game.CurrentTurnPlayerID == nil {
+		return score
+	}
+
+	opponentID := *after.Game.CurrentTurnPlayerID
+	if opponentID == userID {
+		return score
+	}
+
+	replies := scoreActions(after, opponentID, gamelogic.LegalActions(after, opponentID))
+	if len(replies) == 0 {
+		return score
+	}
+	return Advantage(replies[0].after, userID)
+}
+
+// lookupCard finds the card a purchase or reserve action refers to
+func lookupCard(state *models.FullGameState, playerState *models.PlayerState, action *gamelogic.Action) *models.DevelopmentCard {
+	if action.FromReserve {
+		for i := range playerState.ReservedCards {
+			if playerState.ReservedCards[i].ID == action.CardID {
+				return &playerState.ReservedCards[i]
+			}
+		}
+		return nil
+	}
+
+	for _, cards := range [][]models.DevelopmentCard{
+		state.GameState.VisibleCardsTier1,
+		state.GameState.VisibleCardsTier2,
+		state.GameState.VisibleCardsTier3,
+	} {
+		for i := range cards {
+			if cards[i].ID == action.CardID {
+				return &cards[i]
+			}
+		}
+	}
+	return nil
+}
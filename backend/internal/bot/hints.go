@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+)
+
+// Hint is a suggested move with the score it was ranked by
+type Hint struct {
+	Action      gamelogic.Action `json:"action"`
+	Score       float64          `json:"score"`
+	Description string           `json:"description"`
+}
+
+// Analysis is a player's evaluated position with suggested moves
+type Analysis struct {
+	Evaluation float64 `json:"evaluation"`
+	Advantage  float64 `json:"advantage"` // Evaluation minus the best opponent's
+	Hints      []Hint  `json:"hints"`
+}
+
+// Analyze evaluates userID's position and suggests up to limit moves
+func Analyze(state *models.FullGameState, userID int64, cards []models.DevelopmentCard, limit int) *Analysis {
+	return &Analysis{
+		Evaluation: Evaluate(state, userID),
+		Advantage:  Advantage(state, userID),
+		Hints:      SuggestMoves(state, userID, cards, limit),
+	}
+}
+
+// SuggestMoves ranks userID's legal moves by the position each leads to,
+// using the same evaluation as the bots: points, bonuses toward the nobles
+// on the board and how affordable the visible cards become. It returns at
+// most limit hints, best first. The state must be userID's own view and it
+// must be userID's turn. The cards userID cannot see are dealt from cards at
+// random before the moves are tried.
+func SuggestMoves(state *models.FullGameState, userID int64, cards []models.DevelopmentCard, limit int) []Hint {
+	legal := gamelogic.LegalActions(state, userID)
+	if len(legal) == 0 {
+		return []Hint{}
+	}
+
+	playerState := state.PlayerStates[userID]
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	scored := scoreActions(determinize(state, userID, cards, rng), userID, legal)
+
+	hints := make([]Hint, 0, min(limit, len(scored)))
+	for _, s := range scored[:min(limit, len(scored))] {
+		hints = append(hints, Hint{
+			Action:      s.action,
+			Score:       s.score,
+			Description: describeAction(state, playerState, &s.action),
+		})
+	}
+	return hints
+}
+
+// describeAction explains an action in a short sentence
+func describeAction(state *models.FullGameState, playerState *models.PlayerState, action *gamelogic.Action) string {
+	switch action.Type {
+	case gamelogic.ActionTakeGems:
+		gems := make([]string, 0, len(action.Gems))
+		for gemType, count := range action.Gems {
+			gems = append(gems, fmt.Sprintf("%d %s", count, gemType))
+		}
+		sort.Strings(gems)
+		return "Take " + strings.Join(gems, ", ")
+
+	case gamelogic.ActionPurchaseCard:
+		card := lookupCard(state, playerState, action)
+		if card == nil {
+			return "Buy a card"
+		}
+		where := "from the board"
+		if action.FromReserve {
+			where = "from your reserve"
+		}
+		return fmt.Sprintf("Buy the tier %d %s card %s (%s)", card.Tier, card.GemType, where, pointsLabel(card.VictoryPoints))
+
+	case gamelogic.ActionReserveCard:
+		if action.CardID == 0 {
+			return fmt.Sprintf("Reserve the top card of the tier %d deck", action.Tier)
+		}
+		card := lookupCard(state, playerState, action)
+		if card == nil {
+			return "Reserve a card"
+		}
+		return fmt.Sprintf("Reserve the tier %d %s card (%s)", card.Tier, card.GemType, pointsLabel(card.VictoryPoints))
+
+	case gamelogic.ActionPass:
+		return "Pass, as no other move is possible"
+	}
+
+	return action.Type
+}
+
+func pointsLabel(points int) string {
+	if points == 1 {
+		return "1 point"
+	}
+	return fmt.Sprintf("%d points", points)
+}
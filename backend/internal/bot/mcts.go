@@ -0,0 +1,214 @@
+package bot
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+)
+
+const (
+	// exploration is the UCB1 exploration constant
+	exploration = 1.4
+
+	// rolloutDepth bounds how many plies a playout runs before the
+	// position is scored with the evaluation function
+	rolloutDepth = 40
+
+	// maxIterations caps the search however generous the budget
+	maxIterations = 200000
+)
+
+// CardCatalog provides every development card in the game, used to fill in
+// the cards a bot cannot see
+type CardCatalog interface {
+	GetAllCards(ctx context.Context) ([]models.DevelopmentCard, error)
+}
+
+// MCTSStrategy is the hard difficulty: Monte Carlo tree search over
+// determinized states. Each iteration deals the unseen cards into the decks
+// and opponents' blind reserves at random, so the search never relies on
+// hidden information. It searches until its budget runs out.
+type MCTSStrategy struct {
+	Budget time.Duration
+	cards  *cardCache
+}
+
+func NewMCTSStrategy(catalog CardCatalog, budget time.Duration) *MCTSStrategy {
+	return &MCTSStrategy{
+		Budget: budget,
+		cards:  &cardCache{catalog: catalog},
+	}
+}
+
+func (s *MCTSStrategy) Name() string {
+	return "hard"
+}
+
+type mctsNode struct {
+	mover    int64 // player whose move led to this node
+	visits   int
+	reward   float64
+	children map[string]*mctsNode
+}
+
+func newNode(mover int64) *mctsNode {
+	return &mctsNode{mover: mover, children: make(map[string]*mctsNode)}
+}
+
+func (s *MCTSStrategy) ChooseAction(ctx context.Context, state *models.FullGameState, userID int64, legal []gamelogic.Action) (*gamelogic.Action, error) {
+	if len(legal) == 1 {
+		return &legal[0], nil
+	}
+
+	cards := s.cards.get(ctx)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	deadline := time.Now().Add(s.Budget)
+	root := newNode(0)
+
+	for i := 0; i < maxIterations; i++ {
+		if i > 0 && (time.Now().After(deadline) || ctx.Err() != nil) {
+			break
+		}
+
+		sim := determinize(state, userID, cards, rng)
+		path := []*mctsNode{root}
+		node := root
+
+		// Selection and expansion
+		for sim.Game.Status != models.GameStatusCompleted {
+			mover := *sim.Game.CurrentTurnPlayerID
+			moves := gamelogic.LegalActions(sim, mover)
+			if len(moves) == 0 {
+				break
+			}
+
+			next, action := selectChild(node, moves, mover, rng)
+			if err := gamelogic.SimulateAction(sim, action); err != nil {
+				break
+			}
+			path = append(path, next)
+			node = next
+
+			if next.visits == 0 {
+				break
+			}
+		}
+
+		rewards := rollout(sim, rng)
+
+		// Backpropagation
+		for _, n := range path {
+			n.visits++
+			n.reward += rewards[n.mover]
+		}
+	}
+
+	var best *gamelogic.Action
+	bestVisits := -1
+	for i := range legal {
+		if child, ok := root.children[actionKey(&legal[i])]; ok && child.visits > bestVisits {
+			best = &legal[i]
+			bestVisits = child.visits
+		}
+	}
+	if best == nil {
+		best = &legal[0]
+	}
+	return best, nil
+}
+
+// selectChild expands an untried move if there is one, otherwise picks the
+// child with the best UCB1 score among the moves legal in this determinization
+func selectChild(node *mctsNode, moves []gamelogic.Action, mover int64, rng *rand.Rand) (*mctsNode, *gamelogic.Action) {
+	untried := []int{}
+	for i := range moves {
+		if _, ok := node.children[actionKey(&moves[i])]; !ok {
+			untried = append(untried, i)
+		}
+	}
+	if len(untried) > 0 {
+		i := untried[rng.Intn(len(untried))]
+		child := newNode(mover)
+		node.children[actionKey(&moves[i])] = child
+		return child, &moves[i]
+	}
+
+	var best *mctsNode
+	var bestAction *gamelogic.Action
+	bestScore := math.Inf(-1)
+	logVisits := math.Log(float64(max(1, node.visits)))
+	for i := range moves {
+		child := node.children[actionKey(&moves[i])]
+		score := child.reward/float64(child.visits) + exploration*math.Sqrt(logVisits/float64(child.visits))
+		if score > bestScore {
+			best, bestAction, bestScore = child, &moves[i], score
+		}
+	}
+	return best, bestAction
+}
+
+// rollout plays a fast semi-random game from sim and returns each player's
+// reward in [0, 1]: 1 for the winner of a finished game, otherwise the
+// players' evaluations scaled between the worst and best
+func rollout(sim *models.FullGameState, rng *rand.Rand) map[int64]float64 {
+	for depth := 0; depth < rolloutDepth && sim.Game.Status != models.GameStatusCompleted; depth++ {
+		mover := *sim.Game.CurrentTurnPlayerID
+		moves := gamelogic.LegalActions(sim, mover)
+		if len(moves) == 0 {
+			break
+		}
+		if err := gamelogic.SimulateAction(sim, rolloutMove(sim, mover, moves, rng)); err != nil {
+			break
+		}
+	}
+
+	rewards := make(map[int64]float64, len(sim.Players))
+	if sim.Game.Status == models.GameStatusCompleted && sim.Game.WinnerID != nil {
+		rewards[*sim.Game.WinnerID] = 1
+		return rewards
+	}
+
+	scores := make(map[int64]float64, len(sim.Players))
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, player := range sim.Players {
+		score := Evaluate(sim, player.UserID)
+		scores[player.UserID] = score
+		low = math.Min(low, score)
+		high = math.Max(high, score)
+	}
+	for userID, score := range scores {
+		if high > low {
+			rewards[userID] = (score - low) / (high - low)
+		} else {
+			rewards[userID] = 0.5
+		}
+	}
+	return rewards
+}
+
+// rolloutMove mostly buys the most valuable affordable card and otherwise
+// plays at random
+func rolloutMove(sim *models.FullGameState, mover int64, moves []gamelogic.Action, rng *rand.Rand) *gamelogic.Action {
+	if rng.Float64() < 0.7 {
+		playerState := sim.PlayerStates[mover]
+		var best *gamelogic.Action
+		bestPoints := -1
+		for i := range moves {
+			if moves[i].Type != gamelogic.ActionPurchaseCard {
+				continue
+			}
+			card := lookupCard(sim, playerState, &moves[i])
+			if card != nil && card.VictoryPoints > bestPoints {
+				best, bestPoints = &moves[i], card.VictoryPoints
+			}
+		}
+		if best != nil {
+			return best
+		}
+	}
+	return &moves[rng.Intn(len(moves))]
+}
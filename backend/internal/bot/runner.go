@@ -0,0 +1,192 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+)
+
+// Engine is the part of the game engine the runner drives
+type Engine interface {
+	GetGameState(ctx context.Context, gameID, viewerID int64) (*models.FullGameState, error)
+	ApplyAction(ctx context.Context, gameID, userID int64, action *gamelogic.Action) error
+}
+
+// A bot move that fails is retried after moveRetryDelay, up to
+// maxMoveAttempts times in a row. After that the game is left until the
+// runner is notified again.
+const (
+	moveRetryDelay  = time.Second
+	maxMoveAttempts = 5
+)
+
+// GameFinder finds games left waiting on a bot, e.g. after a restart
+type GameFinder interface {
+	GetGamesAwaitingBot(ctx context.Context) ([]int64, error)
+}
+
+// MoveFunc is called after every move a bot makes
+type MoveFunc func(ctx context.Context, gameID, userID int64, action *gamelogic.Action)
+
+// Runner plays bot turns on the server. It is notified after every change
+// of turn and keeps playing while the current turn belongs to a bot seat.
+type Runner struct {
+	engine    Engine
+	games     GameFinder
+	moveDelay time.Duration
+	onMove    MoveFunc
+
+	// Games with a running loop; true if it must check the turn again
+	mu     sync.Mutex
+	active map[int64]bool
+}
+
+func NewRunner(engine Engine, games GameFinder, moveDelay time.Duration) *Runner {
+	return &Runner{
+		engine:    engine,
+		games:     games,
+		moveDelay: moveDelay,
+		active:    make(map[int64]bool),
+	}
+}
+
+// OnMove sets the callback used to announce bot moves
+func (r *Runner) OnMove(fn MoveFunc) {
+	r.onMove = fn
+}
+
+// Notify tells the runner a game's turn may have passed to a bot
+func (r *Runner) Notify(gameID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.active[gameID]; ok {
+		r.active[gameID] = true
+		return
+	}
+
+	r.active[gameID] = false
+	go r.run(gameID)
+}
+
+// Resume restarts bot play in games that were waiting on a bot
+func (r *Runner) Resume(ctx context.Context) {
+	gameIDs, err := r.games.GetGamesAwaitingBot(ctx)
+	if err != nil {
+		log.Printf("Failed to resume bot games: %v", err)
+		return
+	}
+
+	for _, gameID := range gameIDs {
+		r.Notify(gameID)
+	}
+}
+
+func (r *Runner) run(gameID int64) {
+	for {
+		r.playTurns(gameID)
+
+		// Check again if the game was notified while we were finishing
+		r.mu.Lock()
+		if r.active[gameID] {
+			r.active[gameID] = false
+			r.mu.Unlock()
+			continue
+		}
+		delete(r.active, gameID)
+		r.mu.Unlock()
+		return
+	}
+}
+
+// playTurns plays moves until the game ends or a human is to move. A bot
+// with no move passes through its only legal action; any other failure is
+// retried rather than passing, so it does not cost the bot its turn.
+func (r *Runner) playTurns(gameID int64) {
+	ctx := context.Background()
+
+	failures := 0
+	for {
+		state, err := r.engine.GetGameState(ctx, gameID, 0)
+		if err != nil {
+			log.Printf("Bot runner failed to load game %d: %v", gameID, err)
+			return
+		}
+
+		seat := currentBotSeat(state)
+		if seat == nil {
+			return
+		}
+
+		time.Sleep(r.moveDelay)
+
+		action, err := r.chooseAction(ctx, gameID, seat)
+		if err == nil {
+			err = r.engine.ApplyAction(ctx, gameID, seat.UserID, action)
+		}
+
+		if err != nil {
+			failures++
+			if errors.Is(err, ErrNoLegalActions) || failures >= maxMoveAttempts {
+				log.Printf("Bot %d in game %d failed to move, giving up: %v", seat.UserID, gameID, err)
+				return
+			}
+			log.Printf("Bot %d in game %d failed to move, retrying: %v", seat.UserID, gameID, err)
+			time.Sleep(moveRetryDelay)
+			continue
+		}
+		failures = 0
+
+		if r.onMove != nil {
+			r.onMove(ctx, gameID, seat.UserID, action)
+		}
+	}
+}
+
+// chooseAction asks the seat's strategy for a move from the bot's own view.
+// A failing strategy falls back to the first legal action.
+func (r *Runner) chooseAction(ctx context.Context, gameID int64, seat *models.GamePlayer) (*gamelogic.Action, error) {
+	view, err := r.engine.GetGameState(ctx, gameID, seat.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	legal := gamelogic.LegalActions(view, seat.UserID)
+	if len(legal) == 0 {
+		return nil, ErrNoLegalActions
+	}
+
+	strategy, ok := Lookup(seat.BotStrategy)
+	if !ok {
+		log.Printf("Unknown bot strategy %q, using %q", seat.BotStrategy, DefaultStrategy)
+		strategy, _ = Lookup(DefaultStrategy)
+	}
+
+	action, err := strategy.ChooseAction(ctx, view, seat.UserID, legal)
+	if err != nil || action == nil {
+		log.Printf("Bot strategy %q failed, playing first legal action: %v", strategy.Name(), err)
+		return &legal[0], nil
+	}
+
+	return action, nil
+}
+
+// currentBotSeat returns the bot seat whose turn it is, if any
+func currentBotSeat(state *models.FullGameState) *models.GamePlayer {
+	game := state.Game
+	if game.Status != models.GameStatusInProgress || game.CurrentTurnPlayerID == nil {
+		return nil
+	}
+
+	for _, player := range state.Players {
+		if player.UserID == *game.CurrentTurnPlayerID && player.BotStrategy != "" {
+			return player
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+)
+
+// DefaultStrategy is used when a bot is seated without naming a strategy
+const DefaultStrategy = "medium"
+
+var ErrNoLegalActions = errors.New("no legal actions available")
+
+// Strategy picks a bot's next action. The state is the bot's own view of the
+// game, so hidden information is redacted exactly as for a human player.
+// legal is never empty.
+type Strategy interface {
+	Name() string
+	ChooseAction(ctx context.Context, state *models.FullGameState, userID int64, legal []gamelogic.Action) (*gamelogic.Action, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Strategy{}
+)
+
+// Register makes a strategy available to bot seats under its name
+func Register(strategy Strategy) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[strategy.Name()] = strategy
+}
+
+// RegisterBuiltins registers the built-in difficulty levels: easy plays at
+// random, medium is greedy and hard searches with MCTS. budget bounds how
+// long medium and hard may think about a move; both fill in the cards they
+// cannot see from catalog.
+func RegisterBuiltins(catalog CardCatalog, budget time.Duration) {
+	Register(NewRandomStrategy())
+	Register(NewGreedyStrategy(catalog, budget))
+	Register(NewMCTSStrategy(catalog, budget))
+}
+
+// Lookup returns the strategy registered under name
+func Lookup(name string) (Strategy, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	strategy, ok := registry[name]
+	return strategy, ok
+}
+
+// Names returns the registered strategy names in sorted order
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
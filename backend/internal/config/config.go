@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -15,6 +16,7 @@ type Config struct {
 	JWTSecret           string
 	JWTAccessExpiry     int64 // seconds
 	JWTRefreshExpiry    int64 // seconds
+	GuestRefreshExpiry  int64 // seconds
 
 	// CORS Configuration
 	AllowedOrigins []string
@@ -22,6 +24,47 @@ type Config struct {
 	// WebSocket Configuration
 	WSReadTimeout  int64 // seconds
 	WSWriteTimeout int64 // seconds
+	WSBackplane    string // "local" or "postgres"
+
+	// Chat Configuration
+	ChatMaxLength    int
+	ChatRateLimit    int   // messages per window
+	ChatRateWindow   int64 // seconds
+	ChatBlockedWords []string
+
+	// Bot Configuration
+	BotMoveDelay    int64 // milliseconds between bot moves
+	BotThinkTime    int64 // milliseconds a bot may spend choosing a move
+	BotTurnDeadline int64 // seconds an external bot has to reply to a turn
+
+	// Game Configuration
+	SnapshotInterval int // moves between full game snapshots
+
+	// Rating Configuration
+	RatingInitial          float64
+	RatingKFactor          float64
+	RatingProvisionalK     float64
+	RatingProvisionalGames int     // rated games before a rating is established
+	LeaderboardMinGames    int     // rated games needed to appear on the leaderboard
+	SeasonResetFactor      float64 // share of a rating's distance from initial kept at a season start
+
+	// Matchmaking Configuration
+	MatchBaseWindow   float64 // rating difference accepted right away
+	MatchWindowGrowth float64 // added to the window per second of waiting
+	MatchMaxWindow    float64
+	MatchInterval     int64 // milliseconds between matching passes
+
+	// Tournament Configuration
+	TournamentInterval int64 // seconds between passes that resume stalled tournaments
+
+	// Game Over Configuration
+	FinalizeInterval int64 // seconds between retries of games whose game-over pipeline failed
+
+	// Analytics Configuration
+	AnalyticsInterval int64 // seconds between analytics passes over finished games
+
+	// Session Configuration
+	TokenCleanupInterval int64 // seconds between deletions of expired refresh tokens
 }
 
 func Load() (*Config, error) {
@@ -36,6 +79,7 @@ func Load() (*Config, error) {
 		JWTSecret:        getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
 		JWTAccessExpiry:  15 * 60,        // 15 minutes in seconds
 		JWTRefreshExpiry: 7 * 24 * 60 * 60, // 7 days in seconds
+		GuestRefreshExpiry: 24 * 60 * 60,   // 1 day in seconds
 
 		AllowedOrigins: []string{
 			getEnv("FRONTEND_URL", "http://localhost:5173"),
@@ -43,6 +87,38 @@ func Load() (*Config, error) {
 
 		WSReadTimeout:  60, // 60 seconds
 		WSWriteTimeout: 10, // 10 seconds
+		WSBackplane:    getEnv("WS_BACKPLANE", "local"),
+
+		ChatMaxLength:    500,
+		ChatRateLimit:    5,
+		ChatRateWindow:   10,
+		ChatBlockedWords: getEnvList("CHAT_BLOCKED_WORDS"),
+
+		BotMoveDelay:    800,
+		BotThinkTime:    1000,
+		BotTurnDeadline: 10,
+
+		SnapshotInterval: 10,
+
+		RatingInitial:          1500,
+		RatingKFactor:          24,
+		RatingProvisionalK:     48,
+		RatingProvisionalGames: 10,
+		LeaderboardMinGames:    5,
+		SeasonResetFactor:      0.5,
+
+		MatchBaseWindow:   100,
+		MatchWindowGrowth: 10,
+		MatchMaxWindow:    1000,
+		MatchInterval:     2000,
+
+		TournamentInterval: 60,
+
+		FinalizeInterval: 60,
+
+		AnalyticsInterval: 60,
+
+		TokenCleanupInterval: 60 * 60,
 	}
 
 	return cfg, nil
@@ -54,3 +130,11 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvList(key string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// UserAchievement is an achievement a player unlocked
+type UserAchievement struct {
+	UserID     int64     `json:"user_id"`
+	Key        string    `json:"key"`
+	GameID     *int64    `json:"game_id,omitempty"`
+	UnlockedAt time.Time `json:"unlocked_at"`
+}
+
+// AchievementStatus is one achievement on a player's profile
+type AchievementStatus struct {
+	Key         string     `json:"key"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Unlocked    bool       `json:"unlocked"`
+	UnlockedAt  *time.Time `json:"unlocked_at,omitempty"`
+	GameID      *int64     `json:"game_id,omitempty"`
+}
+
+type UserAchievementsResponse struct {
+	Achievements []*AchievementStatus `json:"achievements"`
+	Unlocked     int                  `json:"unlocked"`
+	Total        int                  `json:"total"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// GameAnalytics is how a player played one game, derived from its move log.
+// Turns count the player's own turns.
+type GameAnalytics struct {
+	GameID            int64     `json:"game_id"`
+	UserID            int64     `json:"user_id"`
+	Turns             int       `json:"turns"`
+	FirstNobleTurn    *int      `json:"first_noble_turn,omitempty"` // nil if no noble visited
+	Tier1Purchases    int       `json:"tier1_purchases"`
+	Tier2Purchases    int       `json:"tier2_purchases"`
+	Tier3Purchases    int       `json:"tier3_purchases"`
+	GoldPurchases     int       `json:"gold_purchases"` // Purchases paid partly with gold
+	GoldSpent         int       `json:"gold_spent"`
+	Reserves          int       `json:"reserves"`
+	ReservedPurchases int       `json:"reserved_purchases"` // Purchases of reserved cards
+	TokensAtEnd       int       `json:"tokens_at_end"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// AnalyticsTotals sums a player's analyzed games
+type AnalyticsTotals struct {
+	Games             int
+	GamesWithNoble    int
+	FirstNobleTurns   int // Sum over games with a noble
+	Tier1Purchases    int
+	Tier2Purchases    int
+	Tier3Purchases    int
+	GoldPurchases     int
+	GoldSpent         int
+	Reserves          int
+	ReservedPurchases int
+	TokensAtEnd       int
+}
+
+// UserAnalytics summarizes how a player plays across their analyzed games
+type UserAnalytics struct {
+	UserID                int64            `json:"user_id"`
+	GamesAnalyzed         int              `json:"games_analyzed"`
+	AverageFirstNobleTurn *float64         `json:"average_first_noble_turn,omitempty"` // Over games with a noble
+	NobleGameRate         float64          `json:"noble_game_rate"`                    // Share of games with a noble
+	TierMix               map[int]float64  `json:"tier_mix"`                           // Share of purchases by tier
+	GoldUsageRate         float64          `json:"gold_usage_rate"`                    // Share of purchases using gold
+	AverageGoldSpent      float64          `json:"average_gold_spent"`                 // Per game
+	AverageReserves       float64          `json:"average_reserves"`                   // Per game
+	ReserveConversion     float64          `json:"reserve_conversion"`                 // Reserved cards later bought
+	AverageTokensAtEnd    float64          `json:"average_tokens_at_end"`
+	RecentGames           []*GameAnalytics `json:"recent_games"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// BotTurn is a turn offered to an external bot. LegalActions lists every
+// move the bot may reply with; State is the bot's own view of the game.
+type BotTurn struct {
+	GameID       int64           `json:"game_id"`
+	UserID       int64           `json:"-"`
+	TurnNumber   int             `json:"turn_number"`
+	LegalActions json.RawMessage `json:"legal_actions"`
+	Deadline     time.Time       `json:"deadline"`
+	State        *FullGameState  `json:"state,omitempty"`
+}
+
+type CreateBotRequest struct {
+	Username string `json:"username" binding:"required,min=3,max=50"`
+}
+
+type CreateBotResponse struct {
+	Bot   *User  `json:"bot"`
+	Token string `json:"token"` // Only ever shown once
+}
+
+type BotMoveRequest struct {
+	TurnNumber int             `json:"turn_number"`
+	Action     json.RawMessage `json:"action" binding:"required"`
+}
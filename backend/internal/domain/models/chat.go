@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// ChatMessage is a chat line stamped by the server with its sender
+type ChatMessage struct {
+	ID        int64     `json:"id"`
+	GameID    int64     `json:"game_id"`
+	UserID    int64     `json:"user_id"`
+	Username  string    `json:"username"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MuteRequest struct {
+	UserID int64 `json:"user_id" binding:"required"`
+}
@@ -19,13 +19,18 @@ type Game struct {
 	WinnerID           *int64     `json:"winner_id,omitempty"`
 	CreatedBy          int64      `json:"created_by"`
 	NumPlayers         int        `json:"num_players"`
+	SpectatorDelaySeconds int     `json:"spectator_delay_seconds"`
+	AllowHints         bool       `json:"allow_hints"`
+	HintsUsed          bool       `json:"hints_used"` // Never set in ranked games, which allow no hints
+	Ranked             bool       `json:"ranked"`     // Undo and hints are disabled
 	CreatedAt          time.Time  `json:"created_at"`
 	StartedAt          *time.Time `json:"started_at,omitempty"`
 	CompletedAt        *time.Time `json:"completed_at,omitempty"`
 
 	// Populated fields (not in DB)
-	Players []*GamePlayer `json:"players,omitempty"`
-	Creator *User         `json:"creator,omitempty"`
+	Players        []*GamePlayer `json:"players,omitempty"`
+	Creator        *User         `json:"creator,omitempty"`
+	SpectatorCount int           `json:"spectator_count"`
 }
 
 type GamePlayer struct {
@@ -35,6 +40,7 @@ type GamePlayer struct {
 	PlayerPosition int       `json:"player_position"`
 	VictoryPoints  int       `json:"victory_points"`
 	IsActive       bool      `json:"is_active"`
+	BotStrategy    string    `json:"bot_strategy,omitempty"` // Empty for human players
 	JoinedAt       time.Time `json:"joined_at"`
 
 	// Populated field
@@ -42,7 +48,21 @@ type GamePlayer struct {
 }
 
 type CreateGameRequest struct {
-	NumPlayers int `json:"num_players" binding:"required,min=2,max=4"`
+	NumPlayers            int `json:"num_players" binding:"required,min=2,max=4"`
+	SpectatorDelaySeconds int `json:"spectator_delay_seconds" binding:"min=0,max=600"`
+	AllowHints            bool `json:"allow_hints"`
+}
+
+// LobbyGame is the summary of a game announced on the lobby channel, which
+// anyone may listen to. It leaves out room codes and account details.
+type LobbyGame struct {
+	ID            int64      `json:"id"`
+	Status        GameStatus `json:"status"`
+	NumPlayers    int        `json:"num_players"`
+	SeatedPlayers int        `json:"seated_players"`
+	Players       []string   `json:"players"` // Usernames of the seated players
+	Ranked        bool       `json:"ranked"`
+	WinnerID      *int64     `json:"winner_id,omitempty"`
 }
 
 type CreateGameResponse struct {
@@ -50,6 +70,11 @@ type CreateGameResponse struct {
 	RoomCode string `json:"room_code"`
 }
 
+type AddBotRequest struct {
+	Strategy  string `json:"strategy"`    // Defaults to the default strategy
+	BotUserID int64  `json:"bot_user_id"` // Registered bot account, for external bots
+}
+
 type JoinGameRequest struct {
 	RoomCode string `json:"room_code" binding:"required"`
 }
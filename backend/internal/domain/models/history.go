@@ -0,0 +1,81 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// MatchHistoryFilter narrows a user's match history. Zero values don't filter.
+type MatchHistoryFilter struct {
+	NumPlayers int        // Only games with this many seats
+	From       *time.Time // Only games finished at or after this time
+	To         *time.Time // Only games finished before this time
+	OpponentID int64      // Only games against this user
+	Limit      int
+	Offset     int
+}
+
+// MatchHistoryEntry is one finished game from a player's point of view
+type MatchHistoryEntry struct {
+	GameID          int64            `json:"game_id"`
+	RoomCode        string           `json:"room_code"`
+	NumPlayers      int              `json:"num_players"`
+	Ranked          bool             `json:"ranked"`
+	WinnerID        *int64           `json:"winner_id,omitempty"`
+	StartedAt       *time.Time       `json:"started_at,omitempty"`
+	CompletedAt     *time.Time       `json:"completed_at,omitempty"`
+	DurationSeconds int              `json:"duration_seconds"`
+	Placement       int              `json:"placement"`
+	VictoryPoints   int              `json:"victory_points"`
+	CardsPurchased  int              `json:"cards_purchased"`
+	NoblesEarned    int              `json:"nobles_earned"`
+	Moves           int              `json:"moves"`
+	Opponents       []*MatchOpponent `json:"opponents"`
+}
+
+// MatchOpponent is another seat in a history entry's game
+type MatchOpponent struct {
+	UserID        int64  `json:"user_id"`
+	Username      string `json:"username"`
+	IsBot         bool   `json:"is_bot"`
+	Placement     int    `json:"placement"`
+	VictoryPoints int    `json:"victory_points"`
+}
+
+type MatchHistoryResponse struct {
+	Games []*MatchHistoryEntry `json:"games"`
+	Total int                  `json:"total"`
+}
+
+// TimelineEntry is one move in a game's turn-by-turn timeline
+type TimelineEntry struct {
+	MoveNumber int             `json:"move_number"`
+	UserID     int64           `json:"user_id"`
+	Username   string          `json:"username"`
+	MoveType   string          `json:"move_type"`
+	MoveData   json.RawMessage `json:"move_data"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// GameSummary is a finished game's scoreboard and timeline
+type GameSummary struct {
+	*FinalStandings
+	RoomCode  string           `json:"room_code"`
+	StartedAt *time.Time       `json:"started_at,omitempty"`
+	Timeline  []*TimelineEntry `json:"timeline"`
+}
+
+// HeadToHead is how a player has done against one opponent in the games
+// they both played. Ahead and Behind compare the two players' placements.
+type HeadToHead struct {
+	UserID           int64      `json:"user_id"`
+	OpponentID       int64      `json:"opponent_id"`
+	OpponentUsername string     `json:"opponent_username"`
+	Games            int        `json:"games"`
+	Ahead            int        `json:"ahead"`  // Games the player finished ahead
+	Behind           int        `json:"behind"` // Games the opponent finished ahead
+	Wins             int        `json:"wins"`   // Shared games the player won
+	OpponentWins     int        `json:"opponent_wins"`
+	AverageMargin    float64    `json:"average_margin"` // Player's points minus the opponent's, per game
+	LastPlayedAt     *time.Time `json:"last_played_at,omitempty"`
+}
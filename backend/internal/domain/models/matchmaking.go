@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Matchmaking statuses
+const (
+	QueueIdle    = "idle"
+	QueueWaiting = "queued"
+	QueueMatched = "matched"
+)
+
+// QueueEntry is a player's row in the matchmaking queue
+type QueueEntry struct {
+	UserID     int64     `json:"user_id"`
+	NumPlayers int       `json:"num_players"`
+	Ranked     bool      `json:"ranked"`
+	Rating     float64   `json:"rating"`
+	JoinedAt   time.Time `json:"joined_at"`
+	GameID     *int64    `json:"game_id,omitempty"` // Set once matched
+}
+
+type JoinQueueRequest struct {
+	NumPlayers int  `json:"num_players" binding:"required,min=2,max=4"`
+	Ranked     bool `json:"ranked"`
+}
+
+// QueueStatus is a player's place in the matchmaking queue
+type QueueStatus struct {
+	Status      string     `json:"status"`
+	NumPlayers  int        `json:"num_players,omitempty"`
+	Ranked      bool       `json:"ranked"`
+	Rating      float64    `json:"rating,omitempty"`
+	Window      float64    `json:"window,omitempty"` // Rating difference currently accepted
+	JoinedAt    *time.Time `json:"joined_at,omitempty"`
+	WaitSeconds int        `json:"wait_seconds,omitempty"`
+	GameID      int64      `json:"game_id,omitempty"` // Set once matched
+	Queued      int        `json:"queued"`            // Players waiting for the same mode
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// GameMove is an entry in a game's move log
+type GameMove struct {
+	ID           int64           `json:"id"`
+	GameID       int64           `json:"game_id"`
+	GamePlayerID int64           `json:"game_player_id"`
+	UserID       int64           `json:"user_id"` // Populated from the seat
+	MoveNumber   int             `json:"move_number"`
+	MoveType     string          `json:"move_type"`
+	MoveData     json.RawMessage `json:"move_data"`
+	StateBefore  json.RawMessage `json:"-"` // What the move changed, for undo
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// Undo request statuses
+const (
+	UndoPending = "pending"
+	UndoApplied = "applied"
+	UndoDenied  = "denied"
+)
+
+// UndoRequest asks the other players to let the last move be taken back
+type UndoRequest struct {
+	GameID      int64     `json:"game_id"`
+	MoveID      int64     `json:"move_id"`
+	RequestedBy int64     `json:"requested_by"`
+	Approvals   []int64   `json:"approvals"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+}
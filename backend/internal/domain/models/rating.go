@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// PlayerRating is a player's current skill rating
+type PlayerRating struct {
+	UserID      int64     `json:"user_id"`
+	Rating      float64   `json:"rating"`
+	PeakRating  float64   `json:"peak_rating"`
+	GamesRated  int       `json:"games_rated"`
+	Provisional bool      `json:"provisional"` // Too few rated games for a settled rating
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// RatingChange is the change one rated game made to a player's rating
+type RatingChange struct {
+	ID           int64     `json:"id"`
+	UserID       int64     `json:"user_id"`
+	GameID       int64     `json:"game_id"`
+	SeasonID     *int64    `json:"season_id,omitempty"` // Season the game was played in
+	RatingBefore float64   `json:"rating_before"`
+	RatingAfter  float64   `json:"rating_after"`
+	Placement    int       `json:"placement"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type RatingResponse struct {
+	Rating  *PlayerRating   `json:"rating"`
+	History []*RatingChange `json:"history"`
+}
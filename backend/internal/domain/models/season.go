@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Season is a ranked season. A running season has no EndedAt.
+type Season struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+}
+
+type StartSeasonRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Snapshot reasons
+const (
+	SnapshotInterval   = "interval"
+	SnapshotStart      = "start"
+	SnapshotFinish     = "finish"
+	SnapshotUndo       = "undo"
+	SnapshotPreRestore = "pre_restore"
+	SnapshotManual     = "manual"
+)
+
+// GameSnapshot is a full copy of a game's state at one point in time
+type GameSnapshot struct {
+	ID         int64           `json:"id"`
+	GameID     int64           `json:"game_id"`
+	TurnNumber int             `json:"turn_number"`
+	Reason     string          `json:"reason"`
+	State      json.RawMessage `json:"-"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// AuditEntry records an admin action
+type AuditEntry struct {
+	ID        int64           `json:"id"`
+	AdminID   int64           `json:"admin_id"`
+	Action    string          `json:"action"`
+	GameID    *int64          `json:"game_id,omitempty"`
+	Details   json.RawMessage `json:"details"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type RestoreSnapshotRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
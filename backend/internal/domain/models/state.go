@@ -28,8 +28,13 @@ type PlayerState struct {
 	PermanentGems  map[string]int     `json:"permanent_gems"`
 	PurchasedCards []DevelopmentCard  `json:"purchased_cards"`
 	ReservedCards  []DevelopmentCard  `json:"reserved_cards"`
+	BlindReserved  []int64            `json:"-"` // IDs of cards reserved from a deck
 	Nobles         []Noble            `json:"nobles"`
 	UpdatedAt      time.Time          `json:"updated_at"`
+
+	// Set on redacted views where some reserved cards are hidden
+	ReservedCount       int   `json:"reserved_count,omitempty"`
+	HiddenReservedTiers []int `json:"hidden_reserved_tiers,omitempty"`
 }
 
 // DevelopmentCard represents a development card
@@ -17,10 +17,83 @@ type GameStatistics struct {
 }
 
 type LeaderboardEntry struct {
+	Rank          int     `json:"rank"`
 	UserID        int64   `json:"user_id"`
 	Username      string  `json:"username"`
+	Rating        float64 `json:"rating"`
+	GamesRated    int     `json:"games_rated"`
+	Provisional   bool    `json:"provisional"`
 	TotalGames    int     `json:"total_games"`
 	TotalWins     int     `json:"total_wins"`
 	AveragePoints float64 `json:"average_points"`
 	WinRate       float64 `json:"win_rate"`
 }
+
+// GameResult is one player's final standing in a finished game
+type GameResult struct {
+	ID             int64          `json:"-"`
+	GameID         int64          `json:"game_id"`
+	UserID         int64          `json:"user_id"`
+	Username       string         `json:"username"`
+	IsBot          bool           `json:"is_bot"`
+	Placement      int            `json:"placement"` // 1 is the winner
+	VictoryPoints  int            `json:"victory_points"`
+	CardsPurchased int            `json:"cards_purchased"`
+	NoblesEarned   int            `json:"nobles_earned"`
+	Moves          int            `json:"moves"`
+	CardsByGem     map[string]int `json:"cards_by_gem"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+// FinalStandings is the scoreboard of a finished game, best first
+type FinalStandings struct {
+	GameID          int64         `json:"game_id"`
+	WinnerID        *int64        `json:"winner_id,omitempty"`
+	TurnNumber      int           `json:"turn_number"`
+	CompletedAt     *time.Time    `json:"completed_at,omitempty"`
+	DurationSeconds int           `json:"duration_seconds"`
+	Standings       []*GameResult `json:"standings"`
+}
+
+// CardStat is how often a development card is bought across finished
+// games, and how its buyers fare
+type CardStat struct {
+	CardID          int64   `json:"card_id"`
+	Tier            int     `json:"tier"`
+	GemType         string  `json:"gem_type"`
+	VictoryPoints   int     `json:"victory_points"`
+	Purchases       int     `json:"purchases"`
+	PurchaseRate    float64 `json:"purchase_rate"` // Share of games the card was bought in
+	WinnerPurchases int     `json:"winner_purchases"`
+	BuyerWinRate    float64 `json:"buyer_win_rate"`
+	WinLift         float64 `json:"win_lift"` // Buyer wins over the wins expected by player count; above 1 favors the card
+	ExpectedWins    float64 `json:"-"`
+}
+
+// NobleStat is how often a noble is claimed across finished games
+type NobleStat struct {
+	NobleID        int64   `json:"noble_id"`
+	Name           string  `json:"name"`
+	VictoryPoints  int     `json:"victory_points"`
+	Claims         int     `json:"claims"`
+	ClaimRate      float64 `json:"claim_rate"` // Share of games the noble was claimed in
+	WinnerClaims   int     `json:"winner_claims"`
+	ClaimerWinRate float64 `json:"claimer_win_rate"`
+}
+
+// SeatStat is how often a seat wins at a player count. Seat 0 moves first.
+type SeatStat struct {
+	NumPlayers   int     `json:"num_players"`
+	SeatPosition int     `json:"seat_position"`
+	Games        int     `json:"games"`
+	Wins         int     `json:"wins"`
+	WinRate      float64 `json:"win_rate"`
+}
+
+// CardStatsResponse holds the balance statistics of all finished games
+type CardStatsResponse struct {
+	Games  int          `json:"games"`
+	Cards  []*CardStat  `json:"cards"`
+	Nobles []*NobleStat `json:"nobles"`
+	Seats  []*SeatStat  `json:"seats"`
+}
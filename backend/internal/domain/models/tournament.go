@@ -0,0 +1,97 @@
+package models
+
+import "time"
+
+// Tournament formats
+const (
+	TournamentSwiss       = "swiss"
+	TournamentElimination = "elimination"
+)
+
+// Tournament statuses
+const (
+	TournamentRegistration = "registration"
+	TournamentInProgress   = "in_progress"
+	TournamentCompleted    = "completed"
+)
+
+// Tournament table statuses
+const (
+	TableSeating  = "seating" // Claimed, its game not created yet
+	TablePlaying  = "playing"
+	TableFinished = "finished"
+	TableBye      = "bye"
+)
+
+type Tournament struct {
+	ID           int64      `json:"id"`
+	Name         string     `json:"name"`
+	Format       string     `json:"format"`
+	TableSize    int        `json:"table_size"`
+	Rounds       int        `json:"rounds"` // Planned rounds; 0 for elimination, which runs until one player is left
+	CurrentRound int        `json:"current_round"`
+	Status       string     `json:"status"`
+	Ranked       bool       `json:"ranked"`
+	WinnerID     *int64     `json:"winner_id,omitempty"`
+	CreatedBy    int64      `json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+
+	// Populated fields (not in DB)
+	Players []*TournamentPlayer `json:"players,omitempty"`
+	Tables  []*TournamentTable  `json:"tables,omitempty"`
+}
+
+type TournamentPlayer struct {
+	UserID       int64     `json:"user_id"`
+	Username     string    `json:"username"`
+	Seed         int       `json:"seed"` // 1 is the highest rated; set when the tournament starts
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+// TournamentTable seats players for one round
+type TournamentTable struct {
+	ID           int64   `json:"id"`
+	TournamentID int64   `json:"tournament_id"`
+	Round        int     `json:"round"`
+	TableNumber  int     `json:"table_number"`
+	GameID       *int64  `json:"game_id,omitempty"`
+	PlayerIDs    []int64 `json:"player_ids"`
+	Status       string  `json:"status"`
+}
+
+// TournamentResult is one seat's result at a finished table
+type TournamentResult struct {
+	TableID       int64
+	Round         int
+	UserID        int64
+	Placement     int
+	VictoryPoints int
+}
+
+// TournamentStanding is a player's place in a tournament. Swiss players are
+// ranked by match points, then Buchholz (their opponents' match points),
+// then victory points, then seed. Elimination players are ranked by the
+// round they reached, then their placement and points in it.
+type TournamentStanding struct {
+	Rank            int     `json:"rank"`
+	UserID          int64   `json:"user_id"`
+	Username        string  `json:"username"`
+	Seed            int     `json:"seed"`
+	MatchPoints     float64 `json:"match_points"`
+	Buchholz        float64 `json:"buchholz"`
+	VictoryPoints   int     `json:"victory_points"`
+	GamesPlayed     int     `json:"games_played"`
+	Wins            int     `json:"wins"`
+	Byes            int     `json:"byes"`
+	EliminatedRound int     `json:"eliminated_round,omitempty"`
+}
+
+type CreateTournamentRequest struct {
+	Name      string `json:"name" binding:"required,min=1,max=100"`
+	Format    string `json:"format" binding:"required,oneof=swiss elimination"`
+	TableSize int    `json:"table_size" binding:"required,min=2,max=4"`
+	Rounds    int    `json:"rounds" binding:"min=0,max=10"` // Swiss only; defaults to 3
+	Ranked    bool   `json:"ranked"`
+}
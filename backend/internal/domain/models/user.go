@@ -7,6 +7,10 @@ type User struct {
 	Username     string    `json:"username"`
 	Email        string    `json:"email"`
 	PasswordHash string    `json:"-"` // Never send to client
+	IsBot        bool      `json:"is_bot"`
+	IsGuest      bool      `json:"is_guest"`
+	BotOwnerID   *int64    `json:"bot_owner_id,omitempty"` // Set for external bots
+	IsAdmin      bool      `json:"is_admin,omitempty"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
@@ -22,8 +26,30 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// GuestRequest starts a guest session under a display name
+type GuestRequest struct {
+	Username string `json:"username" binding:"required,min=3,max=50"`
+}
+
+// UpgradeGuestRequest turns a guest into a registered account
+type UpgradeGuestRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
 type AuthResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
 	User         *User  `json:"user"`
 }
+
+// RefreshToken is the server-side record of an issued refresh token
+type RefreshToken struct {
+	ID        string     `json:"id"`
+	UserID    int64      `json:"user_id"`
+	FamilyID  string     `json:"family_id"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
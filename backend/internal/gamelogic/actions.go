@@ -3,7 +3,7 @@ package gamelogic
 import (
 	"context"
 	"fmt"
-	"time"
+	"maps"
 
 	"splendor-backend/internal/domain/models"
 )
@@ -54,14 +54,14 @@ func (e *GameEngine) TakeGems(ctx context.Context, gameID, userID int64, gems ma
 		return err
 	}
 
-	// Execute: Remove gems from bank, add to player
-	for gemType, count := range gems {
-		if count > 0 {
-			gameState.AvailableGems[gemType] -= count
-			playerState.Gems[gemType] += count
-		}
+	stateBefore, err := snapshotMove(game, gameState, currentPlayer, playerState)
+	if err != nil {
+		return err
 	}
 
+	// Execute: Remove gems from bank, add to player
+	takeGems(gameState, playerState, gems)
+
 	// Update game state
 	if err := e.stateRepo.UpdateGameState(ctx, gameState); err != nil {
 		return err
@@ -77,6 +77,9 @@ func (e *GameEngine) TakeGems(ctx context.Context, gameID, userID int64, gems ma
 		return err
 	}
 
+	action := &Action{Type: ActionTakeGems, Gems: gems}
+	e.recordMove(ctx, gameID, currentPlayer, action, stateBefore)
+	e.movePlayed(ctx, &MoveEvent{GameID: gameID, UserID: userID, Action: action, State: playerState})
 	return nil
 }
 
@@ -127,53 +130,23 @@ func (e *GameEngine) PurchaseCard(ctx context.Context, gameID, userID int64, car
 	}
 
 	// Validate can afford
-	if err := validator.ValidatePurchaseCard(gameState, playerState, card); err != nil {
+	if err := validator.ValidatePurchaseCard(gameState, playerState, card, fromReserve); err != nil {
 		return err
 	}
 
-	// Calculate actual cost
-	actualCost := validator.CalculateCost(card, playerState)
-
-	// Pay cost
-	for gemType, cost := range actualCost {
-		playerState.Gems[gemType] -= cost
-		gameState.AvailableGems[gemType] += cost
-	}
-
-	// Add card to player
-	playerState.PurchasedCards = append(playerState.PurchasedCards, *card)
-	playerState.PermanentGems[card.GemType]++
-
-	// Remove from reserve if applicable
-	if fromReserve {
-		newReserved := []models.DevelopmentCard{}
-		for _, c := range playerState.ReservedCards {
-			if c.ID != cardID {
-				newReserved = append(newReserved, c)
-			}
-		}
-		playerState.ReservedCards = newReserved
-	} else {
-		// Remove from visible cards and replace
-		e.removeAndReplaceCard(gameState, card)
-	}
-
-	// Update victory points
-	currentPlayer.VictoryPoints += card.VictoryPoints
-	if err := e.gameRepo.UpdatePlayer(ctx, currentPlayer); err != nil {
+	stateBefore, err := snapshotMove(game, gameState, currentPlayer, playerState)
+	if err != nil {
 		return err
 	}
 
-	// Check for noble visits
-	for i, noble := range gameState.AvailableNobles {
-		if validator.CheckNobleVisit(playerState, &noble) {
-			// Noble visits player
-			playerState.Nobles = append(playerState.Nobles, noble)
-			currentPlayer.VictoryPoints += noble.VictoryPoints
+	// Pay, take the card and any visiting noble
+	gemsBefore := maps.Clone(playerState.Gems)
+	purchaseCard(gameState, playerState, currentPlayer, card, fromReserve)
 
-			// Remove noble from available
-			gameState.AvailableNobles = append(gameState.AvailableNobles[:i], gameState.AvailableNobles[i+1:]...)
-			break // Only one noble per turn
+	paid := make(map[string]int)
+	for gemType, count := range gemsBefore {
+		if spent := count - playerState.Gems[gemType]; spent > 0 {
+			paid[gemType] = spent
 		}
 	}
 
@@ -191,30 +164,33 @@ func (e *GameEngine) PurchaseCard(ctx context.Context, gameID, userID int64, car
 	}
 
 	// Check victory condition
-	if validator.CheckVictoryCondition(players) {
-		// Someone reached 15 points - end the game
-		winner := validator.DetermineWinner(players, map[int64]*models.PlayerState{})
-
-		// Get all player states for proper winner determination
-		playerStates := make(map[int64]*models.PlayerState)
-		for _, p := range players {
-			state, err := e.stateRepo.GetPlayerState(ctx, p.ID)
-			if err == nil {
-				playerStates[p.UserID] = state
-			}
+	playerStates := make(map[int64]*models.PlayerState)
+	for _, p := range players {
+		if p.ID == currentPlayer.ID {
+			playerStates[p.UserID] = playerState
+			continue
 		}
-		winner = validator.DetermineWinner(players, playerStates)
+		state, err := e.stateRepo.GetPlayerState(ctx, p.ID)
+		if err == nil {
+			playerStates[p.UserID] = state
+		}
+	}
 
-		// Update game status
-		game.Status = models.GameStatusCompleted
-		game.WinnerID = &winner.UserID
-		now := time.Now()
-		game.CompletedAt = &now
+	action := &Action{Type: ActionPurchaseCard, CardID: cardID, FromReserve: fromReserve}
+	event := &MoveEvent{GameID: gameID, UserID: userID, Action: action, Card: card, Paid: paid, State: playerState}
 
+	if finishIfWon(game, players, playerStates) {
+		// Someone reached 15 points - end the game
 		if err := e.gameRepo.Update(ctx, game); err != nil {
 			return fmt.Errorf("failed to update game status: %w", err)
 		}
 
+		e.recordMove(ctx, gameID, currentPlayer, action, stateBefore)
+		e.movePlayed(ctx, event)
+		e.snapshotOnEvent(ctx, gameID, models.SnapshotFinish)
+		if e.onFinish != nil {
+			e.onFinish(ctx, gameID)
+		}
 		return nil
 	}
 
@@ -223,6 +199,8 @@ func (e *GameEngine) PurchaseCard(ctx context.Context, gameID, userID int64, car
 		return err
 	}
 
+	e.recordMove(ctx, gameID, currentPlayer, action, stateBefore)
+	e.movePlayed(ctx, event)
 	return nil
 }
 
@@ -280,22 +258,15 @@ func (e *GameEngine) ReserveCard(ctx context.Context, gameID, userID int64, card
 			return err
 		}
 		card = c
-		e.removeAndReplaceCard(gameState, card)
-	} else {
-		// Reserve from deck (blind)
-		card = e.drawCardFromDeck(gameState, tier)
-		if card == nil {
-			return fmt.Errorf("no cards left in tier %d", tier)
-		}
 	}
 
-	// Add to reserved
-	playerState.ReservedCards = append(playerState.ReservedCards, *card)
+	stateBefore, err := snapshotMove(game, gameState, currentPlayer, playerState)
+	if err != nil {
+		return err
+	}
 
-	// Give gold coin if available
-	if gameState.AvailableGems["gold"] > 0 {
-		gameState.AvailableGems["gold"]--
-		playerState.Gems["gold"]++
+	if err := reserveCard(gameState, playerState, card, tier); err != nil {
+		return err
 	}
 
 	// Update states
@@ -312,96 +283,137 @@ func (e *GameEngine) ReserveCard(ctx context.Context, gameID, userID int64, card
 		return err
 	}
 
+	action := &Action{Type: ActionReserveCard, CardID: cardID, Tier: tier}
+	e.recordMove(ctx, gameID, currentPlayer, action, stateBefore)
+	e.movePlayed(ctx, &MoveEvent{GameID: gameID, UserID: userID, Action: action, Card: card, State: playerState})
 	return nil
 }
 
-// Helper functions
-func (e *GameEngine) switchTurn(ctx context.Context, game *models.Game, players []*models.GamePlayer) error {
-	// Find current player index
-	currentIndex := -1
-	for i, p := range players {
-		if game.CurrentTurnPlayerID != nil && p.UserID == *game.CurrentTurnPlayerID {
-			currentIndex = i
-			break
-		}
+// Pass executes the pass action, allowed only when the player has no other
+// move
+func (e *GameEngine) Pass(ctx context.Context, gameID, userID int64) error {
+	state, err := e.loadGameState(ctx, gameID)
+	if err != nil {
+		return err
 	}
 
-	// Next player
-	nextIndex := (currentIndex + 1) % len(players)
-	game.CurrentTurnPlayerID = &players[nextIndex].UserID
-	game.TurnNumber++
+	if !CanOnlyPass(state, userID) {
+		return ErrCannotPass
+	}
 
-	return e.gameRepo.Update(ctx, game)
+	return e.passTurn(ctx, gameID, userID)
 }
 
-func (e *GameEngine) removeAndReplaceCard(gameState *models.GameState, card *models.DevelopmentCard) {
-	switch card.Tier {
-	case 1:
-		newCards := []models.DevelopmentCard{}
-		for _, c := range gameState.VisibleCardsTier1 {
-			if c.ID != card.ID {
-				newCards = append(newCards, c)
-			}
-		}
-		gameState.VisibleCardsTier1 = newCards
-		// Replace with new card from deck
-		if len(gameState.DeckTier1) > 0 {
-			gameState.VisibleCardsTier1 = append(gameState.VisibleCardsTier1, gameState.DeckTier1[0])
-			gameState.DeckTier1 = gameState.DeckTier1[1:]
-			gameState.DeckTier1Count--
+// passTurn ends userID's turn without a move. A pass that completes a round
+// of passes ends the game as it stands.
+func (e *GameEngine) passTurn(ctx context.Context, gameID, userID int64) error {
+	validator := NewGameValidator()
+
+	// Get game
+	game, err := e.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return err
+	}
+
+	// Validate turn
+	if err := validator.ValidateTurn(game, userID); err != nil {
+		return err
+	}
+
+	// Get player
+	players, err := e.gameRepo.GetPlayers(ctx, gameID)
+	if err != nil {
+		return err
+	}
+
+	var currentPlayer *models.GamePlayer
+	for _, p := range players {
+		if p.UserID == userID {
+			currentPlayer = p
+			break
 		}
-	case 2:
-		newCards := []models.DevelopmentCard{}
-		for _, c := range gameState.VisibleCardsTier2 {
-			if c.ID != card.ID {
-				newCards = append(newCards, c)
+	}
+
+	// Get states
+	gameState, err := e.stateRepo.GetGameState(ctx, gameID)
+	if err != nil {
+		return err
+	}
+
+	playerState, err := e.stateRepo.GetPlayerState(ctx, currentPlayer.ID)
+	if err != nil {
+		return err
+	}
+
+	stateBefore, err := snapshotMove(game, gameState, currentPlayer, playerState)
+	if err != nil {
+		return err
+	}
+
+	action := &Action{Type: ActionPass}
+	event := &MoveEvent{GameID: gameID, UserID: userID, Action: action, State: playerState}
+
+	stalemate, err := e.othersPassed(ctx, gameID, len(players)-1)
+	if err != nil {
+		return err
+	}
+
+	if stalemate {
+		// Every player passed in turn, so nobody can move again - end the
+		// game as it stands
+		playerStates := make(map[int64]*models.PlayerState)
+		for _, p := range players {
+			state, err := e.stateRepo.GetPlayerState(ctx, p.ID)
+			if err != nil {
+				return err
 			}
+			playerStates[p.UserID] = state
 		}
-		gameState.VisibleCardsTier2 = newCards
-		if len(gameState.DeckTier2) > 0 {
-			gameState.VisibleCardsTier2 = append(gameState.VisibleCardsTier2, gameState.DeckTier2[0])
-			gameState.DeckTier2 = gameState.DeckTier2[1:]
-			gameState.DeckTier2Count--
-		}
-	case 3:
-		newCards := []models.DevelopmentCard{}
-		for _, c := range gameState.VisibleCardsTier3 {
-			if c.ID != card.ID {
-				newCards = append(newCards, c)
-			}
+
+		finishGame(game, players, playerStates)
+		if err := e.gameRepo.Update(ctx, game); err != nil {
+			return fmt.Errorf("failed to update game status: %w", err)
 		}
-		gameState.VisibleCardsTier3 = newCards
-		if len(gameState.DeckTier3) > 0 {
-			gameState.VisibleCardsTier3 = append(gameState.VisibleCardsTier3, gameState.DeckTier3[0])
-			gameState.DeckTier3 = gameState.DeckTier3[1:]
-			gameState.DeckTier3Count--
+
+		e.recordMove(ctx, gameID, currentPlayer, action, stateBefore)
+		e.movePlayed(ctx, event)
+		e.snapshotOnEvent(ctx, gameID, models.SnapshotFinish)
+		if e.onFinish != nil {
+			e.onFinish(ctx, gameID)
 		}
+		return nil
+	}
+
+	// Switch turn
+	if err := e.switchTurn(ctx, game, players); err != nil {
+		return err
 	}
+
+	e.recordMove(ctx, gameID, currentPlayer, action, stateBefore)
+	e.movePlayed(ctx, event)
+	return nil
 }
 
-func (e *GameEngine) drawCardFromDeck(gameState *models.GameState, tier int) *models.DevelopmentCard {
-	switch tier {
-	case 1:
-		if len(gameState.DeckTier1) > 0 {
-			card := gameState.DeckTier1[0]
-			gameState.DeckTier1 = gameState.DeckTier1[1:]
-			gameState.DeckTier1Count--
-			return &card
-		}
-	case 2:
-		if len(gameState.DeckTier2) > 0 {
-			card := gameState.DeckTier2[0]
-			gameState.DeckTier2 = gameState.DeckTier2[1:]
-			gameState.DeckTier2Count--
-			return &card
-		}
-	case 3:
-		if len(gameState.DeckTier3) > 0 {
-			card := gameState.DeckTier3[0]
-			gameState.DeckTier3 = gameState.DeckTier3[1:]
-			gameState.DeckTier3Count--
-			return &card
+// othersPassed reports whether the last n moves of a game were all passes
+func (e *GameEngine) othersPassed(ctx context.Context, gameID int64, n int) (bool, error) {
+	moves, err := e.moveRepo.GetByGame(ctx, gameID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get moves: %w", err)
+	}
+
+	if len(moves) < n {
+		return false, nil
+	}
+	for _, move := range moves[len(moves)-n:] {
+		if move.MoveType != ActionPass {
+			return false, nil
 		}
 	}
-	return nil
+	return true, nil
+}
+
+// Helper functions
+func (e *GameEngine) switchTurn(ctx context.Context, game *models.Game, players []*models.GamePlayer) error {
+	advanceTurn(game, players)
+	return e.gameRepo.Update(ctx, game)
 }
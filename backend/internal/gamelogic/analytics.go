@@ -0,0 +1,78 @@
+package gamelogic
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"splendor-backend/internal/domain/models"
+)
+
+// AnalyzePlayer derives a player's game analytics from their logged moves,
+// in the order played, and their final state. Each move's snapshot holds
+// the player's hand before it, so the hand after a move is the next move's
+// snapshot, or the final state after the last move. Metrics that need a
+// snapshot skip moves logged without one.
+func AnalyzePlayer(moves []*models.GameMove, final *models.PlayerState) (*models.GameAnalytics, error) {
+	analytics := &models.GameAnalytics{Turns: len(moves)}
+
+	tiers := make(map[int64]int, len(final.PurchasedCards))
+	for _, card := range final.PurchasedCards {
+		tiers[card.ID] = card.Tier
+	}
+
+	hands := make([]*models.PlayerState, len(moves)+1)
+	for i, move := range moves {
+		if len(move.StateBefore) == 0 {
+			continue
+		}
+		var before moveSnapshot
+		if err := json.Unmarshal(move.StateBefore, &before); err != nil {
+			return nil, fmt.Errorf("failed to read move snapshot: %w", err)
+		}
+		hands[i] = before.PlayerState
+	}
+	hands[len(moves)] = final
+
+	for i, move := range moves {
+		var action Action
+		if err := json.Unmarshal(move.MoveData, &action); err != nil {
+			return nil, fmt.Errorf("failed to read move: %w", err)
+		}
+		before, after := hands[i], hands[i+1]
+
+		switch action.Type {
+		case ActionPurchaseCard:
+			switch tiers[action.CardID] {
+			case 1:
+				analytics.Tier1Purchases++
+			case 2:
+				analytics.Tier2Purchases++
+			case 3:
+				analytics.Tier3Purchases++
+			}
+			if action.FromReserve {
+				analytics.ReservedPurchases++
+			}
+			if before != nil && after != nil {
+				if spent := before.Gems["gold"] - after.Gems["gold"]; spent > 0 {
+					analytics.GoldPurchases++
+					analytics.GoldSpent += spent
+				}
+			}
+
+		case ActionReserveCard:
+			analytics.Reserves++
+		}
+
+		if analytics.FirstNobleTurn == nil && after != nil && len(after.Nobles) > 0 {
+			turn := i + 1
+			analytics.FirstNobleTurn = &turn
+		}
+	}
+
+	for _, count := range final.Gems {
+		analytics.TokensAtEnd += count
+	}
+
+	return analytics, nil
+}
@@ -0,0 +1,145 @@
+package gamelogic
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"splendor-backend/internal/domain/models"
+)
+
+// loggedMove builds a move as the engine logs it. A nil hand leaves the move
+// without a snapshot, as moves logged before snapshots were kept.
+func loggedMove(action Action, hand *models.PlayerState) *models.GameMove {
+	data, err := json.Marshal(action)
+	if err != nil {
+		panic(err)
+	}
+	move := &models.GameMove{MoveType: action.Type, MoveData: data}
+	if hand != nil {
+		snapshot, err := json.Marshal(moveSnapshot{PlayerState: hand})
+		if err != nil {
+			panic(err)
+		}
+		move.StateBefore = snapshot
+	}
+	return move
+}
+
+func hand(gems map[string]int, nobles int) *models.PlayerState {
+	state := &models.PlayerState{Gems: gems}
+	for i := 0; i < nobles; i++ {
+		state.Nobles = append(state.Nobles, models.Noble{ID: int64(i + 1)})
+	}
+	return state
+}
+
+func turn(n int) *int {
+	return &n
+}
+
+func TestAnalyzePlayer(t *testing.T) {
+	final := &models.PlayerState{
+		Gems: map[string]int{"red": 2, "blue": 1, "gold": 0},
+		PurchasedCards: []models.DevelopmentCard{
+			{ID: 10, Tier: 1},
+			{ID: 20, Tier: 2},
+			{ID: 30, Tier: 3},
+		},
+		Nobles: []models.Noble{{ID: 1}},
+	}
+
+	tests := []struct {
+		name    string
+		moves   []*models.GameMove
+		want    *models.GameAnalytics
+		wantErr bool
+	}{
+		{
+			name:  "no moves",
+			moves: nil,
+			want:  &models.GameAnalytics{TokensAtEnd: 3},
+		},
+		{
+			name: "full game",
+			moves: []*models.GameMove{
+				loggedMove(Action{Type: ActionTakeGems, Gems: map[string]int{"red": 3}}, hand(map[string]int{}, 0)),
+				loggedMove(Action{Type: ActionReserveCard, CardID: 30}, hand(map[string]int{"red": 3}, 0)),
+				loggedMove(Action{Type: ActionPurchaseCard, CardID: 10}, hand(map[string]int{"red": 3, "gold": 1}, 0)),
+				loggedMove(Action{Type: ActionPurchaseCard, CardID: 30, FromReserve: true}, hand(map[string]int{"red": 3, "gold": 1}, 0)),
+				loggedMove(Action{Type: ActionPurchaseCard, CardID: 20}, hand(map[string]int{"red": 1, "gold": 0}, 0)),
+			},
+			want: &models.GameAnalytics{
+				Turns:             5,
+				FirstNobleTurn:    turn(5),
+				Tier1Purchases:    1,
+				Tier2Purchases:    1,
+				Tier3Purchases:    1,
+				GoldPurchases:     1,
+				GoldSpent:         1,
+				Reserves:          1,
+				ReservedPurchases: 1,
+				TokensAtEnd:       3,
+			},
+		},
+		{
+			name: "noble on an earlier turn",
+			moves: []*models.GameMove{
+				loggedMove(Action{Type: ActionPurchaseCard, CardID: 10}, hand(map[string]int{"gold": 2}, 0)),
+				loggedMove(Action{Type: ActionPass}, hand(map[string]int{"gold": 0}, 1)),
+				loggedMove(Action{Type: ActionPurchaseCard, CardID: 20}, hand(map[string]int{"gold": 0}, 1)),
+			},
+			want: &models.GameAnalytics{
+				Turns:          3,
+				FirstNobleTurn: turn(1),
+				Tier1Purchases: 1,
+				Tier2Purchases: 1,
+				GoldPurchases:  1,
+				GoldSpent:      2,
+				TokensAtEnd:    3,
+			},
+		},
+		{
+			name: "moves without snapshots skip gold and nobles",
+			moves: []*models.GameMove{
+				loggedMove(Action{Type: ActionPurchaseCard, CardID: 10}, nil),
+				loggedMove(Action{Type: ActionPurchaseCard, CardID: 20}, nil),
+			},
+			want: &models.GameAnalytics{
+				Turns:          2,
+				FirstNobleTurn: turn(2),
+				Tier1Purchases: 1,
+				Tier2Purchases: 1,
+				TokensAtEnd:    3,
+			},
+		},
+		{
+			name:    "unreadable move",
+			moves:   []*models.GameMove{{MoveData: json.RawMessage(`{`)}},
+			wantErr: true,
+		},
+		{
+			name:    "unreadable snapshot",
+			moves:   []*models.GameMove{{MoveData: json.RawMessage(`{"type":"pass"}`), StateBefore: json.RawMessage(`[`)}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AnalyzePlayer(tt.moves, final)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
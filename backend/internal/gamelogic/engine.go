@@ -8,19 +8,66 @@ import (
 
 	"splendor-backend/internal/domain/models"
 	"splendor-backend/internal/repository/postgres"
+	"splendor-backend/pkg/database"
 )
 
+// FinishFunc is called once a move has ended a game
+type FinishFunc func(ctx context.Context, gameID int64)
+
+// MoveEvent describes a move that was just played
+type MoveEvent struct {
+	GameID int64
+	UserID int64
+	Action *Action
+	Card   *models.DevelopmentCard // The card bought or reserved; nil for gems and blind reserves
+	Paid   map[string]int          // Tokens paid for a purchase
+	State  *models.PlayerState     // The player's hand after the move
+}
+
+// MoveFunc is called after every move that was played
+type MoveFunc func(ctx context.Context, event *MoveEvent)
+
 type GameEngine struct {
+	db *database.DB
 	gameRepo *postgres.GameRepository
 	cardRepo *postgres.CardRepository
 	stateRepo *postgres.StateRepository
+	moveRepo *postgres.MoveRepository
+	snapshotRepo *postgres.SnapshotRepository
+
+	// A full snapshot is saved every snapshotInterval moves; 0 disables them
+	snapshotInterval int
+
+	onFinish FinishFunc
+	onMove MoveFunc
 }
 
-func NewGameEngine(gameRepo *postgres.GameRepository, cardRepo *postgres.CardRepository, stateRepo *postgres.StateRepository) *GameEngine {
+func NewGameEngine(db *database.DB, gameRepo *postgres.GameRepository, cardRepo *postgres.CardRepository, stateRepo *postgres.StateRepository, moveRepo *postgres.MoveRepository, snapshotRepo *postgres.SnapshotRepository, snapshotInterval int) *GameEngine {
 	return &GameEngine{
+		db: db,
 		gameRepo: gameRepo,
 		cardRepo: cardRepo,
 		stateRepo: stateRepo,
+		moveRepo: moveRepo,
+		snapshotRepo: snapshotRepo,
+		snapshotInterval: snapshotInterval,
+	}
+}
+
+// OnGameFinished sets the callback run after a move ends a game
+func (e *GameEngine) OnGameFinished(fn FinishFunc) {
+	e.onFinish = fn
+}
+
+// OnMovePlayed sets the callback run after every move
+func (e *GameEngine) OnMovePlayed(fn MoveFunc) {
+	e.onMove = fn
+}
+
+// movePlayed reports a move to the OnMovePlayed callback
+func (e *GameEngine) movePlayed(ctx context.Context, event *MoveEvent) {
+	if e.onMove != nil {
+		e.onMove(ctx, event)
 	}
 }
 
@@ -44,6 +91,36 @@ func (e *GameEngine) InitializeGame(ctx context.Context, gameID int64) error {
 		return fmt.Errorf("failed to get cards: %w", err)
 	}
 
+	// Get all nobles
+	allNobles, err := e.cardRepo.GetAllNobles(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get nobles: %w", err)
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	gameState := DealBoard(gameID, allCards, allNobles, numPlayers, rng)
+
+	if err := e.stateRepo.CreateGameState(ctx, gameState); err != nil {
+		return fmt.Errorf("failed to create game state: %w", err)
+	}
+
+	// Initialize player states
+	for _, player := range players {
+		playerState := NewPlayerState(player.ID)
+		if err := e.stateRepo.CreatePlayerState(ctx, playerState); err != nil {
+			return fmt.Errorf("failed to create player state: %w", err)
+		}
+	}
+
+	e.snapshotOnEvent(ctx, gameID, models.SnapshotStart)
+
+	return nil
+}
+
+// DealBoard sets up the opening board: each tier is shuffled and four cards
+// of each are laid out, numPlayers + 1 nobles are drawn and the bank is
+// filled for the player count
+func DealBoard(gameID int64, allCards []models.DevelopmentCard, allNobles []models.Noble, numPlayers int, rng *rand.Rand) *models.GameState {
 	// Separate cards by tier
 	tier1Cards := []models.DevelopmentCard{}
 	tier2Cards := []models.DevelopmentCard{}
@@ -61,77 +138,66 @@ func (e *GameEngine) InitializeGame(ctx context.Context, gameID int64) error {
 	}
 
 	// Shuffle each tier
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 	shuffleCardsWithRNG(tier1Cards, rng)
 	shuffleCardsWithRNG(tier2Cards, rng)
 	shuffleCardsWithRNG(tier3Cards, rng)
 
 	// Deal 4 cards from each tier
-	visibleTier1 := tier1Cards[:4]
-	visibleTier2 := tier2Cards[:4]
-	visibleTier3 := tier3Cards[:4]
-
-	deckTier1 := tier1Cards[4:]
-	deckTier2 := tier2Cards[4:]
-	deckTier3 := tier3Cards[4:]
+	visibleTier1, deckTier1 := splitDeal(tier1Cards, 4)
+	visibleTier2, deckTier2 := splitDeal(tier2Cards, 4)
+	visibleTier3, deckTier3 := splitDeal(tier3Cards, 4)
 
-	// Get all nobles and select numPlayers + 1
-	allNobles, err := e.cardRepo.GetAllNobles(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get nobles: %w", err)
-	}
-
-	shuffleNoblesWithRNG(allNobles, rng)
+	// Select numPlayers + 1 nobles
+	nobles := append([]models.Noble{}, allNobles...)
+	shuffleNoblesWithRNG(nobles, rng)
 	noblesCount := numPlayers + 1
-	if noblesCount > len(allNobles) {
-		noblesCount = len(allNobles)
+	if noblesCount > len(nobles) {
+		noblesCount = len(nobles)
 	}
-	selectedNobles := allNobles[:noblesCount]
 
-	// Determine gem counts based on player count
-	gemCounts := getGemCounts(numPlayers)
-
-	// Create game state
-	gameState := &models.GameState{
-		GameID:        gameID,
-		AvailableGems: gemCounts,
+	return &models.GameState{
+		GameID:            gameID,
+		AvailableGems:     getGemCounts(numPlayers),
 		VisibleCardsTier1: visibleTier1,
 		VisibleCardsTier2: visibleTier2,
 		VisibleCardsTier3: visibleTier3,
-		AvailableNobles:  selectedNobles,
-		DeckTier1:        deckTier1,
-		DeckTier2:        deckTier2,
-		DeckTier3:        deckTier3,
+		AvailableNobles:   nobles[:noblesCount],
+		DeckTier1:         deckTier1,
+		DeckTier2:         deckTier2,
+		DeckTier3:         deckTier3,
+		DeckTier1Count:    len(deckTier1),
+		DeckTier2Count:    len(deckTier2),
+		DeckTier3Count:    len(deckTier3),
 	}
+}
 
-	if err := e.stateRepo.CreateGameState(ctx, gameState); err != nil {
-		return fmt.Errorf("failed to create game state: %w", err)
+// NewPlayerState returns the empty starting hand for a seat
+func NewPlayerState(gamePlayerID int64) *models.PlayerState {
+	playerState := &models.PlayerState{
+		GamePlayerID:   gamePlayerID,
+		Gems:           make(map[string]int),
+		PermanentGems:  make(map[string]int),
+		PurchasedCards: []models.DevelopmentCard{},
+		ReservedCards:  []models.DevelopmentCard{},
+		Nobles:         []models.Noble{},
 	}
 
-	// Initialize player states
-	for _, player := range players {
-		playerState := &models.PlayerState{
-			GamePlayerID: player.ID,
-			Gems:         make(map[string]int),
-			PermanentGems: make(map[string]int),
-			PurchasedCards: []models.DevelopmentCard{},
-			ReservedCards:  []models.DevelopmentCard{},
-			Nobles:         []models.Noble{},
-		}
+	// Initialize all gem types to 0
+	gemTypes := []string{"diamond", "sapphire", "emerald", "ruby", "onyx", "gold"}
+	for _, gemType := range gemTypes {
+		playerState.Gems[gemType] = 0
+		playerState.PermanentGems[gemType] = 0
+	}
 
-		// Initialize all gem types to 0
-		gemTypes := []string{"diamond", "sapphire", "emerald", "ruby", "onyx", "gold"}
-		for _, gemType := range gemTypes {
-			playerState.Gems[gemType] = 0
-			playerState.PermanentGems[gemType] = 0
-		}
+	return playerState
+}
 
-		if err := e.stateRepo.CreatePlayerState(ctx, playerState); err != nil {
-			return fmt.Errorf("failed to create player state: %w", err)
-		}
+// splitDeal lays out up to n cards and leaves the rest as the deck
+func splitDeal(cards []models.DevelopmentCard, n int) ([]models.DevelopmentCard, []models.DevelopmentCard) {
+	if n > len(cards) {
+		n = len(cards)
 	}
-
-	return nil
+	return cards[:n], cards[n:]
 }
 
 func shuffleCardsWithRNG(cards []models.DevelopmentCard, rng *rand.Rand) {
@@ -174,8 +240,37 @@ func getGemCounts(numPlayers int) map[string]int {
 	}
 }
 
-// GetGameState retrieves the full game state including player states
-func (e *GameEngine) GetGameState(ctx context.Context, gameID int64) (*models.FullGameState, error) {
+// GetGameState retrieves the game state as seen by viewerID. Players see
+// their own hand in full and a redacted view of everyone else's; a viewerID
+// of 0 returns the spectator view.
+func (e *GameEngine) GetGameState(ctx context.Context, gameID, viewerID int64) (*models.FullGameState, error) {
+	state, err := e.loadGameState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ViewFor(state, viewerID), nil
+}
+
+// GetGameStateViews loads the game state once and returns the view of every
+// seated player keyed by user ID, plus the spectator view under key 0
+func (e *GameEngine) GetGameStateViews(ctx context.Context, gameID int64) (map[int64]*models.FullGameState, error) {
+	state, err := e.loadGameState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make(map[int64]*models.FullGameState, len(state.Players)+1)
+	views[0] = ViewFor(state, 0)
+	for _, player := range state.Players {
+		views[player.UserID] = ViewFor(state, player.UserID)
+	}
+
+	return views, nil
+}
+
+// loadGameState retrieves the unredacted game state including player states
+func (e *GameEngine) loadGameState(ctx context.Context, gameID int64) (*models.FullGameState, error) {
 	game, err := e.gameRepo.GetByID(ctx, gameID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get game: %w", err)
@@ -0,0 +1,148 @@
+package gamelogic
+
+import (
+	"context"
+	"fmt"
+
+	"splendor-backend/internal/domain/models"
+)
+
+// Action types, matching the move types recorded in game_moves
+const (
+	ActionTakeGems     = "take_gems"
+	ActionPurchaseCard = "purchase_card"
+	ActionReserveCard  = "reserve_card"
+	ActionPass         = "pass"
+)
+
+// baseGemTypes are the gem colors that can be taken from the bank
+var baseGemTypes = []string{"diamond", "sapphire", "emerald", "ruby", "onyx"}
+
+// Action is a single move a player can make on their turn
+type Action struct {
+	Type        string         `json:"type"`
+	Gems        map[string]int `json:"gems,omitempty"`
+	CardID      int64          `json:"card_id,omitempty"` // 0 for blind reserve
+	FromReserve bool           `json:"from_reserve,omitempty"`
+	Tier        int            `json:"tier,omitempty"`
+}
+
+// ApplyAction executes an action for userID through the matching engine method
+func (e *GameEngine) ApplyAction(ctx context.Context, gameID, userID int64, action *Action) error {
+	switch action.Type {
+	case ActionTakeGems:
+		return e.TakeGems(ctx, gameID, userID, action.Gems)
+	case ActionPurchaseCard:
+		return e.PurchaseCard(ctx, gameID, userID, action.CardID, action.FromReserve)
+	case ActionReserveCard:
+		return e.ReserveCard(ctx, gameID, userID, action.CardID, action.Tier)
+	case ActionPass:
+		return e.Pass(ctx, gameID, userID)
+	default:
+		return fmt.Errorf("unknown action type %q", action.Type)
+	}
+}
+
+// LegalActions lists every action available to userID. Only the board and
+// the player's own hand are read, so it works on the player's redacted view.
+// A player with no move, e.g. holding ten tokens and three reserved cards
+// they cannot afford, may only pass.
+func LegalActions(state *models.FullGameState, userID int64) []Action {
+	actions := moveActions(state, userID)
+	if actions != nil && len(actions) == 0 {
+		actions = append(actions, Action{Type: ActionPass})
+	}
+	return actions
+}
+
+// CanOnlyPass reports whether userID has no move other than passing
+func CanOnlyPass(state *models.FullGameState, userID int64) bool {
+	actions := moveActions(state, userID)
+	return actions != nil && len(actions) == 0
+}
+
+// moveActions lists the moves available to userID, not counting a pass. It
+// returns nil if the state has no hand for userID.
+func moveActions(state *models.FullGameState, userID int64) []Action {
+	validator := NewGameValidator()
+	gameState := state.GameState
+	playerState, ok := state.PlayerStates[userID]
+	if gameState == nil || !ok {
+		return nil
+	}
+
+	actions := []Action{}
+
+	// Take three different colors
+	for i := 0; i < len(baseGemTypes); i++ {
+		for j := i + 1; j < len(baseGemTypes); j++ {
+			for k := j + 1; k < len(baseGemTypes); k++ {
+				gems := map[string]int{baseGemTypes[i]: 1, baseGemTypes[j]: 1, baseGemTypes[k]: 1}
+				if validator.ValidateTakeGems(gameState, playerState, gems) == nil {
+					actions = append(actions, Action{Type: ActionTakeGems, Gems: gems})
+				}
+			}
+		}
+	}
+
+	// Take one or two different colors when three cannot be taken
+	for i := 0; i < len(baseGemTypes); i++ {
+		gems := map[string]int{baseGemTypes[i]: 1}
+		if validator.ValidateTakeGems(gameState, playerState, gems) == nil {
+			actions = append(actions, Action{Type: ActionTakeGems, Gems: gems})
+		}
+		for j := i + 1; j < len(baseGemTypes); j++ {
+			gems := map[string]int{baseGemTypes[i]: 1, baseGemTypes[j]: 1}
+			if validator.ValidateTakeGems(gameState, playerState, gems) == nil {
+				actions = append(actions, Action{Type: ActionTakeGems, Gems: gems})
+			}
+		}
+	}
+
+	// Take two of the same color
+	for _, gemType := range baseGemTypes {
+		gems := map[string]int{gemType: 2}
+		if validator.ValidateTakeGems(gameState, playerState, gems) == nil {
+			actions = append(actions, Action{Type: ActionTakeGems, Gems: gems})
+		}
+	}
+
+	visible := [][]models.DevelopmentCard{
+		gameState.VisibleCardsTier1,
+		gameState.VisibleCardsTier2,
+		gameState.VisibleCardsTier3,
+	}
+
+	// Purchase from the board or from reserve
+	for _, cards := range visible {
+		for i := range cards {
+			if validator.ValidatePurchaseCard(gameState, playerState, &cards[i], false) == nil {
+				actions = append(actions, Action{Type: ActionPurchaseCard, CardID: cards[i].ID})
+			}
+		}
+	}
+	for i := range playerState.ReservedCards {
+		card := &playerState.ReservedCards[i]
+		if validator.ValidatePurchaseCard(gameState, playerState, card, true) == nil {
+			actions = append(actions, Action{Type: ActionPurchaseCard, CardID: card.ID, FromReserve: true})
+		}
+	}
+
+	// Reserve from the board or blind from a deck
+	if validator.ValidateReserveCard(playerState) == nil {
+		for _, cards := range visible {
+			for _, card := range cards {
+				actions = append(actions, Action{Type: ActionReserveCard, CardID: card.ID, Tier: card.Tier})
+			}
+		}
+
+		deckCounts := []int{gameState.DeckTier1Count, gameState.DeckTier2Count, gameState.DeckTier3Count}
+		for i, count := range deckCounts {
+			if count > 0 {
+				actions = append(actions, Action{Type: ActionReserveCard, Tier: i + 1})
+			}
+		}
+	}
+
+	return actions
+}
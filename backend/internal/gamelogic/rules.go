@@ -0,0 +1,212 @@
+package gamelogic
+
+import (
+	"fmt"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+)
+
+// The functions in this file apply already-validated moves to in-memory
+// state. The engine persists their results; the simulator calls them
+// directly.
+
+// takeGems moves gems from the bank to the player
+func takeGems(gameState *models.GameState, playerState *models.PlayerState, gems map[string]int) {
+	for gemType, count := range gems {
+		if count > 0 {
+			gameState.AvailableGems[gemType] -= count
+			playerState.Gems[gemType] += count
+		}
+	}
+}
+
+// purchaseCard pays for a card, adds it to the player's tableau and awards
+// its points plus any noble that now visits the player
+func purchaseCard(gameState *models.GameState, playerState *models.PlayerState, player *models.GamePlayer, card *models.DevelopmentCard, fromReserve bool) {
+	validator := NewGameValidator()
+
+	// Pay cost
+	actualCost := validator.CalculateCost(card, playerState)
+	for gemType, cost := range actualCost {
+		playerState.Gems[gemType] -= cost
+		gameState.AvailableGems[gemType] += cost
+	}
+
+	// Add card to player
+	playerState.PurchasedCards = append(playerState.PurchasedCards, *card)
+	playerState.PermanentGems[card.GemType]++
+	player.VictoryPoints += card.VictoryPoints
+
+	// Remove from reserve if applicable
+	if fromReserve {
+		newReserved := []models.DevelopmentCard{}
+		for _, c := range playerState.ReservedCards {
+			if c.ID != card.ID {
+				newReserved = append(newReserved, c)
+			}
+		}
+		playerState.ReservedCards = newReserved
+
+		newBlind := []int64{}
+		for _, id := range playerState.BlindReserved {
+			if id != card.ID {
+				newBlind = append(newBlind, id)
+			}
+		}
+		playerState.BlindReserved = newBlind
+	} else {
+		// Remove from visible cards and replace
+		removeAndReplaceCard(gameState, card)
+	}
+
+	// Check for noble visits
+	for i, noble := range gameState.AvailableNobles {
+		if validator.CheckNobleVisit(playerState, &noble) {
+			// Noble visits player
+			playerState.Nobles = append(playerState.Nobles, noble)
+			player.VictoryPoints += noble.VictoryPoints
+
+			// Remove noble from available
+			gameState.AvailableNobles = append(gameState.AvailableNobles[:i], gameState.AvailableNobles[i+1:]...)
+			break // Only one noble per turn
+		}
+	}
+}
+
+// reserveCard moves a visible card, or the top card of tier's deck when card
+// is nil, into the player's reserve and hands out a gold token if any are left
+func reserveCard(gameState *models.GameState, playerState *models.PlayerState, card *models.DevelopmentCard, tier int) error {
+	if card != nil {
+		removeAndReplaceCard(gameState, card)
+	} else {
+		// Reserve from deck (blind)
+		card = drawCardFromDeck(gameState, tier)
+		if card == nil {
+			return fmt.Errorf("no cards left in tier %d", tier)
+		}
+		playerState.BlindReserved = append(playerState.BlindReserved, card.ID)
+	}
+
+	// Add to reserved
+	playerState.ReservedCards = append(playerState.ReservedCards, *card)
+
+	// Give gold coin if available
+	if gameState.AvailableGems["gold"] > 0 {
+		gameState.AvailableGems["gold"]--
+		playerState.Gems["gold"]++
+	}
+
+	return nil
+}
+
+// finishIfWon ends the game if someone has reached 15 points, recording the
+// winner. It reports whether the game ended.
+func finishIfWon(game *models.Game, players []*models.GamePlayer, playerStates map[int64]*models.PlayerState) bool {
+	validator := NewGameValidator()
+	if !validator.CheckVictoryCondition(players) {
+		return false
+	}
+
+	finishGame(game, players, playerStates)
+	return true
+}
+
+// finishGame ends the game as it stands, recording the winner
+func finishGame(game *models.Game, players []*models.GamePlayer, playerStates map[int64]*models.PlayerState) {
+	winner := NewGameValidator().DetermineWinner(players, playerStates)
+
+	game.Status = models.GameStatusCompleted
+	game.WinnerID = &winner.UserID
+	now := time.Now()
+	game.CompletedAt = &now
+}
+
+// advanceTurn passes the turn to the next player in seat order
+func advanceTurn(game *models.Game, players []*models.GamePlayer) {
+	// Find current player index
+	currentIndex := -1
+	for i, p := range players {
+		if game.CurrentTurnPlayerID != nil && p.UserID == *game.CurrentTurnPlayerID {
+			currentIndex = i
+			break
+		}
+	}
+
+	// Next player
+	nextIndex := (currentIndex + 1) % len(players)
+	game.CurrentTurnPlayerID = &players[nextIndex].UserID
+	game.TurnNumber++
+}
+
+func removeAndReplaceCard(gameState *models.GameState, card *models.DevelopmentCard) {
+	switch card.Tier {
+	case 1:
+		newCards := []models.DevelopmentCard{}
+		for _, c := range gameState.VisibleCardsTier1 {
+			if c.ID != card.ID {
+				newCards = append(newCards, c)
+			}
+		}
+		gameState.VisibleCardsTier1 = newCards
+		// Replace with new card from deck
+		if len(gameState.DeckTier1) > 0 {
+			gameState.VisibleCardsTier1 = append(gameState.VisibleCardsTier1, gameState.DeckTier1[0])
+			gameState.DeckTier1 = gameState.DeckTier1[1:]
+			gameState.DeckTier1Count--
+		}
+	case 2:
+		newCards := []models.DevelopmentCard{}
+		for _, c := range gameState.VisibleCardsTier2 {
+			if c.ID != card.ID {
+				newCards = append(newCards, c)
+			}
+		}
+		gameState.VisibleCardsTier2 = newCards
+		if len(gameState.DeckTier2) > 0 {
+			gameState.VisibleCardsTier2 = append(gameState.VisibleCardsTier2, gameState.DeckTier2[0])
+			gameState.DeckTier2 = gameState.DeckTier2[1:]
+			gameState.DeckTier2Count--
+		}
+	case 3:
+		newCards := []models.DevelopmentCard{}
+		for _, c := range gameState.VisibleCardsTier3 {
+			if c.ID != card.ID {
+				newCards = append(newCards, c)
+			}
+		}
+		gameState.VisibleCardsTier3 = newCards
+		if len(gameState.DeckTier3) > 0 {
+			gameState.VisibleCardsTier3 = append(gameState.VisibleCardsTier3, gameState.DeckTier3[0])
+			gameState.DeckTier3 = gameState.DeckTier3[1:]
+			gameState.DeckTier3Count--
+		}
+	}
+}
+
+func drawCardFromDeck(gameState *models.GameState, tier int) *models.DevelopmentCard {
+	switch tier {
+	case 1:
+		if len(gameState.DeckTier1) > 0 {
+			card := gameState.DeckTier1[0]
+			gameState.DeckTier1 = gameState.DeckTier1[1:]
+			gameState.DeckTier1Count--
+			return &card
+		}
+	case 2:
+		if len(gameState.DeckTier2) > 0 {
+			card := gameState.DeckTier2[0]
+			gameState.DeckTier2 = gameState.DeckTier2[1:]
+			gameState.DeckTier2Count--
+			return &card
+		}
+	case 3:
+		if len(gameState.DeckTier3) > 0 {
+			card := gameState.DeckTier3[0]
+			gameState.DeckTier3 = gameState.DeckTier3[1:]
+			gameState.DeckTier3Count--
+			return &card
+		}
+	}
+	return nil
+}
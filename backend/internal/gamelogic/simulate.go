@@ -0,0 +1,193 @@
+package gamelogic
+
+import (
+	"errors"
+	"fmt"
+
+	"splendor-backend/internal/domain/models"
+)
+
+var ErrGameOver = errors.New("game is over")
+
+// CloneState returns a deep copy of a game state that can be played forward
+// in memory without touching the original
+func CloneState(state *models.FullGameState) *models.FullGameState {
+	clone := &models.FullGameState{
+		PlayerStates: make(map[int64]*models.PlayerState, len(state.PlayerStates)),
+	}
+
+	if state.Game != nil {
+		game := *state.Game
+		if state.Game.CurrentTurnPlayerID != nil {
+			turn := *state.Game.CurrentTurnPlayerID
+			game.CurrentTurnPlayerID = &turn
+		}
+		if state.Game.WinnerID != nil {
+			winner := *state.Game.WinnerID
+			game.WinnerID = &winner
+		}
+		game.Players = nil
+		clone.Game = &game
+	}
+
+	clone.Players = make([]*models.GamePlayer, len(state.Players))
+	for i, player := range state.Players {
+		p := *player
+		clone.Players[i] = &p
+	}
+
+	if state.GameState != nil {
+		gs := *state.GameState
+		gs.AvailableGems = cloneGems(state.GameState.AvailableGems)
+		gs.VisibleCardsTier1 = cloneCards(state.GameState.VisibleCardsTier1)
+		gs.VisibleCardsTier2 = cloneCards(state.GameState.VisibleCardsTier2)
+		gs.VisibleCardsTier3 = cloneCards(state.GameState.VisibleCardsTier3)
+		gs.AvailableNobles = append([]models.Noble{}, state.GameState.AvailableNobles...)
+		gs.DeckTier1 = cloneCards(state.GameState.DeckTier1)
+		gs.DeckTier2 = cloneCards(state.GameState.DeckTier2)
+		gs.DeckTier3 = cloneCards(state.GameState.DeckTier3)
+		clone.GameState = &gs
+	}
+
+	for userID, playerState := range state.PlayerStates {
+		ps := *playerState
+		ps.Gems = cloneGems(playerState.Gems)
+		ps.PermanentGems = cloneGems(playerState.PermanentGems)
+		ps.PurchasedCards = cloneCards(playerState.PurchasedCards)
+		ps.ReservedCards = cloneCards(playerState.ReservedCards)
+		ps.BlindReserved = append([]int64{}, playerState.BlindReserved...)
+		ps.Nobles = append([]models.Noble{}, playerState.Nobles...)
+		ps.HiddenReservedTiers = append([]int{}, playerState.HiddenReservedTiers...)
+		clone.PlayerStates[userID] = &ps
+	}
+
+	return clone
+}
+
+// SimulateAction plays an action for the player whose turn it is, applying
+// the same validation and rules as the engine to an in-memory state. Cards
+// drawn to refill the board come from the state's decks, so a state built
+// from a redacted view must have its decks filled in first.
+func SimulateAction(state *models.FullGameState, action *Action) error {
+	validator := NewGameValidator()
+	game := state.Game
+
+	if game.Status == models.GameStatusCompleted {
+		return ErrGameOver
+	}
+	if game.CurrentTurnPlayerID == nil {
+		return ErrNotYourTurn
+	}
+	userID := *game.CurrentTurnPlayerID
+
+	var currentPlayer *models.GamePlayer
+	for _, p := range state.Players {
+		if p.UserID == userID {
+			currentPlayer = p
+			break
+		}
+	}
+	playerState, ok := state.PlayerStates[userID]
+	if currentPlayer == nil || !ok {
+		return fmt.Errorf("no state for player %d", userID)
+	}
+	gameState := state.GameState
+
+	switch action.Type {
+	case ActionTakeGems:
+		if err := validator.ValidateTakeGems(gameState, playerState, action.Gems); err != nil {
+			return err
+		}
+		takeGems(gameState, playerState, action.Gems)
+
+	case ActionPurchaseCard:
+		card := findCard(gameState, playerState, action.CardID, action.FromReserve)
+		if card == nil {
+			return ErrCardNotAvailable
+		}
+		if err := validator.ValidatePurchaseCard(gameState, playerState, card, action.FromReserve); err != nil {
+			return err
+		}
+		purchaseCard(gameState, playerState, currentPlayer, card, action.FromReserve)
+
+		if finishIfWon(game, state.Players, state.PlayerStates) {
+			return nil
+		}
+
+	case ActionReserveCard:
+		if err := validator.ValidateReserveCard(playerState); err != nil {
+			return err
+		}
+		var card *models.DevelopmentCard
+		if action.CardID > 0 {
+			card = findCard(gameState, playerState, action.CardID, false)
+			if card == nil {
+				return ErrCardNotAvailable
+			}
+		}
+		if err := reserveCard(gameState, playerState, card, action.Tier); err != nil {
+			return err
+		}
+
+	case ActionPass:
+		if !CanOnlyPass(state, userID) {
+			return ErrCannotPass
+		}
+
+	default:
+		return fmt.Errorf("unknown action type %q", action.Type)
+	}
+
+	advanceTurn(game, state.Players)
+	return nil
+}
+
+// FinishStalemate ends a game in which every player passed in turn, scoring
+// it as it stands, as the engine does
+func FinishStalemate(state *models.FullGameState) {
+	finishGame(state.Game, state.Players, state.PlayerStates)
+}
+
+// findCard looks a card up on the board, or in the player's reserve
+func findCard(gameState *models.GameState, playerState *models.PlayerState, cardID int64, fromReserve bool) *models.DevelopmentCard {
+	if fromReserve {
+		for _, card := range playerState.ReservedCards {
+			if card.ID == cardID {
+				c := card
+				return &c
+			}
+		}
+		return nil
+	}
+
+	for _, cards := range [][]models.DevelopmentCard{
+		gameState.VisibleCardsTier1,
+		gameState.VisibleCardsTier2,
+		gameState.VisibleCardsTier3,
+	} {
+		for _, card := range cards {
+			if card.ID == cardID {
+				c := card
+				return &c
+			}
+		}
+	}
+	return nil
+}
+
+func cloneGems(gems map[string]int) map[string]int {
+	clone := make(map[string]int, len(gems))
+	for gemType, count := range gems {
+		clone[gemType] = count
+	}
+	return clone
+}
+
+// cloneCards copies a card slice. Card cost maps are never mutated, so they
+// are shared.
+func cloneCards(cards []models.DevelopmentCard) []models.DevelopmentCard {
+	if cards == nil {
+		return nil
+	}
+	return append([]models.DevelopmentCard{}, cards...)
+}
@@ -0,0 +1,145 @@
+package gamelogic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+)
+
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// gameSnapshot is the full state of a game: the turn, the board and every
+// player's hand and points. Like moveSnapshot it stores the decks and blind
+// reserves that the client-facing models leave out of JSON.
+type gameSnapshot struct {
+	Status              models.GameStatus           `json:"status"`
+	TurnNumber          int                         `json:"turn_number"`
+	CurrentTurnPlayerID *int64                      `json:"current_turn_player_id"`
+	WinnerID            *int64                      `json:"winner_id"`
+	CompletedAt         *time.Time                  `json:"completed_at"`
+	GameState           *models.GameState           `json:"game_state"`
+	Decks               [3][]models.DevelopmentCard `json:"decks"`
+	Players             []playerSnapshot            `json:"players"`
+}
+
+type playerSnapshot struct {
+	GamePlayerID  int64               `json:"game_player_id"`
+	VictoryPoints int                 `json:"victory_points"`
+	State         *models.PlayerState `json:"state"`
+	BlindReserved []int64             `json:"blind_reserved"`
+}
+
+// SaveSnapshot stores a full snapshot of a game's current state
+func (e *GameEngine) SaveSnapshot(ctx context.Context, gameID int64, reason string) (*models.GameSnapshot, error) {
+	state, err := e.loadGameState(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	game := state.Game
+	snapshot := &gameSnapshot{
+		Status:              game.Status,
+		TurnNumber:          game.TurnNumber,
+		CurrentTurnPlayerID: game.CurrentTurnPlayerID,
+		WinnerID:            game.WinnerID,
+		CompletedAt:         game.CompletedAt,
+		GameState:           state.GameState,
+		Decks:               [3][]models.DevelopmentCard{state.GameState.DeckTier1, state.GameState.DeckTier2, state.GameState.DeckTier3},
+	}
+	for _, player := range state.Players {
+		playerState := state.PlayerStates[player.UserID]
+		snapshot.Players = append(snapshot.Players, playerSnapshot{
+			GamePlayerID:  player.ID,
+			VictoryPoints: player.VictoryPoints,
+			State:         playerState,
+			BlindReserved: playerState.BlindReserved,
+		})
+	}
+
+	stateJSON, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	saved := &models.GameSnapshot{
+		GameID:     gameID,
+		TurnNumber: game.TurnNumber,
+		Reason:     reason,
+		State:      stateJSON,
+	}
+	if err := e.snapshotRepo.Create(ctx, saved); err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// snapshotOnEvent saves a snapshot after a key event. Snapshots are a safety
+// net, so a failure is logged rather than failing the event.
+func (e *GameEngine) snapshotOnEvent(ctx context.Context, gameID int64, reason string) {
+	if _, err := e.SaveSnapshot(ctx, gameID, reason); err != nil {
+		log.Printf("Failed to save %s snapshot of game %d: %v", reason, gameID, err)
+	}
+}
+
+// RestoreSnapshot puts a game back into the state stored in a snapshot.
+// The current state is snapshotted first so the restore can be reversed,
+// and moves made after the snapshot are dropped from the log.
+func (e *GameEngine) RestoreSnapshot(ctx context.Context, gameID, snapshotID int64) (*models.GameSnapshot, error) {
+	saved, err := e.snapshotRepo.GetByID(ctx, snapshotID)
+	if err != nil || saved.GameID != gameID {
+		return nil, ErrSnapshotNotFound
+	}
+
+	var snapshot gameSnapshot
+	if err := json.Unmarshal(saved.State, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	if _, err := e.SaveSnapshot(ctx, gameID, models.SnapshotPreRestore); err != nil {
+		return nil, fmt.Errorf("failed to save pre-restore snapshot: %w", err)
+	}
+
+	gameState := snapshot.GameState
+	gameState.DeckTier1, gameState.DeckTier2, gameState.DeckTier3 = snapshot.Decks[0], snapshot.Decks[1], snapshot.Decks[2]
+	if err := e.stateRepo.UpdateGameState(ctx, gameState); err != nil {
+		return nil, err
+	}
+
+	for _, p := range snapshot.Players {
+		playerState := p.State
+		playerState.BlindReserved = p.BlindReserved
+		if err := e.stateRepo.UpdatePlayerState(ctx, playerState); err != nil {
+			return nil, err
+		}
+
+		player := &models.GamePlayer{ID: p.GamePlayerID, VictoryPoints: p.VictoryPoints}
+		if err := e.gameRepo.UpdatePlayer(ctx, player); err != nil {
+			return nil, err
+		}
+	}
+
+	game, err := e.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	game.Status = snapshot.Status
+	game.TurnNumber = snapshot.TurnNumber
+	game.CurrentTurnPlayerID = snapshot.CurrentTurnPlayerID
+	game.WinnerID = snapshot.WinnerID
+	game.CompletedAt = snapshot.CompletedAt
+	if err := e.gameRepo.Update(ctx, game); err != nil {
+		return nil, err
+	}
+
+	if err := e.moveRepo.DeleteFrom(ctx, gameID, snapshot.TurnNumber); err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
@@ -0,0 +1,135 @@
+package gamelogic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"splendor-backend/internal/domain/models"
+)
+
+var ErrMoveNotUndoable = errors.New("move can no longer be undone")
+
+// moveSnapshot holds everything a move can change: the turn, the board and
+// the moving player's hand and points. The client-facing models leave decks
+// and blind reserves out of JSON, so those are stored separately.
+type moveSnapshot struct {
+	TurnNumber          int                         `json:"turn_number"`
+	CurrentTurnPlayerID *int64                      `json:"current_turn_player_id"`
+	GameState           *models.GameState           `json:"game_state"`
+	Decks               [3][]models.DevelopmentCard `json:"decks"`
+	GamePlayerID        int64                       `json:"game_player_id"`
+	VictoryPoints       int                         `json:"victory_points"`
+	PlayerState         *models.PlayerState         `json:"player_state"`
+	BlindReserved       []int64                     `json:"blind_reserved"`
+}
+
+// snapshotMove captures the state a move is about to change. It must be
+// called after validation and before the move is applied.
+func snapshotMove(game *models.Game, gameState *models.GameState, player *models.GamePlayer, playerState *models.PlayerState) (json.RawMessage, error) {
+	return json.Marshal(&moveSnapshot{
+		TurnNumber:          game.TurnNumber,
+		CurrentTurnPlayerID: game.CurrentTurnPlayerID,
+		GameState:           gameState,
+		Decks:               [3][]models.DevelopmentCard{gameState.DeckTier1, gameState.DeckTier2, gameState.DeckTier3},
+		GamePlayerID:        player.ID,
+		VictoryPoints:       player.VictoryPoints,
+		PlayerState:         playerState,
+		BlindReserved:       playerState.BlindReserved,
+	})
+}
+
+// recordMove appends a played move to the game's log together with the
+// snapshot taken before it. The move has already been persisted, so a
+// failure only means it cannot be undone.
+func (e *GameEngine) recordMove(ctx context.Context, gameID int64, player *models.GamePlayer, action *Action, stateBefore json.RawMessage) {
+	moveData, err := json.Marshal(action)
+	if err != nil {
+		log.Printf("Failed to record move in game %d: %v", gameID, err)
+		return
+	}
+
+	var before moveSnapshot
+	if err := json.Unmarshal(stateBefore, &before); err != nil {
+		log.Printf("Failed to record move in game %d: %v", gameID, err)
+		return
+	}
+
+	err = e.moveRepo.Create(ctx, &models.GameMove{
+		GameID:       gameID,
+		GamePlayerID: player.ID,
+		MoveNumber:   before.TurnNumber,
+		MoveType:     action.Type,
+		MoveData:     moveData,
+		StateBefore:  stateBefore,
+	})
+	if err != nil {
+		log.Printf("Failed to record move in game %d: %v", gameID, err)
+	}
+
+	if e.snapshotInterval > 0 && (before.TurnNumber+1)%e.snapshotInterval == 0 {
+		e.snapshotOnEvent(ctx, gameID, models.SnapshotInterval)
+	}
+}
+
+// UndoMove takes back a game's last move, restoring the state from before
+// it and removing it from the log. It fails if moveID is no longer the last
+// move or the next player has already acted. The restore runs in one
+// transaction, so the state and the log never disagree.
+func (e *GameEngine) UndoMove(ctx context.Context, gameID, moveID int64) error {
+	err := e.db.InTx(ctx, func(ctx context.Context) error {
+		game, err := e.gameRepo.GetByID(ctx, gameID)
+		if err != nil {
+			return err
+		}
+
+		move, err := e.moveRepo.GetLast(ctx, gameID)
+		if err != nil {
+			return err
+		}
+		if move == nil || move.ID != moveID || len(move.StateBefore) == 0 {
+			return ErrMoveNotUndoable
+		}
+		if game.Status != models.GameStatusInProgress || game.TurnNumber != move.MoveNumber+1 {
+			return ErrMoveNotUndoable
+		}
+
+		var before moveSnapshot
+		if err := json.Unmarshal(move.StateBefore, &before); err != nil {
+			return fmt.Errorf("failed to read move snapshot: %w", err)
+		}
+
+		gameState := before.GameState
+		gameState.DeckTier1, gameState.DeckTier2, gameState.DeckTier3 = before.Decks[0], before.Decks[1], before.Decks[2]
+		if err := e.stateRepo.UpdateGameState(ctx, gameState); err != nil {
+			return err
+		}
+
+		playerState := before.PlayerState
+		playerState.BlindReserved = before.BlindReserved
+		if err := e.stateRepo.UpdatePlayerState(ctx, playerState); err != nil {
+			return err
+		}
+
+		player := &models.GamePlayer{ID: before.GamePlayerID, VictoryPoints: before.VictoryPoints}
+		if err := e.gameRepo.UpdatePlayer(ctx, player); err != nil {
+			return err
+		}
+
+		game.TurnNumber = before.TurnNumber
+		game.CurrentTurnPlayerID = before.CurrentTurnPlayerID
+		if err := e.gameRepo.Update(ctx, game); err != nil {
+			return err
+		}
+
+		return e.moveRepo.Delete(ctx, move.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	e.snapshotOnEvent(ctx, gameID, models.SnapshotUndo)
+	return nil
+}
@@ -15,6 +15,7 @@ var (
 	ErrCardNotAvailable   = errors.New("card not available")
 	ErrCannotAffordCard   = errors.New("cannot afford this card")
 	ErrTooManyReserved    = errors.New("too many reserved cards (max 3)")
+	ErrCannotPass         = errors.New("cannot pass while a move is available")
 )
 
 type GameValidator struct{}
@@ -55,7 +56,8 @@ func (v *GameValidator) ValidateTakeGems(gameState *models.GameState, playerStat
 		}
 	}
 
-	// Rule: Take 3 different colors OR 2 of the same color
+	// Rule: Take 3 different colors OR 2 of the same color; fewer different
+	// colors only when no more can be taken
 	if differentColors == 3 && sameColorCount == 0 && totalTaking == 3 {
 		// Taking 3 different colors - valid
 		for gemType, count := range gems {
@@ -63,6 +65,17 @@ func (v *GameValidator) ValidateTakeGems(gameState *models.GameState, playerStat
 				return ErrNotEnoughGems
 			}
 		}
+	} else if differentColors < 3 && sameColorCount == 0 && totalTaking == differentColors && totalTaking > 0 {
+		// Fewer than 3 different colors - valid only when the bank or the
+		// 10 gem limit leaves no room for more
+		for gemType, count := range gems {
+			if count > 0 && gameState.AvailableGems[gemType] < count {
+				return ErrNotEnoughGems
+			}
+		}
+		if totalTaking < min(3, colorsAvailable(gameState), 10-gemTotal(playerState)) {
+			return ErrInvalidGemCount
+		}
 	} else if differentColors == 1 && sameColorCount == 2 && totalTaking == 2 {
 		// Taking 2 of the same color - valid only if at least 4 available
 		if gameState.AvailableGems[sameColorType] < 4 {
@@ -73,19 +86,40 @@ func (v *GameValidator) ValidateTakeGems(gameState *models.GameState, playerStat
 	}
 
 	// Check 10 gem limit
-	currentTotal := 0
-	for _, count := range playerState.Gems {
-		currentTotal += count
-	}
-	if currentTotal+totalTaking > 10 {
+	if gemTotal(playerState)+totalTaking > 10 {
 		return ErrTooManyGems
 	}
 
 	return nil
 }
 
-// ValidatePurchaseCard validates purchasing a card
-func (v *GameValidator) ValidatePurchaseCard(gameState *models.GameState, playerState *models.PlayerState, card *models.DevelopmentCard) error {
+// colorsAvailable counts the gem colors the bank can still give out
+func colorsAvailable(gameState *models.GameState) int {
+	colors := 0
+	for gemType, count := range gameState.AvailableGems {
+		if gemType != "gold" && count > 0 {
+			colors++
+		}
+	}
+	return colors
+}
+
+// gemTotal counts the tokens in a player's hand, gold included
+func gemTotal(playerState *models.PlayerState) int {
+	total := 0
+	for _, count := range playerState.Gems {
+		total += count
+	}
+	return total
+}
+
+// ValidatePurchaseCard validates purchasing a card, which must be on the
+// board or, fromReserve, in the player's reserve
+func (v *GameValidator) ValidatePurchaseCard(gameState *models.GameState, playerState *models.PlayerState, card *models.DevelopmentCard, fromReserve bool) error {
+	if findCard(gameState, playerState, card.ID, fromReserve) == nil {
+		return ErrCardNotAvailable
+	}
+
 	// Calculate total gold needed
 	totalGoldNeeded := 0
 
@@ -0,0 +1,286 @@
+package gamelogic
+
+import (
+	"testing"
+
+	"splendor-backend/internal/domain/models"
+)
+
+// bank builds the gems left in the bank. Colors not given are empty.
+func bank(gems map[string]int) *models.GameState {
+	available := map[string]int{"diamond": 0, "sapphire": 0, "emerald": 0, "ruby": 0, "onyx": 0, "gold": 0}
+	for gemType, count := range gems {
+		available[gemType] = count
+	}
+	return &models.GameState{AvailableGems: available}
+}
+
+// holding builds a player state with the given number of gold tokens, so the
+// player's colored gems never affect what the bank can give
+func holding(gold int) *models.PlayerState {
+	return &models.PlayerState{
+		Gems:          map[string]int{"gold": gold},
+		PermanentGems: map[string]int{},
+	}
+}
+
+func TestValidateTakeGems(t *testing.T) {
+	full := map[string]int{"diamond": 4, "sapphire": 4, "emerald": 4, "ruby": 4, "onyx": 4}
+
+	tests := []struct {
+		name    string
+		bank    map[string]int
+		held    int
+		gems    map[string]int
+		wantErr error
+	}{
+		{
+			name: "three different colors",
+			bank: full,
+			gems: map[string]int{"diamond": 1, "sapphire": 1, "emerald": 1},
+		},
+		{
+			name: "two of the same color",
+			bank: full,
+			gems: map[string]int{"ruby": 2},
+		},
+		{
+			name:    "two different while three are available",
+			bank:    full,
+			gems:    map[string]int{"diamond": 1, "sapphire": 1},
+			wantErr: ErrInvalidGemCount,
+		},
+		{
+			name:    "one while three are available",
+			bank:    full,
+			gems:    map[string]int{"diamond": 1},
+			wantErr: ErrInvalidGemCount,
+		},
+		{
+			name: "two different when the bank has two colors",
+			bank: map[string]int{"diamond": 1, "sapphire": 3},
+			gems: map[string]int{"diamond": 1, "sapphire": 1},
+		},
+		{
+			name:    "one when the bank has two colors",
+			bank:    map[string]int{"diamond": 1, "sapphire": 3},
+			gems:    map[string]int{"diamond": 1},
+			wantErr: ErrInvalidGemCount,
+		},
+		{
+			name: "one when the bank has one color",
+			bank: map[string]int{"onyx": 1},
+			gems: map[string]int{"onyx": 1},
+		},
+		{
+			name: "two different when holding eight",
+			bank: full,
+			held: 8,
+			gems: map[string]int{"diamond": 1, "sapphire": 1},
+		},
+		{
+			name:    "one when holding eight",
+			bank:    full,
+			held:    8,
+			gems:    map[string]int{"diamond": 1},
+			wantErr: ErrInvalidGemCount,
+		},
+		{
+			name: "one when holding nine",
+			bank: full,
+			held: 9,
+			gems: map[string]int{"diamond": 1},
+		},
+		{
+			name:    "three different when holding eight",
+			bank:    full,
+			held:    8,
+			gems:    map[string]int{"diamond": 1, "sapphire": 1, "emerald": 1},
+			wantErr: ErrTooManyGems,
+		},
+		{
+			name:    "one when holding ten",
+			bank:    full,
+			held:    10,
+			gems:    map[string]int{"diamond": 1},
+			wantErr: ErrTooManyGems,
+		},
+		{
+			name:    "color the bank is out of",
+			bank:    map[string]int{"diamond": 4, "sapphire": 4},
+			gems:    map[string]int{"diamond": 1, "sapphire": 1, "emerald": 1},
+			wantErr: ErrNotEnoughGems,
+		},
+		{
+			name:    "two of one color and one of another",
+			bank:    full,
+			gems:    map[string]int{"diamond": 2, "sapphire": 1},
+			wantErr: ErrInvalidGemCount,
+		},
+		{
+			name:    "nothing",
+			bank:    full,
+			gems:    map[string]int{},
+			wantErr: ErrInvalidGemCount,
+		},
+	}
+
+	validator := NewGameValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateTakeGems(bank(tt.bank), holding(tt.held), tt.gems)
+			if err != tt.wantErr {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePurchaseCard(t *testing.T) {
+	cheap := models.DevelopmentCard{ID: 1, Tier: 1, GemType: "ruby", Cost: map[string]int{"diamond": 1}}
+	reserved := models.DevelopmentCard{ID: 2, Tier: 2, GemType: "onyx", Cost: map[string]int{"diamond": 1}}
+	costly := models.DevelopmentCard{ID: 3, Tier: 3, GemType: "emerald", Cost: map[string]int{"ruby": 7}}
+	elsewhere := models.DevelopmentCard{ID: 4, Tier: 1, GemType: "sapphire", Cost: map[string]int{"diamond": 1}}
+
+	tests := []struct {
+		name        string
+		card        models.DevelopmentCard
+		fromReserve bool
+		gold        int
+		wantErr     error
+	}{
+		{name: "from the board", card: cheap},
+		{name: "from reserve", card: reserved, fromReserve: true},
+		{name: "board card claimed from reserve", card: cheap, fromReserve: true, wantErr: ErrCardNotAvailable},
+		{name: "reserved card claimed from the board", card: reserved, wantErr: ErrCardNotAvailable},
+		{name: "card on neither", card: elsewhere, wantErr: ErrCardNotAvailable},
+		{name: "card on neither claimed from reserve", card: elsewhere, fromReserve: true, wantErr: ErrCardNotAvailable},
+		{name: "cannot afford", card: costly, wantErr: ErrCannotAffordCard},
+		{name: "gold covers the cost", card: costly, gold: 3},
+	}
+
+	validator := NewGameValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameState := &models.GameState{
+				VisibleCardsTier1: []models.DevelopmentCard{cheap},
+				VisibleCardsTier3: []models.DevelopmentCard{costly},
+			}
+			playerState := &models.PlayerState{
+				Gems:          map[string]int{"diamond": 1, "ruby": 4, "gold": tt.gold},
+				PermanentGems: map[string]int{},
+				ReservedCards: []models.DevelopmentCard{reserved},
+			}
+
+			err := validator.ValidatePurchaseCard(gameState, playerState, &tt.card, tt.fromReserve)
+			if err != tt.wantErr {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCanOnlyPass(t *testing.T) {
+	costly := func(id int64) models.DevelopmentCard {
+		return models.DevelopmentCard{ID: id, Tier: 3, GemType: "onyx", Cost: map[string]int{"ruby": 7}}
+	}
+	affordable := models.DevelopmentCard{ID: 9, Tier: 1, GemType: "onyx", Cost: map[string]int{"diamond": 1}}
+	board := []models.DevelopmentCard{costly(1), costly(2)}
+	fullReserve := []models.DevelopmentCard{costly(3), costly(4), costly(5)}
+	tenGems := map[string]int{"diamond": 5, "sapphire": 5}
+	fullBank := map[string]int{"diamond": 4, "sapphire": 4, "emerald": 4, "ruby": 4, "onyx": 4}
+
+	tests := []struct {
+		name     string
+		bank     map[string]int
+		gems     map[string]int
+		board    []models.DevelopmentCard
+		reserved []models.DevelopmentCard
+		userID   int64
+		want     bool
+	}{
+		{
+			name:   "fresh hand",
+			bank:   fullBank,
+			gems:   map[string]int{},
+			board:  board,
+			userID: 1,
+			want:   false,
+		},
+		{
+			name:     "ten gems and a full reserve",
+			bank:     fullBank,
+			gems:     tenGems,
+			board:    board,
+			reserved: fullReserve,
+			userID:   1,
+			want:     true,
+		},
+		{
+			name:     "empty bank and a full reserve",
+			bank:     map[string]int{},
+			gems:     map[string]int{},
+			board:    board,
+			reserved: fullReserve,
+			userID:   1,
+			want:     true,
+		},
+		{
+			name:     "ten gems and room to reserve",
+			bank:     fullBank,
+			gems:     tenGems,
+			board:    board,
+			reserved: fullReserve[:2],
+			userID:   1,
+			want:     false,
+		},
+		{
+			name:     "ten gems and an affordable board card",
+			bank:     fullBank,
+			gems:     tenGems,
+			board:    append([]models.DevelopmentCard{affordable}, board...),
+			reserved: fullReserve,
+			userID:   1,
+			want:     false,
+		},
+		{
+			name:     "ten gems and an affordable reserved card",
+			bank:     fullBank,
+			gems:     tenGems,
+			board:    board,
+			reserved: []models.DevelopmentCard{costly(3), costly(4), affordable},
+			userID:   1,
+			want:     false,
+		},
+		{
+			name:     "no hand for the user",
+			bank:     map[string]int{},
+			gems:     map[string]int{},
+			board:    board,
+			reserved: fullReserve,
+			userID:   2,
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameState := bank(tt.bank)
+			gameState.VisibleCardsTier3 = tt.board
+			state := &models.FullGameState{
+				GameState: gameState,
+				PlayerStates: map[int64]*models.PlayerState{
+					1: {
+						Gems:          tt.gems,
+						PermanentGems: map[string]int{},
+						ReservedCards: tt.reserved,
+					},
+				},
+			}
+
+			if got := CanOnlyPass(state, tt.userID); got != tt.want {
+				t.Errorf("CanOnlyPass = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package gamelogic
+
+import "splendor-backend/internal/domain/models"
+
+// ViewFor returns a copy of the game state as seen by viewerID. A seated
+// player sees their own hand in full; every other hand is redacted so cards
+// reserved blind from a deck show only their tier. Deck order is never
+// included. A viewerID of 0 yields the spectator view.
+func ViewFor(state *models.FullGameState, viewerID int64) *models.FullGameState {
+	view := &models.FullGameState{
+		Game:         state.Game,
+		Players:      state.Players,
+		PlayerStates: make(map[int64]*models.PlayerState, len(state.PlayerStates)),
+	}
+
+	if state.GameState != nil {
+		gameState := *state.GameState
+		gameState.DeckTier1 = nil
+		gameState.DeckTier2 = nil
+		gameState.DeckTier3 = nil
+		view.GameState = &gameState
+	}
+
+	for userID, playerState := range state.PlayerStates {
+		if viewerID != 0 && userID == viewerID {
+			own := *playerState
+			view.PlayerStates[userID] = &own
+		} else {
+			view.PlayerStates[userID] = redactPlayerState(playerState)
+		}
+	}
+
+	return view
+}
+
+// SpectatorView returns the view shown to users who are not seated
+func SpectatorView(state *models.FullGameState) *models.FullGameState {
+	return ViewFor(state, 0)
+}
+
+// redactPlayerState hides a player's blind reserves from other viewers,
+// leaving only the reserved count and the tiers of the hidden cards
+func redactPlayerState(state *models.PlayerState) *models.PlayerState {
+	redacted := *state
+	redacted.ReservedCards = []models.DevelopmentCard{}
+	redacted.HiddenReservedTiers = nil
+	redacted.ReservedCount = len(state.ReservedCards)
+
+	blind := make(map[int64]bool, len(state.BlindReserved))
+	for _, id := range state.BlindReserved {
+		blind[id] = true
+	}
+
+	for _, card := range state.ReservedCards {
+		if blind[card.ID] {
+			redacted.HiddenReservedTiers = append(redacted.HiddenReservedTiers, card.Tier)
+		} else {
+			redacted.ReservedCards = append(redacted.ReservedCards, card)
+		}
+	}
+
+	redacted.BlindReserved = nil
+	return &redacted
+}
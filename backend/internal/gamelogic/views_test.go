@@ -0,0 +1,186 @@
+package gamelogic
+
+import (
+	"reflect"
+	"testing"
+
+	"splendor-backend/internal/domain/models"
+)
+
+// viewTestState seats two players. Player 1 reserved card 11 from the board
+// and card 12 blind from the tier 2 deck; player 2 reserved card 21 blind
+// from the tier 3 deck.
+func viewTestState() *models.FullGameState {
+	return &models.FullGameState{
+		Game: &models.Game{ID: 1, Status: models.GameStatusInProgress},
+		Players: []*models.GamePlayer{
+			{ID: 101, UserID: 1, IsActive: true},
+			{ID: 102, UserID: 2, IsActive: true},
+		},
+		GameState: &models.GameState{
+			DeckTier1: []models.DevelopmentCard{{ID: 1, Tier: 1}, {ID: 2, Tier: 1}},
+			DeckTier2: []models.DevelopmentCard{{ID: 4, Tier: 2}},
+			DeckTier3: []models.DevelopmentCard{{ID: 6, Tier: 3}},
+		},
+		PlayerStates: map[int64]*models.PlayerState{
+			1: {
+				GamePlayerID:  101,
+				ReservedCards: []models.DevelopmentCard{{ID: 11, Tier: 1}, {ID: 12, Tier: 2}},
+				BlindReserved: []int64{12},
+			},
+			2: {
+				GamePlayerID:  102,
+				ReservedCards: []models.DevelopmentCard{{ID: 21, Tier: 3}},
+				BlindReserved: []int64{21},
+			},
+		},
+	}
+}
+
+func TestViewFor(t *testing.T) {
+	type seen struct {
+		reserved []int64
+		count    int
+		hidden   []int
+		blind    []int64
+	}
+
+	tests := []struct {
+		name     string
+		viewerID int64
+		want     map[int64]seen
+	}{
+		{
+			name:     "spectator",
+			viewerID: 0,
+			want: map[int64]seen{
+				1: {reserved: []int64{11}, count: 2, hidden: []int{2}},
+				2: {reserved: []int64{}, count: 1, hidden: []int{3}},
+			},
+		},
+		{
+			name:     "seated player sees own blind reserve",
+			viewerID: 1,
+			want: map[int64]seen{
+				1: {reserved: []int64{11, 12}, blind: []int64{12}},
+				2: {reserved: []int64{}, count: 1, hidden: []int{3}},
+			},
+		},
+		{
+			name:     "other seated player",
+			viewerID: 2,
+			want: map[int64]seen{
+				1: {reserved: []int64{11}, count: 2, hidden: []int{2}},
+				2: {reserved: []int64{21}, blind: []int64{21}},
+			},
+		},
+		{
+			name:     "unseated user",
+			viewerID: 3,
+			want: map[int64]seen{
+				1: {reserved: []int64{11}, count: 2, hidden: []int{2}},
+				2: {reserved: []int64{}, count: 1, hidden: []int{3}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := viewTestState()
+			view := ViewFor(state, tt.viewerID)
+
+			if view.GameState.DeckTier1 != nil || view.GameState.DeckTier2 != nil || view.GameState.DeckTier3 != nil {
+				t.Error("deck order is visible")
+			}
+
+			for userID, want := range tt.want {
+				got := view.PlayerStates[userID]
+				reserved := []int64{}
+				for _, card := range got.ReservedCards {
+					reserved = append(reserved, card.ID)
+				}
+				if !reflect.DeepEqual(reserved, want.reserved) {
+					t.Errorf("user %d: reserved %v, want %v", userID, reserved, want.reserved)
+				}
+				if got.ReservedCount != want.count {
+					t.Errorf("user %d: reserved count %d, want %d", userID, got.ReservedCount, want.count)
+				}
+				if !reflect.DeepEqual(got.HiddenReservedTiers, want.hidden) {
+					t.Errorf("user %d: hidden tiers %v, want %v", userID, got.HiddenReservedTiers, want.hidden)
+				}
+				if !reflect.DeepEqual(got.BlindReserved, want.blind) {
+					t.Errorf("user %d: blind reserved %v, want %v", userID, got.BlindReserved, want.blind)
+				}
+			}
+
+			// Redacting must leave the loaded state untouched
+			original := viewTestState()
+			if !reflect.DeepEqual(state, original) {
+				t.Error("ViewFor changed the state it was given")
+			}
+		})
+	}
+}
+
+func TestRedactPlayerState(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    *models.PlayerState
+		reserved []int64
+		hidden   []int
+	}{
+		{
+			name:     "nothing reserved",
+			state:    &models.PlayerState{},
+			reserved: []int64{},
+		},
+		{
+			name: "only board reserves",
+			state: &models.PlayerState{
+				ReservedCards: []models.DevelopmentCard{{ID: 1, Tier: 1}, {ID: 2, Tier: 3}},
+			},
+			reserved: []int64{1, 2},
+		},
+		{
+			name: "only blind reserves",
+			state: &models.PlayerState{
+				ReservedCards: []models.DevelopmentCard{{ID: 1, Tier: 1}, {ID: 2, Tier: 3}},
+				BlindReserved: []int64{1, 2},
+			},
+			reserved: []int64{},
+			hidden:   []int{1, 3},
+		},
+		{
+			name: "mixed",
+			state: &models.PlayerState{
+				ReservedCards: []models.DevelopmentCard{{ID: 1, Tier: 2}, {ID: 2, Tier: 2}, {ID: 3, Tier: 3}},
+				BlindReserved: []int64{3, 1},
+			},
+			reserved: []int64{2},
+			hidden:   []int{2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redacted := redactPlayerState(tt.state)
+
+			reserved := []int64{}
+			for _, card := range redacted.ReservedCards {
+				reserved = append(reserved, card.ID)
+			}
+			if !reflect.DeepEqual(reserved, tt.reserved) {
+				t.Errorf("reserved %v, want %v", reserved, tt.reserved)
+			}
+			if !reflect.DeepEqual(redacted.HiddenReservedTiers, tt.hidden) {
+				t.Errorf("hidden tiers %v, want %v", redacted.HiddenReservedTiers, tt.hidden)
+			}
+			if redacted.ReservedCount != len(tt.state.ReservedCards) {
+				t.Errorf("reserved count %d, want %d", redacted.ReservedCount, len(tt.state.ReservedCards))
+			}
+			if redacted.BlindReserved != nil {
+				t.Errorf("blind reserved IDs leaked: %v", redacted.BlindReserved)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Package rating computes skill ratings from free-for-all game results.
+//
+// A game of n players is scored as n(n-1)/2 head-to-head Elo matches: each
+// player beat everyone placed below them and lost to everyone placed above.
+// A player's change is the sum over their n-1 matches scaled by 1/(n-1), so
+// a four-player game moves a rating about as much as a two-player one.
+package rating
+
+import "math"
+
+// Config sets the Elo parameters
+type Config struct {
+	Initial          float64 // Rating of a player's first game
+	KFactor          float64 // Largest change per game once established
+	ProvisionalK     float64 // Larger K used while a player is provisional
+	ProvisionalGames int     // Rated games before a player is established
+}
+
+// Player is one seat in a rated game
+type Player struct {
+	UserID    int64
+	Rating    float64
+	Games     int // Rated games played before this one
+	Placement int // 1 is first; equal placements are a draw
+}
+
+// IsProvisional reports whether a player with this many rated games is
+// still in the provisional period
+func (c Config) IsProvisional(games int) bool {
+	return games < c.ProvisionalGames
+}
+
+// Expected is the expected score of a player rated a against one rated b
+func Expected(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// Update returns each player's new rating, keyed by user ID. Provisional
+// players move faster; their opponents move at their own K.
+func (c Config) Update(players []Player) map[int64]float64 {
+	ratings := make(map[int64]float64, len(players))
+	if len(players) < 2 {
+		for _, p := range players {
+			ratings[p.UserID] = p.Rating
+		}
+		return ratings
+	}
+
+	for i, p := range players {
+		var delta float64
+		for j, o := range players {
+			if i == j {
+				continue
+			}
+			delta += score(p.Placement, o.Placement) - Expected(p.Rating, o.Rating)
+		}
+
+		k := c.KFactor
+		if c.IsProvisional(p.Games) {
+			k = c.ProvisionalK
+		}
+		ratings[p.UserID] = p.Rating + k*delta/float64(len(players)-1)
+	}
+
+	return ratings
+}
+
+// score is the result of a head-to-head match decided by placement
+func score(placement, opponent int) float64 {
+	switch {
+	case placement < opponent:
+		return 1
+	case placement > opponent:
+		return 0
+	}
+	return 0.5
+}
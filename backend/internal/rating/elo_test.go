@@ -0,0 +1,159 @@
+package rating
+
+import (
+	"math"
+	"testing"
+)
+
+var testConfig = Config{
+	Initial:          1200,
+	KFactor:          32,
+	ProvisionalK:     64,
+	ProvisionalGames: 10,
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		players []Player
+		want    map[int64]float64
+	}{
+		{
+			name: "single player keeps rating",
+			players: []Player{
+				{UserID: 1, Rating: 1300, Games: 20, Placement: 1},
+			},
+			want: map[int64]float64{1: 1300},
+		},
+		{
+			name: "equal ratings winner takes half K",
+			players: []Player{
+				{UserID: 1, Rating: 1200, Games: 20, Placement: 1},
+				{UserID: 2, Rating: 1200, Games: 20, Placement: 2},
+			},
+			want: map[int64]float64{1: 1216, 2: 1184},
+		},
+		{
+			name: "draw between equals changes nothing",
+			players: []Player{
+				{UserID: 1, Rating: 1200, Games: 20, Placement: 1},
+				{UserID: 2, Rating: 1200, Games: 20, Placement: 1},
+			},
+			want: map[int64]float64{1: 1200, 2: 1200},
+		},
+		{
+			name: "draw moves the stronger player down",
+			players: []Player{
+				{UserID: 1, Rating: 1600, Games: 20, Placement: 1},
+				{UserID: 2, Rating: 1200, Games: 20, Placement: 1},
+			},
+			want: map[int64]float64{
+				1: 1600 + 32*(0.5-Expected(1600, 1200)),
+				2: 1200 + 32*(0.5-Expected(1200, 1600)),
+			},
+		},
+		{
+			name: "provisional player moves at provisional K",
+			players: []Player{
+				{UserID: 1, Rating: 1200, Games: 3, Placement: 1},
+				{UserID: 2, Rating: 1200, Games: 20, Placement: 2},
+			},
+			want: map[int64]float64{1: 1232, 2: 1184},
+		},
+		{
+			name: "four players scaled by opponents",
+			players: []Player{
+				{UserID: 1, Rating: 1200, Games: 20, Placement: 1},
+				{UserID: 2, Rating: 1200, Games: 20, Placement: 2},
+				{UserID: 3, Rating: 1200, Games: 20, Placement: 3},
+				{UserID: 4, Rating: 1200, Games: 20, Placement: 4},
+			},
+			want: map[int64]float64{
+				1: 1200 + 32*1.5/3,
+				2: 1200 + 32*0.5/3,
+				3: 1200 - 32*0.5/3,
+				4: 1200 - 32*1.5/3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testConfig.Update(tt.players)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d ratings, want %d", len(got), len(tt.want))
+			}
+			for id, want := range tt.want {
+				if math.Abs(got[id]-want) > 1e-9 {
+					t.Errorf("user %d: got %.6f, want %.6f", id, got[id], want)
+				}
+			}
+		})
+	}
+}
+
+// TestUpdateConservesRating checks that established players in one game gain
+// exactly what the others lose
+func TestUpdateConservesRating(t *testing.T) {
+	tests := []struct {
+		name    string
+		players []Player
+	}{
+		{
+			name: "upset",
+			players: []Player{
+				{UserID: 1, Rating: 1100, Games: 30, Placement: 1},
+				{UserID: 2, Rating: 1500, Games: 30, Placement: 2},
+			},
+		},
+		{
+			name: "three players with a tie",
+			players: []Player{
+				{UserID: 1, Rating: 1250, Games: 30, Placement: 1},
+				{UserID: 2, Rating: 1400, Games: 30, Placement: 1},
+				{UserID: 3, Rating: 1000, Games: 30, Placement: 3},
+			},
+		},
+		{
+			name: "four players spread out",
+			players: []Player{
+				{UserID: 1, Rating: 900, Games: 10, Placement: 2},
+				{UserID: 2, Rating: 1800, Games: 10, Placement: 4},
+				{UserID: 3, Rating: 1350, Games: 10, Placement: 1},
+				{UserID: 4, Rating: 1200, Games: 10, Placement: 3},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testConfig.Update(tt.players)
+			var before, after float64
+			for _, p := range tt.players {
+				before += p.Rating
+				after += got[p.UserID]
+			}
+			if math.Abs(after-before) > 1e-9 {
+				t.Errorf("total rating changed from %.6f to %.6f", before, after)
+			}
+		})
+	}
+}
+
+func TestIsProvisional(t *testing.T) {
+	tests := []struct {
+		games int
+		want  bool
+	}{
+		{0, true},
+		{9, true},
+		{10, false},
+		{25, false},
+	}
+
+	for _, tt := range tests {
+		if got := testConfig.IsProvisional(tt.games); got != tt.want {
+			t.Errorf("IsProvisional(%d) = %v, want %v", tt.games, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type AchievementRepository struct {
+	db *database.DB
+}
+
+func NewAchievementRepository(db *database.DB) *AchievementRepository {
+	return &AchievementRepository{db: db}
+}
+
+// Unlock records that a player unlocked an achievement. It returns nil if
+// they had unlocked it before.
+func (r *AchievementRepository) Unlock(ctx context.Context, userID int64, key string, gameID *int64) (*models.UserAchievement, error) {
+	query := `
+		INSERT INTO user_achievements (user_id, achievement_key, game_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, achievement_key) DO NOTHING
+		RETURNING unlocked_at
+	`
+
+	unlocked := &models.UserAchievement{UserID: userID, Key: key, GameID: gameID}
+	err := r.db.QueryRow(ctx, query, userID, key, gameID).Scan(&unlocked.UnlockedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to unlock achievement: %w", err)
+	}
+
+	return unlocked, nil
+}
+
+// ListByUser retrieves the achievements a player unlocked, oldest first
+func (r *AchievementRepository) ListByUser(ctx context.Context, userID int64) ([]*models.UserAchievement, error) {
+	query := `
+		SELECT user_id, achievement_key, game_id, unlocked_at
+		FROM user_achievements
+		WHERE user_id = $1
+		ORDER BY unlocked_at, achievement_key
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list achievements: %w", err)
+	}
+	defer rows.Close()
+
+	achievements := []*models.UserAchievement{}
+	for rows.Next() {
+		a := &models.UserAchievement{}
+		if err := rows.Scan(&a.UserID, &a.Key, &a.GameID, &a.UnlockedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan achievement: %w", err)
+		}
+		achievements = append(achievements, a)
+	}
+
+	return achievements, nil
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/pkg/database"
+)
+
+type AnalyticsRepository struct {
+	db *database.DB
+}
+
+func NewAnalyticsRepository(db *database.DB) *AnalyticsRepository {
+	return &AnalyticsRepository{db: db}
+}
+
+// Create stores a player's analytics for a game. A game already analyzed
+// for the player is left as is.
+func (r *AnalyticsRepository) Create(ctx context.Context, a *models.GameAnalytics) error {
+	query := `
+		INSERT INTO game_analytics (
+			game_id, user_id, turns, first_noble_turn,
+			tier1_purchases, tier2_purchases, tier3_purchases,
+			gold_purchases, gold_spent, reserves, reserved_purchases, tokens_at_end
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (game_id, user_id) DO NOTHING
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		a.GameID,
+		a.UserID,
+		a.Turns,
+		a.FirstNobleTurn,
+		a.Tier1Purchases,
+		a.Tier2Purchases,
+		a.Tier3Purchases,
+		a.GoldPurchases,
+		a.GoldSpent,
+		a.Reserves,
+		a.ReservedPurchases,
+		a.TokensAtEnd,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create game analytics: %w", err)
+	}
+
+	return nil
+}
+
+// GetTotals sums a player's analytics over all their analyzed games
+func (r *AnalyticsRepository) GetTotals(ctx context.Context, userID int64) (*models.AnalyticsTotals, error) {
+	query := `
+		SELECT COUNT(*),
+		       COUNT(first_noble_turn),
+		       COALESCE(SUM(first_noble_turn), 0),
+		       COALESCE(SUM(tier1_purchases), 0),
+		       COALESCE(SUM(tier2_purchases), 0),
+		       COALESCE(SUM(tier3_purchases), 0),
+		       COALESCE(SUM(gold_purchases), 0),
+		       COALESCE(SUM(gold_spent), 0),
+		       COALESCE(SUM(reserves), 0),
+		       COALESCE(SUM(reserved_purchases), 0),
+		       COALESCE(SUM(tokens_at_end), 0)
+		FROM game_analytics
+		WHERE user_id = $1
+	`
+
+	totals := &models.AnalyticsTotals{}
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&totals.Games,
+		&totals.GamesWithNoble,
+		&totals.FirstNobleTurns,
+		&totals.Tier1Purchases,
+		&totals.Tier2Purchases,
+		&totals.Tier3Purchases,
+		&totals.GoldPurchases,
+		&totals.GoldSpent,
+		&totals.Reserves,
+		&totals.ReservedPurchases,
+		&totals.TokensAtEnd,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get analytics totals: %w", err)
+	}
+
+	return totals, nil
+}
+
+// ListByUser retrieves a player's most recently analyzed games
+func (r *AnalyticsRepository) ListByUser(ctx context.Context, userID int64, limit int) ([]*models.GameAnalytics, error) {
+	query := `
+		SELECT game_id, user_id, turns, first_noble_turn,
+		       tier1_purchases, tier2_purchases, tier3_purchases,
+		       gold_purchases, gold_spent, reserves, reserved_purchases,
+		       tokens_at_end, created_at
+		FROM game_analytics
+		WHERE user_id = $1
+		ORDER BY game_id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list game analytics: %w", err)
+	}
+	defer rows.Close()
+
+	games := []*models.GameAnalytics{}
+	for rows.Next() {
+		a := &models.GameAnalytics{}
+		err := rows.Scan(
+			&a.GameID,
+			&a.UserID,
+			&a.Turns,
+			&a.FirstNobleTurn,
+			&a.Tier1Purchases,
+			&a.Tier2Purchases,
+			&a.Tier3Purchases,
+			&a.GoldPurchases,
+			&a.GoldSpent,
+			&a.Reserves,
+			&a.ReservedPurchases,
+			&a.TokensAtEnd,
+			&a.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan game analytics: %w", err)
+		}
+		games = append(games, a)
+	}
+
+	return games, nil
+}
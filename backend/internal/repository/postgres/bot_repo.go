@@ -0,0 +1,241 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type BotRepository struct {
+	db *database.DB
+}
+
+func NewBotRepository(db *database.DB) *BotRepository {
+	return &BotRepository{db: db}
+}
+
+// CreateToken stores the hash of a new token for a bot account
+func (r *BotRepository) CreateToken(ctx context.Context, userID int64, tokenHash string) error {
+	query := `INSERT INTO bot_tokens (user_id, token_hash) VALUES ($1, $2)`
+
+	_, err := r.db.Exec(ctx, query, userID, tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to create bot token: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeTokens revokes every active token of a bot account
+func (r *BotRepository) RevokeTokens(ctx context.Context, userID int64) error {
+	query := `UPDATE bot_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL`
+
+	_, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke bot tokens: %w", err)
+	}
+
+	return nil
+}
+
+// GetUserByTokenHash returns the bot account holding an active token and
+// records that the token was used
+func (r *BotRepository) GetUserByTokenHash(ctx context.Context, tokenHash string) (*models.User, error) {
+	query := `
+		UPDATE bot_tokens bt
+		SET last_used_at = CURRENT_TIMESTAMP
+		FROM users u
+		WHERE bt.token_hash = $1 AND bt.revoked_at IS NULL AND u.id = bt.user_id
+		RETURNING u.id, u.username, u.email, u.is_bot, u.bot_owner_id, u.created_at, u.updated_at
+	`
+
+	user := &models.User{}
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.IsBot,
+		&user.BotOwnerID,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("bot token not found")
+		}
+		return nil, fmt.Errorf("failed to get bot by token: %w", err)
+	}
+
+	return user, nil
+}
+
+// ListByOwner returns the bot accounts registered by a user
+func (r *BotRepository) ListByOwner(ctx context.Context, ownerID int64) ([]*models.User, error) {
+	query := `
+		SELECT id, username, email, is_bot, bot_owner_id, created_at, updated_at
+		FROM users
+		WHERE bot_owner_id = $1
+		ORDER BY id
+	`
+
+	rows, err := r.db.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bots: %w", err)
+	}
+	defer rows.Close()
+
+	bots := []*models.User{}
+	for rows.Next() {
+		user := &models.User{}
+		err := rows.Scan(
+			&user.ID,
+			&user.Username,
+			&user.Email,
+			&user.IsBot,
+			&user.BotOwnerID,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bot: %w", err)
+		}
+		bots = append(bots, user)
+	}
+
+	return bots, nil
+}
+
+// OfferTurn records a turn awaiting an external bot's reply, replacing any
+// earlier offer of the same turn
+func (r *BotRepository) OfferTurn(ctx context.Context, turn *models.BotTurn) error {
+	query := `
+		INSERT INTO bot_turns (game_id, user_id, turn_number, legal_actions, deadline)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (game_id, turn_number) DO UPDATE
+		SET user_id = EXCLUDED.user_id,
+		    legal_actions = EXCLUDED.legal_actions,
+		    action = NULL,
+		    deadline = EXCLUDED.deadline
+	`
+
+	_, err := r.db.Exec(ctx, query, turn.GameID, turn.UserID, turn.TurnNumber, turn.LegalActions, turn.Deadline)
+	if err != nil {
+		return fmt.Errorf("failed to offer bot turn: %w", err)
+	}
+
+	return nil
+}
+
+// GetTurn returns an open turn offered to a bot
+func (r *BotRepository) GetTurn(ctx context.Context, gameID, userID int64, turnNumber int) (*models.BotTurn, error) {
+	query := `
+		SELECT game_id, user_id, turn_number, legal_actions, deadline
+		FROM bot_turns
+		WHERE game_id = $1 AND user_id = $2 AND turn_number = $3
+		  AND action IS NULL AND deadline > CURRENT_TIMESTAMP
+	`
+
+	turn := &models.BotTurn{}
+	err := r.db.QueryRow(ctx, query, gameID, userID, turnNumber).Scan(
+		&turn.GameID,
+		&turn.UserID,
+		&turn.TurnNumber,
+		&turn.LegalActions,
+		&turn.Deadline,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("bot turn not found")
+		}
+		return nil, fmt.Errorf("failed to get bot turn: %w", err)
+	}
+
+	return turn, nil
+}
+
+// GetPendingTurns returns the open turns offered to a bot
+func (r *BotRepository) GetPendingTurns(ctx context.Context, userID int64) ([]*models.BotTurn, error) {
+	query := `
+		SELECT game_id, user_id, turn_number, legal_actions, deadline
+		FROM bot_turns
+		WHERE user_id = $1 AND action IS NULL AND deadline > CURRENT_TIMESTAMP
+		ORDER BY deadline
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending bot turns: %w", err)
+	}
+	defer rows.Close()
+
+	turns := []*models.BotTurn{}
+	for rows.Next() {
+		turn := &models.BotTurn{}
+		err := rows.Scan(
+			&turn.GameID,
+			&turn.UserID,
+			&turn.TurnNumber,
+			&turn.LegalActions,
+			&turn.Deadline,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bot turn: %w", err)
+		}
+		turns = append(turns, turn)
+	}
+
+	return turns, nil
+}
+
+// AnswerTurn stores a bot's reply to an open turn. It reports false if the
+// turn was already answered or its deadline has passed.
+func (r *BotRepository) AnswerTurn(ctx context.Context, gameID, userID int64, turnNumber int, action json.RawMessage) (bool, error) {
+	query := `
+		UPDATE bot_turns
+		SET action = $4
+		WHERE game_id = $1 AND user_id = $2 AND turn_number = $3
+		  AND action IS NULL AND deadline > CURRENT_TIMESTAMP
+	`
+
+	tag, err := r.db.Exec(ctx, query, gameID, userID, turnNumber, action)
+	if err != nil {
+		return false, fmt.Errorf("failed to answer bot turn: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// GetTurnAction returns a bot's reply to a turn, or nil if it has not
+// answered yet
+func (r *BotRepository) GetTurnAction(ctx context.Context, gameID int64, turnNumber int) (json.RawMessage, error) {
+	query := `SELECT action FROM bot_turns WHERE game_id = $1 AND turn_number = $2`
+
+	var action []byte
+	err := r.db.QueryRow(ctx, query, gameID, turnNumber).Scan(&action)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get bot turn action: %w", err)
+	}
+
+	return action, nil
+}
+
+// CloseTurn removes a turn once it has been played
+func (r *BotRepository) CloseTurn(ctx context.Context, gameID int64, turnNumber int) error {
+	query := `DELETE FROM bot_turns WHERE game_id = $1 AND turn_number = $2`
+
+	_, err := r.db.Exec(ctx, query, gameID, turnNumber)
+	if err != nil {
+		return fmt.Errorf("failed to close bot turn: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/pkg/database"
+)
+
+type ChatRepository struct {
+	db *database.DB
+}
+
+func NewChatRepository(db *database.DB) *ChatRepository {
+	return &ChatRepository{db: db}
+}
+
+// CreateMessage stores a chat message
+func (r *ChatRepository) CreateMessage(ctx context.Context, msg *models.ChatMessage) error {
+	query := `
+		INSERT INTO chat_messages (game_id, user_id, message)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query, msg.GameID, msg.UserID, msg.Message).
+		Scan(&msg.ID, &msg.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create chat message: %w", err)
+	}
+
+	return nil
+}
+
+// GetMessages retrieves a game's chat history, newest first. Messages from
+// users muted by viewerID are left out. A beforeID of 0 starts from the latest.
+// With a delay, only messages at least that many seconds old are returned.
+func (r *ChatRepository) GetMessages(ctx context.Context, gameID, viewerID, beforeID int64, delaySeconds, limit int) ([]*models.ChatMessage, error) {
+	query := `
+		SELECT cm.id, cm.game_id, cm.user_id, u.username, cm.message, cm.created_at
+		FROM chat_messages cm
+		JOIN users u ON u.id = cm.user_id
+		WHERE cm.game_id = $1
+		  AND ($2::BIGINT = 0 OR cm.id < $2)
+		  AND ($5::INT = 0 OR cm.created_at <= CURRENT_TIMESTAMP - make_interval(secs => $5))
+		  AND NOT EXISTS (
+		      SELECT 1 FROM chat_mutes m
+		      WHERE m.game_id = cm.game_id AND m.user_id = $3 AND m.muted_user_id = cm.user_id
+		  )
+		ORDER BY cm.id DESC
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(ctx, query, gameID, beforeID, viewerID, limit, delaySeconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat messages: %w", err)
+	}
+	defer rows.Close()
+
+	messages := []*models.ChatMessage{}
+	for rows.Next() {
+		msg := &models.ChatMessage{}
+		err := rows.Scan(
+			&msg.ID,
+			&msg.GameID,
+			&msg.UserID,
+			&msg.Username,
+			&msg.Message,
+			&msg.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan chat message: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// AddMute records that userID has muted mutedUserID in a game
+func (r *ChatRepository) AddMute(ctx context.Context, gameID, userID, mutedUserID int64) error {
+	query := `
+		INSERT INTO chat_mutes (game_id, user_id, muted_user_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (game_id, user_id, muted_user_id) DO NOTHING
+	`
+
+	_, err := r.db.Exec(ctx, query, gameID, userID, mutedUserID)
+	if err != nil {
+		return fmt.Errorf("failed to add mute: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveMute deletes a mute
+func (r *ChatRepository) RemoveMute(ctx context.Context, gameID, userID, mutedUserID int64) error {
+	query := `DELETE FROM chat_mutes WHERE game_id = $1 AND user_id = $2 AND muted_user_id = $3`
+
+	_, err := r.db.Exec(ctx, query, gameID, userID, mutedUserID)
+	if err != nil {
+		return fmt.Errorf("failed to remove mute: %w", err)
+	}
+
+	return nil
+}
+
+// GetMutedUsers returns the IDs of users muted by userID in a game
+func (r *ChatRepository) GetMutedUsers(ctx context.Context, gameID, userID int64) ([]int64, error) {
+	query := `SELECT muted_user_id FROM chat_mutes WHERE game_id = $1 AND user_id = $2`
+
+	rows, err := r.db.Query(ctx, query, gameID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get muted users: %w", err)
+	}
+	defer rows.Close()
+
+	muted := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan muted user: %w", err)
+		}
+		muted = append(muted, id)
+	}
+
+	return muted, nil
+}
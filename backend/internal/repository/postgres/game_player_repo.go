@@ -22,3 +22,35 @@ func (r *GameRepository) UpdatePlayer(ctx context.Context, player *models.GamePl
 
 	return nil
 }
+
+// DeletePlayer removes a player's seat entirely, freeing its position
+func (r *GameRepository) DeletePlayer(ctx context.Context, gameID, userID int64) error {
+	query := `DELETE FROM game_players WHERE game_id = $1 AND user_id = $2`
+
+	_, err := r.db.Exec(ctx, query, gameID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete player: %w", err)
+	}
+
+	return nil
+}
+
+// GetFreePosition returns the lowest seat position not taken in a game
+func (r *GameRepository) GetFreePosition(ctx context.Context, gameID int64) (int, error) {
+	query := `
+		SELECT MIN(pos)
+		FROM generate_series(0, 3) AS pos
+		WHERE pos NOT IN (SELECT player_position FROM game_players WHERE game_id = $1)
+	`
+
+	var position *int
+	err := r.db.QueryRow(ctx, query, gameID).Scan(&position)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get free position: %w", err)
+	}
+	if position == nil {
+		return 0, fmt.Errorf("no free position in game %d", gameID)
+	}
+
+	return *position, nil
+}
@@ -51,12 +51,12 @@ func generateRandomCode(length int) string {
 // Create creates a new game
 func (r *GameRepository) Create(ctx context.Context, game *models.Game) error {
 	query := `
-		INSERT INTO games (room_code, status, num_players, created_by, turn_number)
-		VALUES ($1, $2, $3, $4, 0)
+		INSERT INTO games (room_code, status, num_players, created_by, turn_number, spectator_delay_seconds, allow_hints, ranked)
+		VALUES ($1, $2, $3, $4, 0, $5, $6, $7)
 		RETURNING id, created_at
 	`
 
-	err := r.db.QueryRow(ctx, query, game.RoomCode, game.Status, game.NumPlayers, game.CreatedBy).
+	err := r.db.QueryRow(ctx, query, game.RoomCode, game.Status, game.NumPlayers, game.CreatedBy, game.SpectatorDelaySeconds, game.AllowHints, game.Ranked).
 		Scan(&game.ID, &game.CreatedAt)
 
 	if err != nil {
@@ -70,7 +70,9 @@ func (r *GameRepository) Create(ctx context.Context, game *models.Game) error {
 func (r *GameRepository) GetByID(ctx context.Context, id int64) (*models.Game, error) {
 	query := `
 		SELECT id, room_code, status, current_turn_player_id, turn_number,
-		       winner_id, created_by, num_players, created_at, started_at, completed_at
+		       winner_id, created_by, num_players, spectator_delay_seconds,
+		       allow_hints, hints_used, ranked,
+		       created_at, started_at, completed_at
 		FROM games
 		WHERE id = $1
 	`
@@ -85,6 +87,10 @@ func (r *GameRepository) GetByID(ctx context.Context, id int64) (*models.Game, e
 		&game.WinnerID,
 		&game.CreatedBy,
 		&game.NumPlayers,
+		&game.SpectatorDelaySeconds,
+		&game.AllowHints,
+		&game.HintsUsed,
+		&game.Ranked,
 		&game.CreatedAt,
 		&game.StartedAt,
 		&game.CompletedAt,
@@ -104,7 +110,9 @@ func (r *GameRepository) GetByID(ctx context.Context, id int64) (*models.Game, e
 func (r *GameRepository) GetByRoomCode(ctx context.Context, roomCode string) (*models.Game, error) {
 	query := `
 		SELECT id, room_code, status, current_turn_player_id, turn_number,
-		       winner_id, created_by, num_players, created_at, started_at, completed_at
+		       winner_id, created_by, num_players, spectator_delay_seconds,
+		       allow_hints, hints_used, ranked,
+		       created_at, started_at, completed_at
 		FROM games
 		WHERE room_code = $1
 	`
@@ -119,6 +127,10 @@ func (r *GameRepository) GetByRoomCode(ctx context.Context, roomCode string) (*m
 		&game.WinnerID,
 		&game.CreatedBy,
 		&game.NumPlayers,
+		&game.SpectatorDelaySeconds,
+		&game.AllowHints,
+		&game.HintsUsed,
+		&game.Ranked,
 		&game.CreatedAt,
 		&game.StartedAt,
 		&game.CompletedAt,
@@ -160,7 +172,9 @@ func (r *GameRepository) List(ctx context.Context, status *models.GameStatus, li
 	if status != nil {
 		query = `
 			SELECT id, room_code, status, current_turn_player_id, turn_number,
-			       winner_id, created_by, num_players, created_at, started_at, completed_at
+			       winner_id, created_by, num_players, spectator_delay_seconds,
+			       allow_hints, hints_used, ranked,
+			       created_at, started_at, completed_at
 			FROM games
 			WHERE status = $1
 			ORDER BY created_at DESC
@@ -170,7 +184,9 @@ func (r *GameRepository) List(ctx context.Context, status *models.GameStatus, li
 	} else {
 		query = `
 			SELECT id, room_code, status, current_turn_player_id, turn_number,
-			       winner_id, created_by, num_players, created_at, started_at, completed_at
+			       winner_id, created_by, num_players, spectator_delay_seconds,
+			       allow_hints, hints_used, ranked,
+			       created_at, started_at, completed_at
 			FROM games
 			ORDER BY created_at DESC
 			LIMIT $1 OFFSET $2
@@ -196,6 +212,10 @@ func (r *GameRepository) List(ctx context.Context, status *models.GameStatus, li
 			&game.WinnerID,
 			&game.CreatedBy,
 			&game.NumPlayers,
+			&game.SpectatorDelaySeconds,
+			&game.AllowHints,
+			&game.HintsUsed,
+			&game.Ranked,
 			&game.CreatedAt,
 			&game.StartedAt,
 			&game.CompletedAt,
@@ -235,11 +255,142 @@ func (r *GameRepository) Update(ctx context.Context, game *models.Game) error {
 	return nil
 }
 
+// MarkHintsUsed flags a game as played with hints
+func (r *GameRepository) MarkHintsUsed(ctx context.Context, gameID int64) error {
+	query := `UPDATE games SET hints_used = true WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, query, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to mark hints used: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFinalized claims a completed game for the game-over pipeline. It
+// reports false if the game isn't completed or was already claimed.
+func (r *GameRepository) MarkFinalized(ctx context.Context, gameID int64) (bool, error) {
+	query := `
+		UPDATE games SET finalized_at = NOW()
+		WHERE id = $1 AND status = 'completed' AND finalized_at IS NULL
+	`
+
+	tag, err := r.db.Exec(ctx, query, gameID)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark game finalized: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// GetUnfinalizedGames returns completed games the game-over pipeline has not
+// processed yet, e.g. because the server stopped right after the last move
+func (r *GameRepository) GetUnfinalizedGames(ctx context.Context) ([]int64, error) {
+	query := `SELECT id FROM games WHERE status = 'completed' AND finalized_at IS NULL`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unfinalized games: %w", err)
+	}
+	defer rows.Close()
+
+	gameIDs := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan game ID: %w", err)
+		}
+		gameIDs = append(gameIDs, id)
+	}
+
+	return gameIDs, nil
+}
+
+// MarkAchievementsChecked records that a finalized game's game-end
+// achievements were checked
+func (r *GameRepository) MarkAchievementsChecked(ctx context.Context, gameID int64) error {
+	query := `UPDATE games SET achievements_checked_at = NOW() WHERE id = $1 AND finalized_at IS NOT NULL`
+
+	_, err := r.db.Exec(ctx, query, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to mark achievements checked: %w", err)
+	}
+
+	return nil
+}
+
+// GetUncheckedAchievementGames returns finalized games whose game-end
+// achievements have not been checked, because the check failed
+func (r *GameRepository) GetUncheckedAchievementGames(ctx context.Context) ([]int64, error) {
+	query := `SELECT id FROM games WHERE finalized_at IS NOT NULL AND achievements_checked_at IS NULL`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get games with unchecked achievements: %w", err)
+	}
+	defer rows.Close()
+
+	gameIDs := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan game ID: %w", err)
+		}
+		gameIDs = append(gameIDs, id)
+	}
+
+	return gameIDs, nil
+}
+
+// MarkAnalyzed claims a completed game for the analytics job. It reports
+// false if the game isn't completed or was already claimed.
+func (r *GameRepository) MarkAnalyzed(ctx context.Context, gameID int64) (bool, error) {
+	query := `
+		UPDATE games SET analyzed_at = NOW()
+		WHERE id = $1 AND status = 'completed' AND analyzed_at IS NULL
+	`
+
+	tag, err := r.db.Exec(ctx, query, gameID)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark game analyzed: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// GetUnanalyzedGames returns up to limit completed games the analytics job
+// has not processed yet, oldest first
+func (r *GameRepository) GetUnanalyzedGames(ctx context.Context, limit int) ([]int64, error) {
+	query := `
+		SELECT id FROM games
+		WHERE status = 'completed' AND analyzed_at IS NULL
+		ORDER BY completed_at, id
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unanalyzed games: %w", err)
+	}
+	defer rows.Close()
+
+	gameIDs := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan game ID: %w", err)
+		}
+		gameIDs = append(gameIDs, id)
+	}
+
+	return gameIDs, nil
+}
+
 // AddPlayer adds a player to a game
 func (r *GameRepository) AddPlayer(ctx context.Context, gamePlayer *models.GamePlayer) error {
 	query := `
-		INSERT INTO game_players (game_id, user_id, player_position, victory_points, is_active)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO game_players (game_id, user_id, player_position, victory_points, is_active, bot_strategy)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
 		RETURNING id, joined_at
 	`
 
@@ -249,6 +400,7 @@ func (r *GameRepository) AddPlayer(ctx context.Context, gamePlayer *models.GameP
 		gamePlayer.PlayerPosition,
 		gamePlayer.VictoryPoints,
 		gamePlayer.IsActive,
+		gamePlayer.BotStrategy,
 	).Scan(&gamePlayer.ID, &gamePlayer.JoinedAt)
 
 	if err != nil {
@@ -262,8 +414,8 @@ func (r *GameRepository) AddPlayer(ctx context.Context, gamePlayer *models.GameP
 func (r *GameRepository) GetPlayers(ctx context.Context, gameID int64) ([]*models.GamePlayer, error) {
 	query := `
 		SELECT gp.id, gp.game_id, gp.user_id, gp.player_position,
-		       gp.victory_points, gp.is_active, gp.joined_at,
-		       u.id, u.username, u.email, u.created_at, u.updated_at
+		       gp.victory_points, gp.is_active, COALESCE(gp.bot_strategy, ''), gp.joined_at,
+		       u.id, u.username, u.email, u.is_bot, u.created_at, u.updated_at
 		FROM game_players gp
 		JOIN users u ON u.id = gp.user_id
 		WHERE gp.game_id = $1
@@ -286,10 +438,12 @@ func (r *GameRepository) GetPlayers(ctx context.Context, gameID int64) ([]*model
 			&player.PlayerPosition,
 			&player.VictoryPoints,
 			&player.IsActive,
+			&player.BotStrategy,
 			&player.JoinedAt,
 			&player.User.ID,
 			&player.User.Username,
 			&player.User.Email,
+			&player.User.IsBot,
 			&player.User.CreatedAt,
 			&player.User.UpdatedAt,
 		)
@@ -339,3 +493,31 @@ func (r *GameRepository) RemovePlayer(ctx context.Context, gameID, userID int64)
 
 	return nil
 }
+
+// GetGamesAwaitingBot returns the IDs of in-progress games whose current
+// turn belongs to a bot seat
+func (r *GameRepository) GetGamesAwaitingBot(ctx context.Context) ([]int64, error) {
+	query := `
+		SELECT g.id
+		FROM games g
+		JOIN game_players gp ON gp.game_id = g.id AND gp.user_id = g.current_turn_player_id
+		WHERE g.status = 'in_progress' AND gp.bot_strategy IS NOT NULL
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get games awaiting bot: %w", err)
+	}
+	defer rows.Close()
+
+	gameIDs := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan game ID: %w", err)
+		}
+		gameIDs = append(gameIDs, id)
+	}
+
+	return gameIDs, nil
+}
@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type MatchmakingRepository struct {
+	db *database.DB
+}
+
+func NewMatchmakingRepository(db *database.DB) *MatchmakingRepository {
+	return &MatchmakingRepository{db: db}
+}
+
+// Join queues a player, replacing any earlier request or match. A player who
+// joins the queue they are already waiting in keeps their place.
+func (r *MatchmakingRepository) Join(ctx context.Context, entry *models.QueueEntry) error {
+	query := `
+		INSERT INTO matchmaking_queue (user_id, num_players, ranked, rating, joined_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE SET
+			num_players = EXCLUDED.num_players,
+			ranked = EXCLUDED.ranked,
+			rating = EXCLUDED.rating,
+			game_id = NULL,
+			joined_at = CASE
+				WHEN matchmaking_queue.game_id IS NULL
+					AND matchmaking_queue.num_players = EXCLUDED.num_players
+					AND matchmaking_queue.ranked = EXCLUDED.ranked
+				THEN matchmaking_queue.joined_at
+				ELSE EXCLUDED.joined_at
+			END
+		RETURNING joined_at
+	`
+
+	err := r.db.QueryRow(ctx, query, entry.UserID, entry.NumPlayers, entry.Ranked, entry.Rating, entry.JoinedAt).Scan(&entry.JoinedAt)
+	if err != nil {
+		return fmt.Errorf("failed to join queue: %w", err)
+	}
+
+	return nil
+}
+
+// Leave takes a waiting player out of the queue. It reports false if they
+// were not waiting.
+func (r *MatchmakingRepository) Leave(ctx context.Context, userID int64) (bool, error) {
+	query := `DELETE FROM matchmaking_queue WHERE user_id = $1 AND game_id IS NULL`
+
+	result, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to leave queue: %w", err)
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+// Get returns a player's queue row, or nil if they never queued
+func (r *MatchmakingRepository) Get(ctx context.Context, userID int64) (*models.QueueEntry, error) {
+	query := `
+		SELECT user_id, num_players, ranked, rating, joined_at, game_id
+		FROM matchmaking_queue
+		WHERE user_id = $1
+	`
+
+	entry := &models.QueueEntry{}
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&entry.UserID,
+		&entry.NumPlayers,
+		&entry.Ranked,
+		&entry.Rating,
+		&entry.JoinedAt,
+		&entry.GameID,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get queue entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// CountWaiting counts the players waiting for a player count and mode
+func (r *MatchmakingRepository) CountWaiting(ctx context.Context, numPlayers int, ranked bool) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM matchmaking_queue
+		WHERE game_id IS NULL AND num_players = $1 AND ranked = $2
+	`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, numPlayers, ranked).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count queue: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetWaiting returns every waiting player, longest waiting first
+func (r *MatchmakingRepository) GetWaiting(ctx context.Context) ([]*models.QueueEntry, error) {
+	query := `
+		SELECT user_id, num_players, ranked, rating, joined_at, game_id
+		FROM matchmaking_queue
+		WHERE game_id IS NULL
+		ORDER BY joined_at
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queue: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*models.QueueEntry{}
+	for rows.Next() {
+		entry := &models.QueueEntry{}
+		if err := rows.Scan(
+			&entry.UserID,
+			&entry.NumPlayers,
+			&entry.Ranked,
+			&entry.Rating,
+			&entry.JoinedAt,
+			&entry.GameID,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan queue entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// Claim locks the rows of a group that are still waiting, skipping rows
+// another matching pass holds. It reports false unless every player could be
+// claimed. It must run in a transaction, which holds the locks until the
+// group is matched.
+func (r *MatchmakingRepository) Claim(ctx context.Context, userIDs []int64) (bool, error) {
+	query := `
+		SELECT user_id FROM matchmaking_queue
+		WHERE user_id = ANY($1) AND game_id IS NULL
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := r.db.Query(ctx, query, userIDs)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim queue entries: %w", err)
+	}
+	defer rows.Close()
+
+	claimed := 0
+	for rows.Next() {
+		claimed++
+	}
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("failed to claim queue entries: %w", err)
+	}
+
+	return claimed == len(userIDs), nil
+}
+
+// SetMatched records the game a claimed group was put in
+func (r *MatchmakingRepository) SetMatched(ctx context.Context, userIDs []int64, gameID int64) error {
+	query := `UPDATE matchmaking_queue SET game_id = $2 WHERE user_id = ANY($1)`
+
+	_, err := r.db.Exec(ctx, query, userIDs, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to record match: %w", err)
+	}
+
+	return nil
+}
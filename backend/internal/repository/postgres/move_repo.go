@@ -0,0 +1,287 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type MoveRepository struct {
+	db *database.DB
+}
+
+func NewMoveRepository(db *database.DB) *MoveRepository {
+	return &MoveRepository{db: db}
+}
+
+// Create appends a move to a game's log
+func (r *MoveRepository) Create(ctx context.Context, move *models.GameMove) error {
+	query := `
+		INSERT INTO game_moves (game_id, game_player_id, move_number, move_type, move_data, state_before)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		move.GameID,
+		move.GamePlayerID,
+		move.MoveNumber,
+		move.MoveType,
+		move.MoveData,
+		move.StateBefore,
+	).Scan(&move.ID, &move.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create move: %w", err)
+	}
+
+	return nil
+}
+
+// GetLast returns the most recent move of a game, or nil if none was made
+func (r *MoveRepository) GetLast(ctx context.Context, gameID int64) (*models.GameMove, error) {
+	query := `
+		SELECT m.id, m.game_id, m.game_player_id, gp.user_id, m.move_number,
+		       m.move_type, m.move_data, m.state_before, m.created_at
+		FROM game_moves m
+		JOIN game_players gp ON gp.id = m.game_player_id
+		WHERE m.game_id = $1
+		ORDER BY m.move_number DESC, m.id DESC
+		LIMIT 1
+	`
+
+	move := &models.GameMove{}
+	err := r.db.QueryRow(ctx, query, gameID).Scan(
+		&move.ID,
+		&move.GameID,
+		&move.GamePlayerID,
+		&move.UserID,
+		&move.MoveNumber,
+		&move.MoveType,
+		&move.MoveData,
+		&move.StateBefore,
+		&move.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get last move: %w", err)
+	}
+
+	return move, nil
+}
+
+// Delete removes a move from the log
+func (r *MoveRepository) Delete(ctx context.Context, moveID int64) error {
+	query := `DELETE FROM game_moves WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, query, moveID)
+	if err != nil {
+		return fmt.Errorf("failed to delete move: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteFrom removes a game's moves from moveNumber on, e.g. after the game
+// was restored to an earlier point
+func (r *MoveRepository) DeleteFrom(ctx context.Context, gameID int64, moveNumber int) error {
+	query := `DELETE FROM game_moves WHERE game_id = $1 AND move_number >= $2`
+
+	_, err := r.db.Exec(ctx, query, gameID, moveNumber)
+	if err != nil {
+		return fmt.Errorf("failed to delete moves: %w", err)
+	}
+
+	return nil
+}
+
+// GetByGame retrieves a game's logged moves in the order they were played
+func (r *MoveRepository) GetByGame(ctx context.Context, gameID int64) ([]*models.GameMove, error) {
+	query := `
+		SELECT m.id, m.game_id, m.game_player_id, gp.user_id, m.move_number,
+		       m.move_type, m.move_data, m.state_before, m.created_at
+		FROM game_moves m
+		JOIN game_players gp ON gp.id = m.game_player_id
+		WHERE m.game_id = $1
+		ORDER BY m.move_number, m.id
+	`
+
+	rows, err := r.db.Query(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moves: %w", err)
+	}
+	defer rows.Close()
+
+	moves := []*models.GameMove{}
+	for rows.Next() {
+		move := &models.GameMove{}
+		err := rows.Scan(
+			&move.ID,
+			&move.GameID,
+			&move.GamePlayerID,
+			&move.UserID,
+			&move.MoveNumber,
+			&move.MoveType,
+			&move.MoveData,
+			&move.StateBefore,
+			&move.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan move: %w", err)
+		}
+		moves = append(moves, move)
+	}
+
+	return moves, nil
+}
+
+// GetTimeline retrieves a game's logged moves in the order they were played
+func (r *MoveRepository) GetTimeline(ctx context.Context, gameID int64) ([]*models.TimelineEntry, error) {
+	query := `
+		SELECT m.move_number, gp.user_id, u.username, m.move_type, m.move_data, m.created_at
+		FROM game_moves m
+		JOIN game_players gp ON gp.id = m.game_player_id
+		JOIN users u ON u.id = gp.user_id
+		WHERE m.game_id = $1
+		ORDER BY m.move_number, m.id
+	`
+
+	rows, err := r.db.Query(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timeline: %w", err)
+	}
+	defer rows.Close()
+
+	timeline := []*models.TimelineEntry{}
+	for rows.Next() {
+		entry := &models.TimelineEntry{}
+		err := rows.Scan(
+			&entry.MoveNumber,
+			&entry.UserID,
+			&entry.Username,
+			&entry.MoveType,
+			&entry.MoveData,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan move: %w", err)
+		}
+		timeline = append(timeline, entry)
+	}
+
+	return timeline, nil
+}
+
+// CountByPlayer counts the logged moves of each seat in a game, keyed by
+// game player ID
+func (r *MoveRepository) CountByPlayer(ctx context.Context, gameID int64) (map[int64]int, error) {
+	query := `
+		SELECT game_player_id, COUNT(*)
+		FROM game_moves
+		WHERE game_id = $1
+		GROUP BY game_player_id
+	`
+
+	rows, err := r.db.Query(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count moves: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int64]int)
+	for rows.Next() {
+		var gamePlayerID int64
+		var count int
+		if err := rows.Scan(&gamePlayerID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan move count: %w", err)
+		}
+		counts[gamePlayerID] = count
+	}
+
+	return counts, nil
+}
+
+// CreateUndoRequest opens a take-back request, replacing any earlier one
+// for the game
+func (r *MoveRepository) CreateUndoRequest(ctx context.Context, req *models.UndoRequest) error {
+	query := `
+		INSERT INTO undo_requests (game_id, move_id, requested_by, approvals)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (game_id) DO UPDATE
+		SET move_id = EXCLUDED.move_id,
+		    requested_by = EXCLUDED.requested_by,
+		    approvals = EXCLUDED.approvals,
+		    created_at = CURRENT_TIMESTAMP
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRow(ctx, query, req.GameID, req.MoveID, req.RequestedBy, req.Approvals).Scan(&req.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create undo request: %w", err)
+	}
+
+	return nil
+}
+
+// GetUndoRequest returns a game's open take-back request, or nil
+func (r *MoveRepository) GetUndoRequest(ctx context.Context, gameID int64) (*models.UndoRequest, error) {
+	query := `
+		SELECT game_id, move_id, requested_by, approvals, created_at
+		FROM undo_requests
+		WHERE game_id = $1
+	`
+
+	req := &models.UndoRequest{Status: models.UndoPending}
+	err := r.db.QueryRow(ctx, query, gameID).Scan(
+		&req.GameID,
+		&req.MoveID,
+		&req.RequestedBy,
+		&req.Approvals,
+		&req.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get undo request: %w", err)
+	}
+
+	return req, nil
+}
+
+// AddUndoApproval records a player's approval and returns all approvals so far
+func (r *MoveRepository) AddUndoApproval(ctx context.Context, gameID, userID int64) ([]int64, error) {
+	query := `
+		UPDATE undo_requests
+		SET approvals = array_append(array_remove(approvals, $2), $2)
+		WHERE game_id = $1
+		RETURNING approvals
+	`
+
+	var approvals []int64
+	err := r.db.QueryRow(ctx, query, gameID, userID).Scan(&approvals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve undo request: %w", err)
+	}
+
+	return approvals, nil
+}
+
+// DeleteUndoRequest closes a game's take-back request
+func (r *MoveRepository) DeleteUndoRequest(ctx context.Context, gameID int64) error {
+	query := `DELETE FROM undo_requests WHERE game_id = $1`
+
+	_, err := r.db.Exec(ctx, query, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to delete undo request: %w", err)
+	}
+
+	return nil
+}
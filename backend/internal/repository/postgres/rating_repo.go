@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type RatingRepository struct {
+	db *database.DB
+}
+
+func NewRatingRepository(db *database.DB) *RatingRepository {
+	return &RatingRepository{db: db}
+}
+
+// GetRating retrieves a player's rating, or nil if they have no rated games
+func (r *RatingRepository) GetRating(ctx context.Context, userID int64) (*models.PlayerRating, error) {
+	query := `
+		SELECT user_id, rating, peak_rating, games_rated, updated_at
+		FROM player_ratings
+		WHERE user_id = $1
+	`
+
+	rating := &models.PlayerRating{}
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&rating.UserID,
+		&rating.Rating,
+		&rating.PeakRating,
+		&rating.GamesRated,
+		&rating.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get rating: %w", err)
+	}
+
+	return rating, nil
+}
+
+// GetRatings retrieves the ratings of several players, keyed by user ID.
+// Players without rated games are left out.
+func (r *RatingRepository) GetRatings(ctx context.Context, userIDs []int64) (map[int64]*models.PlayerRating, error) {
+	query := `
+		SELECT user_id, rating, peak_rating, games_rated, updated_at
+		FROM player_ratings
+		WHERE user_id = ANY($1)
+	`
+
+	rows, err := r.db.Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ratings: %w", err)
+	}
+	defer rows.Close()
+
+	ratings := make(map[int64]*models.PlayerRating)
+	for rows.Next() {
+		rating := &models.PlayerRating{}
+		err := rows.Scan(
+			&rating.UserID,
+			&rating.Rating,
+			&rating.PeakRating,
+			&rating.GamesRated,
+			&rating.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rating: %w", err)
+		}
+		ratings[rating.UserID] = rating
+	}
+
+	return ratings, nil
+}
+
+// ApplyChange records a game's rating change and makes it the player's
+// current rating. A change already recorded for the game is ignored.
+func (r *RatingRepository) ApplyChange(ctx context.Context, change *models.RatingChange) error {
+	query := `
+		WITH change AS (
+			INSERT INTO rating_history (user_id, game_id, season_id, rating_before, rating_after, placement)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (user_id, game_id) DO NOTHING
+			RETURNING user_id, rating_after
+		)
+		INSERT INTO player_ratings (user_id, rating, peak_rating, games_rated)
+		SELECT user_id, rating_after, rating_after, 1 FROM change
+		ON CONFLICT (user_id) DO UPDATE SET
+			rating = EXCLUDED.rating,
+			peak_rating = GREATEST(player_ratings.peak_rating, EXCLUDED.rating),
+			games_rated = player_ratings.games_rated + 1,
+			updated_at = CURRENT_TIMESTAMP
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		change.UserID,
+		change.GameID,
+		change.SeasonID,
+		change.RatingBefore,
+		change.RatingAfter,
+		change.Placement,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to apply rating change: %w", err)
+	}
+
+	return nil
+}
+
+// GetHistory retrieves a player's most recent rating changes, newest first
+func (r *RatingRepository) GetHistory(ctx context.Context, userID int64, limit int) ([]*models.RatingChange, error) {
+	query := `
+		SELECT id, user_id, game_id, season_id, rating_before, rating_after, placement, created_at
+		FROM rating_history
+		WHERE user_id = $1
+		ORDER BY id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating history: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []*models.RatingChange{}
+	for rows.Next() {
+		change := &models.RatingChange{}
+		err := rows.Scan(
+			&change.ID,
+			&change.UserID,
+			&change.GameID,
+			&change.SeasonID,
+			&change.RatingBefore,
+			&change.RatingAfter,
+			&change.Placement,
+			&change.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rating change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// SoftReset pulls every rating toward initial, keeping factor of each
+// player's distance from it
+func (r *RatingRepository) SoftReset(ctx context.Context, initial, factor float64) error {
+	query := `
+		UPDATE player_ratings
+		SET rating = $1 + (rating - $1) * $2, updated_at = CURRENT_TIMESTAMP
+	`
+
+	_, err := r.db.Exec(ctx, query, initial, factor)
+	if err != nil {
+		return fmt.Errorf("failed to reset ratings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type ResultRepository struct {
+	db *database.DB
+}
+
+func NewResultRepository(db *database.DB) *ResultRepository {
+	return &ResultRepository{db: db}
+}
+
+// Create stores a player's final standing. A standing already stored for
+// the player is kept.
+func (r *ResultRepository) Create(ctx context.Context, result *models.GameResult) error {
+	cardsByGem, err := json.Marshal(result.CardsByGem)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cards by gem: %w", err)
+	}
+
+	query := `
+		INSERT INTO game_results (
+			game_id, user_id, placement, victory_points,
+			cards_purchased, nobles_earned, moves, cards_by_gem
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (game_id, user_id) DO NOTHING
+		RETURNING id, created_at
+	`
+
+	err = r.db.QueryRow(ctx, query,
+		result.GameID,
+		result.UserID,
+		result.Placement,
+		result.VictoryPoints,
+		result.CardsPurchased,
+		result.NoblesEarned,
+		result.Moves,
+		cardsByGem,
+	).Scan(&result.ID, &result.CreatedAt)
+
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to create game result: %w", err)
+	}
+
+	return nil
+}
+
+// GetByGame retrieves a game's final standings, best first
+func (r *ResultRepository) GetByGame(ctx context.Context, gameID int64) ([]*models.GameResult, error) {
+	query := `
+		SELECT gr.id, gr.game_id, gr.user_id, u.username, u.is_bot,
+		       gr.placement, gr.victory_points, gr.cards_purchased,
+		       gr.nobles_earned, gr.moves, gr.cards_by_gem, gr.created_at
+		FROM game_results gr
+		JOIN users u ON u.id = gr.user_id
+		WHERE gr.game_id = $1
+		ORDER BY gr.placement, gr.id
+	`
+
+	rows, err := r.db.Query(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game results: %w", err)
+	}
+	defer rows.Close()
+
+	results := []*models.GameResult{}
+	for rows.Next() {
+		result := &models.GameResult{}
+		var cardsByGem []byte
+		err := rows.Scan(
+			&result.ID,
+			&result.GameID,
+			&result.UserID,
+			&result.Username,
+			&result.IsBot,
+			&result.Placement,
+			&result.VictoryPoints,
+			&result.CardsPurchased,
+			&result.NoblesEarned,
+			&result.Moves,
+			&cardsByGem,
+			&result.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan game result: %w", err)
+		}
+		if err := json.Unmarshal(cardsByGem, &result.CardsByGem); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal cards by gem: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// ListByUser retrieves a user's finished games, newest first, with the total
+// number matching the filter
+func (r *ResultRepository) ListByUser(ctx context.Context, userID int64, filter *models.MatchHistoryFilter) ([]*models.MatchHistoryEntry, int, error) {
+	where := []string{"gr.user_id = $1"}
+	args := []interface{}{userID}
+
+	if filter.NumPlayers > 0 {
+		args = append(args, filter.NumPlayers)
+		where = append(where, fmt.Sprintf("(SELECT COUNT(*) FROM game_results s WHERE s.game_id = g.id) = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		where = append(where, fmt.Sprintf("g.completed_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		where = append(where, fmt.Sprintf("g.completed_at < $%d", len(args)))
+	}
+	if filter.OpponentID != 0 {
+		args = append(args, filter.OpponentID)
+		where = append(where, fmt.Sprintf("EXISTS (SELECT 1 FROM game_results o WHERE o.game_id = g.id AND o.user_id = $%d)", len(args)))
+	}
+
+	from := `
+		FROM game_results gr
+		JOIN games g ON g.id = gr.game_id
+		WHERE ` + strings.Join(where, " AND ")
+
+	var total int
+	err := r.db.QueryRow(ctx, "SELECT COUNT(*)"+from, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count match history: %w", err)
+	}
+
+	query := `
+		SELECT g.id, g.room_code, g.ranked, g.winner_id, g.started_at, g.completed_at,
+		       (SELECT COUNT(*) FROM game_results s WHERE s.game_id = g.id),
+		       gr.placement, gr.victory_points, gr.cards_purchased,
+		       gr.nobles_earned, gr.moves` + from + fmt.Sprintf(`
+		ORDER BY g.completed_at DESC, g.id DESC
+		LIMIT $%d OFFSET $%d
+	`, len(args)+1, len(args)+2)
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list match history: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*models.MatchHistoryEntry{}
+	for rows.Next() {
+		entry := &models.MatchHistoryEntry{Opponents: []*models.MatchOpponent{}}
+		err := rows.Scan(
+			&entry.GameID,
+			&entry.RoomCode,
+			&entry.Ranked,
+			&entry.WinnerID,
+			&entry.StartedAt,
+			&entry.CompletedAt,
+			&entry.NumPlayers,
+			&entry.Placement,
+			&entry.VictoryPoints,
+			&entry.CardsPurchased,
+			&entry.NoblesEarned,
+			&entry.Moves,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan match history entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, total, nil
+}
+
+// GetOpponents retrieves everyone but userID seated in the given games,
+// keyed by game ID
+func (r *ResultRepository) GetOpponents(ctx context.Context, gameIDs []int64, userID int64) (map[int64][]*models.MatchOpponent, error) {
+	query := `
+		SELECT gr.game_id, gr.user_id, u.username, u.is_bot, gr.placement, gr.victory_points
+		FROM game_results gr
+		JOIN users u ON u.id = gr.user_id
+		WHERE gr.game_id = ANY($1) AND gr.user_id <> $2
+		ORDER BY gr.game_id, gr.placement
+	`
+
+	rows, err := r.db.Query(ctx, query, gameIDs, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get opponents: %w", err)
+	}
+	defer rows.Close()
+
+	opponents := make(map[int64][]*models.MatchOpponent)
+	for rows.Next() {
+		var gameID int64
+		opponent := &models.MatchOpponent{}
+		err := rows.Scan(
+			&gameID,
+			&opponent.UserID,
+			&opponent.Username,
+			&opponent.IsBot,
+			&opponent.Placement,
+			&opponent.VictoryPoints,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan opponent: %w", err)
+		}
+		opponents[gameID] = append(opponents[gameID], opponent)
+	}
+
+	return opponents, nil
+}
+
+// headToHeadColumns aggregates pairs of results a (the player) and b (the
+// opponent) from the same game
+const headToHeadColumns = `
+	COUNT(*),
+	COUNT(*) FILTER (WHERE a.placement < b.placement),
+	COUNT(*) FILTER (WHERE a.placement > b.placement),
+	COUNT(*) FILTER (WHERE a.placement = 1),
+	COUNT(*) FILTER (WHERE b.placement = 1),
+	COALESCE(AVG(a.victory_points - b.victory_points), 0)::float8,
+	MAX(g.completed_at)
+`
+
+// GetHeadToHead aggregates the results of the games userID and opponentID
+// both played
+func (r *ResultRepository) GetHeadToHead(ctx context.Context, userID, opponentID int64) (*models.HeadToHead, error) {
+	query := `
+		SELECT ` + headToHeadColumns + `
+		FROM game_results a
+		JOIN game_results b ON b.game_id = a.game_id
+		JOIN games g ON g.id = a.game_id
+		WHERE a.user_id = $1 AND b.user_id = $2
+	`
+
+	h := &models.HeadToHead{UserID: userID, OpponentID: opponentID}
+	err := r.db.QueryRow(ctx, query, userID, opponentID).Scan(
+		&h.Games,
+		&h.Ahead,
+		&h.Behind,
+		&h.Wins,
+		&h.OpponentWins,
+		&h.AverageMargin,
+		&h.LastPlayedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head-to-head record: %w", err)
+	}
+
+	return h, nil
+}
+
+// GetRivals retrieves userID's head-to-head records against the human
+// opponents they played most, most games first
+func (r *ResultRepository) GetRivals(ctx context.Context, userID int64, limit int) ([]*models.HeadToHead, error) {
+	query := `
+		SELECT b.user_id, u.username, ` + headToHeadColumns + `
+		FROM game_results a
+		JOIN game_results b ON b.game_id = a.game_id AND b.user_id <> a.user_id
+		JOIN games g ON g.id = a.game_id
+		JOIN users u ON u.id = b.user_id
+		WHERE a.user_id = $1 AND NOT u.is_bot
+		GROUP BY b.user_id, u.username
+		ORDER BY COUNT(*) DESC, MAX(g.completed_at) DESC, b.user_id
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rivals: %w", err)
+	}
+	defer rows.Close()
+
+	rivals := []*models.HeadToHead{}
+	for rows.Next() {
+		h := &models.HeadToHead{UserID: userID}
+		err := rows.Scan(
+			&h.OpponentID,
+			&h.OpponentUsername,
+			&h.Games,
+			&h.Ahead,
+			&h.Behind,
+			&h.Wins,
+			&h.OpponentWins,
+			&h.AverageMargin,
+			&h.LastPlayedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rival: %w", err)
+		}
+		rivals = append(rivals, h)
+	}
+
+	return rivals, nil
+}
+
+// CountRankedWins counts the ranked games a player won
+func (r *ResultRepository) CountRankedWins(ctx context.Context, userID int64) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM game_results gr
+		JOIN games g ON g.id = gr.game_id
+		WHERE gr.user_id = $1 AND gr.placement = 1 AND g.ranked
+	`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count ranked wins: %w", err)
+	}
+
+	return count, nil
+}
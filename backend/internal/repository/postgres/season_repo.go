@@ -0,0 +1,226 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrSeasonActive is returned when starting a season while another runs
+var ErrSeasonActive = errors.New("a season is already running")
+
+type SeasonRepository struct {
+	db *database.DB
+}
+
+func NewSeasonRepository(db *database.DB) *SeasonRepository {
+	return &SeasonRepository{db: db}
+}
+
+// Create starts a new season
+func (r *SeasonRepository) Create(ctx context.Context, season *models.Season) error {
+	query := `
+		INSERT INTO seasons (name)
+		VALUES ($1)
+		RETURNING id, started_at
+	`
+
+	err := r.db.QueryRow(ctx, query, season.Name).Scan(&season.ID, &season.StartedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrSeasonActive
+		}
+		return fmt.Errorf("failed to create season: %w", err)
+	}
+
+	return nil
+}
+
+// GetActive retrieves the running season, or nil if there is none
+func (r *SeasonRepository) GetActive(ctx context.Context) (*models.Season, error) {
+	query := `
+		SELECT id, name, started_at, ended_at
+		FROM seasons
+		WHERE ended_at IS NULL
+	`
+
+	season := &models.Season{}
+	err := r.db.QueryRow(ctx, query).Scan(&season.ID, &season.Name, &season.StartedAt, &season.EndedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get active season: %w", err)
+	}
+
+	return season, nil
+}
+
+// GetByID retrieves a season
+func (r *SeasonRepository) GetByID(ctx context.Context, id int64) (*models.Season, error) {
+	query := `
+		SELECT id, name, started_at, ended_at
+		FROM seasons
+		WHERE id = $1
+	`
+
+	season := &models.Season{}
+	err := r.db.QueryRow(ctx, query, id).Scan(&season.ID, &season.Name, &season.StartedAt, &season.EndedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("season not found")
+		}
+		return nil, fmt.Errorf("failed to get season: %w", err)
+	}
+
+	return season, nil
+}
+
+// List retrieves all seasons, newest first
+func (r *SeasonRepository) List(ctx context.Context) ([]*models.Season, error) {
+	query := `
+		SELECT id, name, started_at, ended_at
+		FROM seasons
+		ORDER BY id DESC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list seasons: %w", err)
+	}
+	defer rows.Close()
+
+	seasons := []*models.Season{}
+	for rows.Next() {
+		season := &models.Season{}
+		if err := rows.Scan(&season.ID, &season.Name, &season.StartedAt, &season.EndedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan season: %w", err)
+		}
+		seasons = append(seasons, season)
+	}
+
+	return seasons, nil
+}
+
+// End closes a running season. It reports false if the season had already
+// ended.
+func (r *SeasonRepository) End(ctx context.Context, season *models.Season) (bool, error) {
+	query := `
+		UPDATE seasons SET ended_at = NOW()
+		WHERE id = $1 AND ended_at IS NULL
+		RETURNING ended_at
+	`
+
+	err := r.db.QueryRow(ctx, query, season.ID).Scan(&season.EndedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to end season: %w", err)
+	}
+
+	return true, nil
+}
+
+// seasonStandingsQuery ranks the human players with at least $2 rated games
+// in season $1 by their current rating
+const seasonStandingsQuery = `
+	SELECT ROW_NUMBER() OVER (ORDER BY pr.rating DESC, COUNT(*) DESC, pr.user_id) AS rank,
+	       pr.user_id, u.username, pr.rating, COUNT(*) AS games_rated,
+	       COUNT(*) FILTER (WHERE rh.placement = 1) AS wins,
+	       COALESCE(AVG(gr.victory_points), 0)::DOUBLE PRECISION AS average_points
+	FROM rating_history rh
+	JOIN player_ratings pr ON pr.user_id = rh.user_id
+	JOIN users u ON u.id = rh.user_id
+	LEFT JOIN game_results gr ON gr.game_id = rh.game_id AND gr.user_id = rh.user_id
+	WHERE rh.season_id = $1 AND u.is_bot = false
+	GROUP BY pr.user_id, u.username, pr.rating
+	HAVING COUNT(*) >= $2
+`
+
+// GetStandings retrieves the live standings of a running season
+func (r *SeasonRepository) GetStandings(ctx context.Context, seasonID int64, minGames, limit, offset int) ([]*models.LeaderboardEntry, error) {
+	query := seasonStandingsQuery + `
+		ORDER BY rank
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Query(ctx, query, seasonID, minGames, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query season standings: %w", err)
+	}
+
+	return scanSeasonStandings(rows)
+}
+
+// ArchiveStandings stores the final standings of a season
+func (r *SeasonRepository) ArchiveStandings(ctx context.Context, seasonID int64, minGames int) error {
+	query := `
+		INSERT INTO season_standings (season_id, rank, user_id, rating, games_rated, wins, average_points)
+		SELECT $1, s.rank, s.user_id, s.rating, s.games_rated, s.wins, s.average_points
+		FROM (` + seasonStandingsQuery + `) s
+		ON CONFLICT (season_id, user_id) DO NOTHING
+	`
+
+	_, err := r.db.Exec(ctx, query, seasonID, minGames)
+	if err != nil {
+		return fmt.Errorf("failed to archive season standings: %w", err)
+	}
+
+	return nil
+}
+
+// GetArchivedStandings retrieves the final standings of an ended season
+func (r *SeasonRepository) GetArchivedStandings(ctx context.Context, seasonID int64, limit, offset int) ([]*models.LeaderboardEntry, error) {
+	query := `
+		SELECT ss.rank, ss.user_id, u.username, ss.rating, ss.games_rated,
+		       ss.wins, ss.average_points
+		FROM season_standings ss
+		JOIN users u ON u.id = ss.user_id
+		WHERE ss.season_id = $1
+		ORDER BY ss.rank
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, seasonID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archived standings: %w", err)
+	}
+
+	return scanSeasonStandings(rows)
+}
+
+func scanSeasonStandings(rows pgx.Rows) ([]*models.LeaderboardEntry, error) {
+	defer rows.Close()
+
+	entries := []*models.LeaderboardEntry{}
+	for rows.Next() {
+		entry := &models.LeaderboardEntry{}
+		err := rows.Scan(
+			&entry.Rank,
+			&entry.UserID,
+			&entry.Username,
+			&entry.Rating,
+			&entry.GamesRated,
+			&entry.TotalWins,
+			&entry.AveragePoints,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan standing: %w", err)
+		}
+		entry.TotalGames = entry.GamesRated
+		if entry.TotalGames > 0 {
+			entry.WinRate = float64(entry.TotalWins) / float64(entry.TotalGames)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
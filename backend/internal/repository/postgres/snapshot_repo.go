@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type SnapshotRepository struct {
+	db *database.DB
+}
+
+func NewSnapshotRepository(db *database.DB) *SnapshotRepository {
+	return &SnapshotRepository{db: db}
+}
+
+// Create stores a game snapshot
+func (r *SnapshotRepository) Create(ctx context.Context, snapshot *models.GameSnapshot) error {
+	query := `
+		INSERT INTO game_snapshots (game_id, turn_number, reason, state)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query, snapshot.GameID, snapshot.TurnNumber, snapshot.Reason, snapshot.State).
+		Scan(&snapshot.ID, &snapshot.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a snapshot including its state
+func (r *SnapshotRepository) GetByID(ctx context.Context, id int64) (*models.GameSnapshot, error) {
+	query := `
+		SELECT id, game_id, turn_number, reason, state, created_at
+		FROM game_snapshots
+		WHERE id = $1
+	`
+
+	snapshot := &models.GameSnapshot{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&snapshot.ID,
+		&snapshot.GameID,
+		&snapshot.TurnNumber,
+		&snapshot.Reason,
+		&snapshot.State,
+		&snapshot.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("snapshot not found")
+		}
+		return nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// ListByGame lists a game's snapshots, newest first, without their state
+func (r *SnapshotRepository) ListByGame(ctx context.Context, gameID int64) ([]*models.GameSnapshot, error) {
+	query := `
+		SELECT id, game_id, turn_number, reason, created_at
+		FROM game_snapshots
+		WHERE game_id = $1
+		ORDER BY id DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []*models.GameSnapshot{}
+	for rows.Next() {
+		snapshot := &models.GameSnapshot{}
+		err := rows.Scan(
+			&snapshot.ID,
+			&snapshot.GameID,
+			&snapshot.TurnNumber,
+			&snapshot.Reason,
+			&snapshot.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan snapshot: %w", err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
@@ -169,6 +169,7 @@ func (r *StateRepository) UpdateGameState(ctx context.Context, state *models.Gam
 func (r *StateRepository) CreatePlayerState(ctx context.Context, state *models.PlayerState) error {
 	purchasedCardsJSON, _ := json.Marshal(state.PurchasedCards)
 	reservedCardsJSON, _ := json.Marshal(state.ReservedCards)
+	blindReservedJSON, _ := json.Marshal(blindReservedOrEmpty(state.BlindReserved))
 	noblesJSON, _ := json.Marshal(state.Nobles)
 
 	query := `
@@ -176,8 +177,8 @@ func (r *StateRepository) CreatePlayerState(ctx context.Context, state *models.P
 			game_player_id,
 			gems_diamond, gems_sapphire, gems_emerald, gems_ruby, gems_onyx, gems_gold,
 			permanent_diamond, permanent_sapphire, permanent_emerald, permanent_ruby, permanent_onyx,
-			purchased_cards, reserved_cards, nobles
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			purchased_cards, reserved_cards, nobles, blind_reserved
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 		RETURNING id, updated_at
 	`
 
@@ -197,6 +198,7 @@ func (r *StateRepository) CreatePlayerState(ctx context.Context, state *models.P
 		purchasedCardsJSON,
 		reservedCardsJSON,
 		noblesJSON,
+		blindReservedJSON,
 	).Scan(&state.ID, &state.UpdatedAt)
 
 	if err != nil {
@@ -212,7 +214,7 @@ func (r *StateRepository) GetPlayerState(ctx context.Context, gamePlayerID int64
 		SELECT id, game_player_id,
 		       gems_diamond, gems_sapphire, gems_emerald, gems_ruby, gems_onyx, gems_gold,
 		       permanent_diamond, permanent_sapphire, permanent_emerald, permanent_ruby, permanent_onyx,
-		       purchased_cards, reserved_cards, nobles,
+		       purchased_cards, reserved_cards, nobles, blind_reserved,
 		       updated_at
 		FROM player_state
 		WHERE game_player_id = $1
@@ -222,7 +224,7 @@ func (r *StateRepository) GetPlayerState(ctx context.Context, gamePlayerID int64
 		Gems:          make(map[string]int),
 		PermanentGems: make(map[string]int),
 	}
-	var purchasedCardsJSON, reservedCardsJSON, noblesJSON []byte
+	var purchasedCardsJSON, reservedCardsJSON, noblesJSON, blindReservedJSON []byte
 	var diamond, sapphire, emerald, ruby, onyx, gold int
 	var permDiamond, permSapphire, permEmerald, permRuby, permOnyx int
 
@@ -243,6 +245,7 @@ func (r *StateRepository) GetPlayerState(ctx context.Context, gamePlayerID int64
 		&purchasedCardsJSON,
 		&reservedCardsJSON,
 		&noblesJSON,
+		&blindReservedJSON,
 		&state.UpdatedAt,
 	)
 	if err != nil {
@@ -267,6 +270,7 @@ func (r *StateRepository) GetPlayerState(ctx context.Context, gamePlayerID int64
 	json.Unmarshal(purchasedCardsJSON, &state.PurchasedCards)
 	json.Unmarshal(reservedCardsJSON, &state.ReservedCards)
 	json.Unmarshal(noblesJSON, &state.Nobles)
+	json.Unmarshal(blindReservedJSON, &state.BlindReserved)
 
 	return state, nil
 }
@@ -275,6 +279,7 @@ func (r *StateRepository) GetPlayerState(ctx context.Context, gamePlayerID int64
 func (r *StateRepository) UpdatePlayerState(ctx context.Context, state *models.PlayerState) error {
 	purchasedCardsJSON, _ := json.Marshal(state.PurchasedCards)
 	reservedCardsJSON, _ := json.Marshal(state.ReservedCards)
+	blindReservedJSON, _ := json.Marshal(blindReservedOrEmpty(state.BlindReserved))
 	noblesJSON, _ := json.Marshal(state.Nobles)
 
 	query := `
@@ -283,8 +288,9 @@ func (r *StateRepository) UpdatePlayerState(ctx context.Context, state *models.P
 		    gems_ruby = $4, gems_onyx = $5, gems_gold = $6,
 		    permanent_diamond = $7, permanent_sapphire = $8, permanent_emerald = $9,
 		    permanent_ruby = $10, permanent_onyx = $11,
-		    purchased_cards = $12, reserved_cards = $13, nobles = $14
-		WHERE game_player_id = $15
+		    purchased_cards = $12, reserved_cards = $13, nobles = $14,
+		    blind_reserved = $15
+		WHERE game_player_id = $16
 	`
 
 	_, err := r.db.Exec(ctx, query,
@@ -302,6 +308,7 @@ func (r *StateRepository) UpdatePlayerState(ctx context.Context, state *models.P
 		purchasedCardsJSON,
 		reservedCardsJSON,
 		noblesJSON,
+		blindReservedJSON,
 		state.GamePlayerID,
 	)
 
@@ -311,3 +318,11 @@ func (r *StateRepository) UpdatePlayerState(ctx context.Context, state *models.P
 
 	return nil
 }
+
+// blindReservedOrEmpty avoids storing null for players with no blind reserves
+func blindReservedOrEmpty(ids []int64) []int64 {
+	if ids == nil {
+		return []int64{}
+	}
+	return ids
+}
@@ -2,10 +2,13 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"splendor-backend/internal/domain/models"
 	"splendor-backend/pkg/database"
+
+	"github.com/jackc/pgx/v5"
 )
 
 type StatsRepository struct {
@@ -20,7 +23,7 @@ func NewStatsRepository(db *database.DB) *StatsRepository {
 func (r *StatsRepository) GetUserStats(ctx context.Context, userID int64) (*models.GameStatistics, error) {
 	query := `
 		SELECT id, user_id, total_games, total_wins, total_losses,
-		       average_points, average_moves_per_game, favorite_gem_type,
+		       average_points, average_moves_per_game, COALESCE(favorite_gem_type, ''),
 		       total_nobles_earned, total_cards_purchased, updated_at
 		FROM game_statistics
 		WHERE user_id = $1
@@ -51,19 +54,23 @@ func (r *StatsRepository) GetUserStats(ctx context.Context, userID int64) (*mode
 	return stats, nil
 }
 
-// GetLeaderboard retrieves the leaderboard
-func (r *StatsRepository) GetLeaderboard(ctx context.Context, limit, offset int) ([]*models.LeaderboardEntry, error) {
+// GetLeaderboard retrieves the leaderboard, ordered by rating. Only players
+// with at least minGames rated games are listed.
+func (r *StatsRepository) GetLeaderboard(ctx context.Context, minGames, limit, offset int) ([]*models.LeaderboardEntry, error) {
 	query := `
-		SELECT gs.user_id, u.username, gs.total_games, gs.total_wins,
-		       gs.average_points, CAST(gs.total_wins AS FLOAT) / NULLIF(gs.total_games, 0) as win_rate
-		FROM game_statistics gs
-		JOIN users u ON u.id = gs.user_id
-		WHERE gs.total_games > 0
-		ORDER BY win_rate DESC, gs.total_wins DESC
-		LIMIT $1 OFFSET $2
+		SELECT pr.user_id, u.username, pr.rating, pr.games_rated,
+		       COALESCE(gs.total_games, 0), COALESCE(gs.total_wins, 0),
+		       COALESCE(gs.average_points, 0),
+		       COALESCE(CAST(gs.total_wins AS FLOAT) / NULLIF(gs.total_games, 0), 0) as win_rate
+		FROM player_ratings pr
+		JOIN users u ON u.id = pr.user_id
+		LEFT JOIN game_statistics gs ON gs.user_id = pr.user_id
+		WHERE pr.games_rated >= $1 AND u.is_bot = false
+		ORDER BY pr.rating DESC, pr.games_rated DESC
+		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.Query(ctx, query, limit, offset)
+	rows, err := r.db.Query(ctx, query, minGames, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query leaderboard: %w", err)
 	}
@@ -75,6 +82,8 @@ func (r *StatsRepository) GetLeaderboard(ctx context.Context, limit, offset int)
 		err := rows.Scan(
 			&entry.UserID,
 			&entry.Username,
+			&entry.Rating,
+			&entry.GamesRated,
 			&entry.TotalGames,
 			&entry.TotalWins,
 			&entry.AveragePoints,
@@ -96,14 +105,14 @@ func (r *StatsRepository) UpdateStats(ctx context.Context, stats *models.GameSta
 			user_id, total_games, total_wins, total_losses,
 			average_points, average_moves_per_game, favorite_gem_type,
 			total_nobles_earned, total_cards_purchased
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9)
 		ON CONFLICT (user_id) DO UPDATE SET
 			total_games = $2,
 			total_wins = $3,
 			total_losses = $4,
 			average_points = $5,
 			average_moves_per_game = $6,
-			favorite_gem_type = $7,
+			favorite_gem_type = NULLIF($7, ''),
 			total_nobles_earned = $8,
 			total_cards_purchased = $9
 		RETURNING id, updated_at
@@ -127,3 +136,186 @@ func (r *StatsRepository) UpdateStats(ctx context.Context, stats *models.GameSta
 
 	return nil
 }
+
+// GetFavoriteGemType returns the gem type of the most cards a user bought
+// across their finished games, or "" if they never bought one
+func (r *StatsRepository) GetFavoriteGemType(ctx context.Context, userID int64) (string, error) {
+	query := `
+		SELECT gem.key
+		FROM game_results gr, jsonb_each_text(gr.cards_by_gem) AS gem
+		WHERE gr.user_id = $1
+		GROUP BY gem.key
+		HAVING SUM(gem.value::int) > 0
+		ORDER BY SUM(gem.value::int) DESC, gem.key
+		LIMIT 1
+	`
+
+	var gemType string
+	err := r.db.QueryRow(ctx, query, userID).Scan(&gemType)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get favorite gem type: %w", err)
+	}
+
+	return gemType, nil
+}
+
+// finishedSeatsQuery selects each seat of a finished game with its final
+// cards and nobles, whether it won and how many seats the game had
+const finishedSeatsQuery = `
+	SELECT gp.player_position, ps.purchased_cards, ps.nobles,
+	       COALESCE(gp.user_id = g.winner_id, false) AS won,
+	       COUNT(*) OVER () AS seats
+	FROM game_players gp
+	JOIN games g ON g.id = gp.game_id
+	JOIN player_state ps ON ps.game_player_id = gp.id
+	WHERE gp.game_id = $1
+`
+
+// AddGameBalance adds a finished game to the card, noble and seat
+// statistics. It must be called once per game.
+func (r *StatsRepository) AddGameBalance(ctx context.Context, gameID int64) error {
+	cardsQuery := `
+		WITH finished_seats AS (` + finishedSeatsQuery + `)
+		INSERT INTO card_stats (card_id, purchases, winner_purchases, expected_wins)
+		SELECT (card->>'id')::BIGINT, COUNT(*), COUNT(*) FILTER (WHERE won), SUM(1.0 / seats)
+		FROM finished_seats, jsonb_array_elements(purchased_cards) AS card
+		GROUP BY 1
+		ON CONFLICT (card_id) DO UPDATE SET
+			purchases = card_stats.purchases + EXCLUDED.purchases,
+			winner_purchases = card_stats.winner_purchases + EXCLUDED.winner_purchases,
+			expected_wins = card_stats.expected_wins + EXCLUDED.expected_wins,
+			updated_at = NOW()
+	`
+	if _, err := r.db.Exec(ctx, cardsQuery, gameID); err != nil {
+		return fmt.Errorf("failed to update card stats: %w", err)
+	}
+
+	noblesQuery := `
+		WITH finished_seats AS (` + finishedSeatsQuery + `)
+		INSERT INTO noble_stats (noble_id, claims, winner_claims)
+		SELECT (noble->>'id')::BIGINT, COUNT(*), COUNT(*) FILTER (WHERE won)
+		FROM finished_seats, jsonb_array_elements(nobles) AS noble
+		GROUP BY 1
+		ON CONFLICT (noble_id) DO UPDATE SET
+			claims = noble_stats.claims + EXCLUDED.claims,
+			winner_claims = noble_stats.winner_claims + EXCLUDED.winner_claims,
+			updated_at = NOW()
+	`
+	if _, err := r.db.Exec(ctx, noblesQuery, gameID); err != nil {
+		return fmt.Errorf("failed to update noble stats: %w", err)
+	}
+
+	seatsQuery := `
+		WITH finished_seats AS (` + finishedSeatsQuery + `)
+		INSERT INTO seat_stats (num_players, seat_position, games, wins)
+		SELECT seats, player_position, COUNT(*), COUNT(*) FILTER (WHERE won)
+		FROM finished_seats
+		GROUP BY 1, 2
+		ON CONFLICT (num_players, seat_position) DO UPDATE SET
+			games = seat_stats.games + EXCLUDED.games,
+			wins = seat_stats.wins + EXCLUDED.wins
+	`
+	if _, err := r.db.Exec(ctx, seatsQuery, gameID); err != nil {
+		return fmt.Errorf("failed to update seat stats: %w", err)
+	}
+
+	return nil
+}
+
+// GetCardStats retrieves the purchase totals of every development card,
+// including cards never bought
+func (r *StatsRepository) GetCardStats(ctx context.Context) ([]*models.CardStat, error) {
+	query := `
+		SELECT dc.id, dc.tier, dc.gem_type, dc.victory_points,
+		       COALESCE(cs.purchases, 0), COALESCE(cs.winner_purchases, 0),
+		       COALESCE(cs.expected_wins, 0)
+		FROM development_cards dc
+		LEFT JOIN card_stats cs ON cs.card_id = dc.id
+		ORDER BY dc.tier, dc.id
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card stats: %w", err)
+	}
+	defer rows.Close()
+
+	cards := []*models.CardStat{}
+	for rows.Next() {
+		card := &models.CardStat{}
+		err := rows.Scan(
+			&card.CardID,
+			&card.Tier,
+			&card.GemType,
+			&card.VictoryPoints,
+			&card.Purchases,
+			&card.WinnerPurchases,
+			&card.ExpectedWins,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan card stat: %w", err)
+		}
+		cards = append(cards, card)
+	}
+
+	return cards, nil
+}
+
+// GetNobleStats retrieves the claim totals of every noble, including
+// nobles never claimed
+func (r *StatsRepository) GetNobleStats(ctx context.Context) ([]*models.NobleStat, error) {
+	query := `
+		SELECT n.id, n.name, n.victory_points,
+		       COALESCE(ns.claims, 0), COALESCE(ns.winner_claims, 0)
+		FROM nobles n
+		LEFT JOIN noble_stats ns ON ns.noble_id = n.id
+		ORDER BY n.id
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get noble stats: %w", err)
+	}
+	defer rows.Close()
+
+	nobles := []*models.NobleStat{}
+	for rows.Next() {
+		noble := &models.NobleStat{}
+		err := rows.Scan(&noble.NobleID, &noble.Name, &noble.VictoryPoints, &noble.Claims, &noble.WinnerClaims)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan noble stat: %w", err)
+		}
+		nobles = append(nobles, noble)
+	}
+
+	return nobles, nil
+}
+
+// GetSeatStats retrieves the win totals of each seat by player count
+func (r *StatsRepository) GetSeatStats(ctx context.Context) ([]*models.SeatStat, error) {
+	query := `
+		SELECT num_players, seat_position, games, wins
+		FROM seat_stats
+		ORDER BY num_players, seat_position
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seat stats: %w", err)
+	}
+	defer rows.Close()
+
+	seats := []*models.SeatStat{}
+	for rows.Next() {
+		seat := &models.SeatStat{}
+		if err := rows.Scan(&seat.NumPlayers, &seat.SeatPosition, &seat.Games, &seat.Wins); err != nil {
+			return nil, fmt.Errorf("failed to scan seat stat: %w", err)
+		}
+		seats = append(seats, seat)
+	}
+
+	return seats, nil
+}
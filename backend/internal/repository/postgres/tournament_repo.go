@@ -0,0 +1,441 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/pkg/database"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type TournamentRepository struct {
+	db *database.DB
+}
+
+func NewTournamentRepository(db *database.DB) *TournamentRepository {
+	return &TournamentRepository{db: db}
+}
+
+const tournamentColumns = `
+	id, name, format, table_size, rounds, current_round, status, ranked,
+	winner_id, created_by, created_at, started_at, completed_at
+`
+
+func scanTournament(row pgx.Row) (*models.Tournament, error) {
+	t := &models.Tournament{}
+	err := row.Scan(
+		&t.ID,
+		&t.Name,
+		&t.Format,
+		&t.TableSize,
+		&t.Rounds,
+		&t.CurrentRound,
+		&t.Status,
+		&t.Ranked,
+		&t.WinnerID,
+		&t.CreatedBy,
+		&t.CreatedAt,
+		&t.StartedAt,
+		&t.CompletedAt,
+	)
+	return t, err
+}
+
+// Create creates a tournament open for registration
+func (r *TournamentRepository) Create(ctx context.Context, t *models.Tournament) error {
+	query := `
+		INSERT INTO tournaments (name, format, table_size, rounds, ranked, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, status, created_at
+	`
+
+	err := r.db.QueryRow(ctx, query, t.Name, t.Format, t.TableSize, t.Rounds, t.Ranked, t.CreatedBy).
+		Scan(&t.ID, &t.Status, &t.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create tournament: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a tournament
+func (r *TournamentRepository) GetByID(ctx context.Context, id int64) (*models.Tournament, error) {
+	query := `SELECT ` + tournamentColumns + ` FROM tournaments WHERE id = $1`
+
+	t, err := scanTournament(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("tournament not found")
+		}
+		return nil, fmt.Errorf("failed to get tournament: %w", err)
+	}
+
+	return t, nil
+}
+
+// List retrieves tournaments, newest first, optionally by status
+func (r *TournamentRepository) List(ctx context.Context, status string, limit, offset int) ([]*models.Tournament, error) {
+	query := `
+		SELECT ` + tournamentColumns + `
+		FROM tournaments
+		WHERE $1 = '' OR status = $1
+		ORDER BY id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tournaments: %w", err)
+	}
+	defer rows.Close()
+
+	tournaments := []*models.Tournament{}
+	for rows.Next() {
+		t, err := scanTournament(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tournament: %w", err)
+		}
+		tournaments = append(tournaments, t)
+	}
+
+	return tournaments, nil
+}
+
+// Start moves a tournament from registration to its first round. It reports
+// false if the tournament had already started.
+func (r *TournamentRepository) Start(ctx context.Context, t *models.Tournament) (bool, error) {
+	query := `
+		UPDATE tournaments
+		SET status = 'in_progress', current_round = 1, started_at = NOW()
+		WHERE id = $1 AND status = 'registration'
+		RETURNING status, current_round, started_at
+	`
+
+	err := r.db.QueryRow(ctx, query, t.ID).Scan(&t.Status, &t.CurrentRound, &t.StartedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to start tournament: %w", err)
+	}
+
+	return true, nil
+}
+
+// AdvanceRound moves a tournament from round to the next one. It reports
+// false if another caller already moved it on.
+func (r *TournamentRepository) AdvanceRound(ctx context.Context, tournamentID int64, round int) (bool, error) {
+	query := `
+		UPDATE tournaments SET current_round = current_round + 1
+		WHERE id = $1 AND current_round = $2 AND status = 'in_progress'
+	`
+
+	tag, err := r.db.Exec(ctx, query, tournamentID, round)
+	if err != nil {
+		return false, fmt.Errorf("failed to advance round: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// Complete ends a tournament after its last round. It reports false if
+// another caller already ended it.
+func (r *TournamentRepository) Complete(ctx context.Context, tournamentID int64, round int, winnerID *int64) (bool, error) {
+	query := `
+		UPDATE tournaments
+		SET status = 'completed', winner_id = $3, completed_at = NOW()
+		WHERE id = $1 AND current_round = $2 AND status = 'in_progress'
+	`
+
+	tag, err := r.db.Exec(ctx, query, tournamentID, round, winnerID)
+	if err != nil {
+		return false, fmt.Errorf("failed to complete tournament: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// AddPlayer registers a player. It reports false if they were registered
+// already.
+func (r *TournamentRepository) AddPlayer(ctx context.Context, tournamentID, userID int64) (bool, error) {
+	query := `
+		INSERT INTO tournament_players (tournament_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (tournament_id, user_id) DO NOTHING
+	`
+
+	tag, err := r.db.Exec(ctx, query, tournamentID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to register player: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// RemovePlayer withdraws a player's registration. It reports false if they
+// were not registered.
+func (r *TournamentRepository) RemovePlayer(ctx context.Context, tournamentID, userID int64) (bool, error) {
+	query := `DELETE FROM tournament_players WHERE tournament_id = $1 AND user_id = $2`
+
+	tag, err := r.db.Exec(ctx, query, tournamentID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove player: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// GetPlayers retrieves a tournament's players, by seed once seeded
+func (r *TournamentRepository) GetPlayers(ctx context.Context, tournamentID int64) ([]*models.TournamentPlayer, error) {
+	query := `
+		SELECT tp.user_id, u.username, tp.seed, tp.registered_at
+		FROM tournament_players tp
+		JOIN users u ON u.id = tp.user_id
+		WHERE tp.tournament_id = $1
+		ORDER BY tp.seed, tp.registered_at
+	`
+
+	rows, err := r.db.Query(ctx, query, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tournament players: %w", err)
+	}
+	defer rows.Close()
+
+	players := []*models.TournamentPlayer{}
+	for rows.Next() {
+		player := &models.TournamentPlayer{}
+		if err := rows.Scan(&player.UserID, &player.Username, &player.Seed, &player.RegisteredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tournament player: %w", err)
+		}
+		players = append(players, player)
+	}
+
+	return players, nil
+}
+
+// SetSeed stores a player's seed
+func (r *TournamentRepository) SetSeed(ctx context.Context, tournamentID, userID int64, seed int) error {
+	query := `UPDATE tournament_players SET seed = $3 WHERE tournament_id = $1 AND user_id = $2`
+
+	_, err := r.db.Exec(ctx, query, tournamentID, userID, seed)
+	if err != nil {
+		return fmt.Errorf("failed to set seed: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimTable creates a table of a round. It reports false if the table was
+// already created, e.g. by a concurrent pass seating the same round.
+func (r *TournamentRepository) ClaimTable(ctx context.Context, table *models.TournamentTable) (bool, error) {
+	query := `
+		INSERT INTO tournament_tables (tournament_id, round, table_number, game_id, player_ids, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (tournament_id, round, table_number) DO NOTHING
+		RETURNING id
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		table.TournamentID,
+		table.Round,
+		table.TableNumber,
+		table.GameID,
+		table.PlayerIDs,
+		table.Status,
+	).Scan(&table.ID)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create table: %w", err)
+	}
+
+	return true, nil
+}
+
+// SeatTable records the game a claimed table plays
+func (r *TournamentRepository) SeatTable(ctx context.Context, tableID, gameID int64) error {
+	query := `UPDATE tournament_tables SET game_id = $2, status = 'playing' WHERE id = $1 AND status = 'seating'`
+
+	_, err := r.db.Exec(ctx, query, tableID, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to seat table: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseTable removes a claimed table whose game could not be created, so
+// it is seated again on the next pass
+func (r *TournamentRepository) ReleaseTable(ctx context.Context, tableID int64) error {
+	query := `DELETE FROM tournament_tables WHERE id = $1 AND status = 'seating'`
+
+	_, err := r.db.Exec(ctx, query, tableID)
+	if err != nil {
+		return fmt.Errorf("failed to release table: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseStaleTables removes tables claimed longer ago than olderThan that
+// never got a game, e.g. because the server stopped while seating them
+func (r *TournamentRepository) ReleaseStaleTables(ctx context.Context, olderThan time.Duration) error {
+	query := `
+		DELETE FROM tournament_tables
+		WHERE status = 'seating' AND created_at < NOW() - make_interval(secs => $1)
+	`
+
+	_, err := r.db.Exec(ctx, query, olderThan.Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to release stale tables: %w", err)
+	}
+
+	return nil
+}
+
+// GetTables retrieves all of a tournament's tables, by round
+func (r *TournamentRepository) GetTables(ctx context.Context, tournamentID int64) ([]*models.TournamentTable, error) {
+	query := `
+		SELECT id, tournament_id, round, table_number, game_id, player_ids, status
+		FROM tournament_tables
+		WHERE tournament_id = $1
+		ORDER BY round, table_number
+	`
+
+	rows, err := r.db.Query(ctx, query, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tables: %w", err)
+	}
+	defer rows.Close()
+
+	tables := []*models.TournamentTable{}
+	for rows.Next() {
+		table := &models.TournamentTable{}
+		err := rows.Scan(
+			&table.ID,
+			&table.TournamentID,
+			&table.Round,
+			&table.TableNumber,
+			&table.GameID,
+			&table.PlayerIDs,
+			&table.Status,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan table: %w", err)
+		}
+		tables = append(tables, table)
+	}
+
+	return tables, nil
+}
+
+// FinishTableByGame marks the table playing a game as finished and returns
+// it. It returns nil if the game isn't a tournament game or its table was
+// already finished.
+func (r *TournamentRepository) FinishTableByGame(ctx context.Context, gameID int64) (*models.TournamentTable, error) {
+	query := `
+		UPDATE tournament_tables SET status = 'finished'
+		WHERE game_id = $1 AND status = 'playing'
+		RETURNING id, tournament_id, round, table_number, game_id, player_ids, status
+	`
+
+	table := &models.TournamentTable{}
+	err := r.db.QueryRow(ctx, query, gameID).Scan(
+		&table.ID,
+		&table.TournamentID,
+		&table.Round,
+		&table.TableNumber,
+		&table.GameID,
+		&table.PlayerIDs,
+		&table.Status,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to finish table: %w", err)
+	}
+
+	return table, nil
+}
+
+// FinishCompletedTables marks tables as finished whose games have gone
+// through the game-over pipeline but were never reported to the tournament
+func (r *TournamentRepository) FinishCompletedTables(ctx context.Context) error {
+	query := `
+		UPDATE tournament_tables tt SET status = 'finished'
+		FROM games g
+		WHERE g.id = tt.game_id AND tt.status = 'playing' AND g.finalized_at IS NOT NULL
+	`
+
+	_, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to finish completed tables: %w", err)
+	}
+
+	return nil
+}
+
+// GetInProgressIDs returns the IDs of running tournaments
+func (r *TournamentRepository) GetInProgressIDs(ctx context.Context) ([]int64, error) {
+	query := `SELECT id FROM tournaments WHERE status = 'in_progress' ORDER BY id`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running tournaments: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan tournament ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// GetResults retrieves the seat results of a tournament's finished games
+func (r *TournamentRepository) GetResults(ctx context.Context, tournamentID int64) ([]*models.TournamentResult, error) {
+	query := `
+		SELECT tt.id, tt.round, gr.user_id, gr.placement, gr.victory_points
+		FROM tournament_tables tt
+		JOIN game_results gr ON gr.game_id = tt.game_id
+		WHERE tt.tournament_id = $1 AND tt.status = 'finished'
+		ORDER BY tt.round, tt.table_number, gr.placement
+	`
+
+	rows, err := r.db.Query(ctx, query, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tournament results: %w", err)
+	}
+	defer rows.Close()
+
+	results := []*models.TournamentResult{}
+	for rows.Next() {
+		result := &models.TournamentResult{}
+		err := rows.Scan(
+			&result.TableID,
+			&result.Round,
+			&result.UserID,
+			&result.Placement,
+			&result.VictoryPoints,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tournament result: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
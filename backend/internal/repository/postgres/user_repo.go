@@ -37,10 +37,71 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 	return nil
 }
 
+// CreateBot creates a bot account. Bots have no password and cannot log in.
+func (r *UserRepository) CreateBot(ctx context.Context, user *models.User) error {
+	query := `
+		INSERT INTO users (username, email, password_hash, is_bot, bot_owner_id)
+		VALUES ($1, $2, '', true, $3)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, user.Username, user.Email, user.BotOwnerID).
+		Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create bot: %w", err)
+	}
+
+	user.IsBot = true
+	return nil
+}
+
+// CreateGuest creates a guest account. Guests have no password and cannot
+// log in until they upgrade.
+func (r *UserRepository) CreateGuest(ctx context.Context, user *models.User) error {
+	query := `
+		INSERT INTO users (username, email, password_hash, is_guest)
+		VALUES ($1, $2, '', true)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, user.Username, user.Email).
+		Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create guest: %w", err)
+	}
+
+	user.IsGuest = true
+	return nil
+}
+
+// UpgradeGuest gives a guest account an email and password, making it a
+// registered account. It reports false if the user is not a guest.
+func (r *UserRepository) UpgradeGuest(ctx context.Context, user *models.User) (bool, error) {
+	query := `
+		UPDATE users
+		SET email = $2, password_hash = $3, is_guest = false, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND is_guest
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query, user.ID, user.Email, user.PasswordHash).Scan(&user.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to upgrade guest: %w", err)
+	}
+
+	user.IsGuest = false
+	return true, nil
+}
+
 // GetByID retrieves a user by ID
 func (r *UserRepository) GetByID(ctx context.Context, id int64) (*models.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, created_at, updated_at
+		SELECT id, username, email, password_hash, is_bot, is_guest, bot_owner_id, is_admin, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -51,6 +112,10 @@ func (r *UserRepository) GetByID(ctx context.Context, id int64) (*models.User, e
 		&user.Username,
 		&user.Email,
 		&user.PasswordHash,
+		&user.IsBot,
+		&user.IsGuest,
+		&user.BotOwnerID,
+		&user.IsAdmin,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
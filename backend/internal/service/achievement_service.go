@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"splendor-backend/internal/achievement"
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+	"splendor-backend/internal/repository/postgres"
+)
+
+// UnlockFunc is called with every achievement a player unlocks
+type UnlockFunc func(ctx context.Context, unlocked *models.UserAchievement)
+
+// AchievementService checks the registered achievements as games are played
+// and finished, and records what each player unlocked. Bots don't unlock
+// achievements.
+type AchievementService struct {
+	achievementRepo *postgres.AchievementRepository
+	userRepo        *postgres.UserRepository
+	moveRepo        *postgres.MoveRepository
+	resultRepo      *postgres.ResultRepository
+	onUnlock        UnlockFunc
+}
+
+func NewAchievementService(achievementRepo *postgres.AchievementRepository, userRepo *postgres.UserRepository, moveRepo *postgres.MoveRepository, resultRepo *postgres.ResultRepository) *AchievementService {
+	return &AchievementService{
+		achievementRepo: achievementRepo,
+		userRepo:        userRepo,
+		moveRepo:        moveRepo,
+		resultRepo:      resultRepo,
+	}
+}
+
+// OnUnlock sets the callback used to announce unlocked achievements
+func (s *AchievementService) OnUnlock(fn UnlockFunc) {
+	s.onUnlock = fn
+}
+
+// CheckMove checks the move achievements against a move that was just
+// played. Achievements are a side effect of play, so failures are logged.
+func (s *AchievementService) CheckMove(ctx context.Context, event *gamelogic.MoveEvent) {
+	var earned []*achievement.Definition
+	for _, def := range achievement.All() {
+		if def.OnMove != nil && def.OnMove(event) {
+			earned = append(earned, def)
+		}
+	}
+	if len(earned) == 0 {
+		return
+	}
+
+	user, err := s.userRepo.GetByID(ctx, event.UserID)
+	if err != nil {
+		log.Printf("Failed to check achievements of user %d: %v", event.UserID, err)
+		return
+	}
+	if user.IsBot {
+		return
+	}
+
+	for _, def := range earned {
+		if err := s.unlock(ctx, event.UserID, def, event.GameID); err != nil {
+			log.Printf("Failed to unlock %s for user %d: %v", def.Key, event.UserID, err)
+		}
+	}
+}
+
+// CheckGameEnd checks the game-end achievements for every human player of
+// a finished game. The game's results must already be stored.
+func (s *AchievementService) CheckGameEnd(ctx context.Context, game *models.Game, results []*models.GameResult) error {
+	moves, err := s.moveRepo.GetByGame(ctx, game.ID)
+	if err != nil {
+		return err
+	}
+
+	reserves := make(map[int64]int)
+	for _, move := range moves {
+		if move.MoveType == gamelogic.ActionReserveCard {
+			reserves[move.UserID]++
+		}
+	}
+
+	for _, result := range results {
+		if result.IsBot {
+			continue
+		}
+
+		rankedWins, err := s.resultRepo.CountRankedWins(ctx, result.UserID)
+		if err != nil {
+			return err
+		}
+
+		end := &achievement.GameEnd{
+			Game:       game,
+			Result:     result,
+			Reserves:   reserves[result.UserID],
+			RankedWins: rankedWins,
+		}
+		for _, def := range achievement.All() {
+			if def.OnGameEnd != nil && def.OnGameEnd(end) {
+				if err := s.unlock(ctx, result.UserID, def, game.ID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetUserAchievements lists every achievement with whether and when the
+// player unlocked it
+func (s *AchievementService) GetUserAchievements(ctx context.Context, userID int64) (*models.UserAchievementsResponse, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	unlocked, err := s.achievementRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]*models.UserAchievement, len(unlocked))
+	for _, a := range unlocked {
+		byKey[a.Key] = a
+	}
+
+	resp := &models.UserAchievementsResponse{Achievements: []*models.AchievementStatus{}}
+	for _, def := range achievement.All() {
+		status := &models.AchievementStatus{
+			Key:         def.Key,
+			Name:        def.Name,
+			Description: def.Description,
+		}
+		if a, ok := byKey[def.Key]; ok {
+			status.Unlocked = true
+			status.UnlockedAt = &a.UnlockedAt
+			status.GameID = a.GameID
+			resp.Unlocked++
+		}
+		resp.Achievements = append(resp.Achievements, status)
+	}
+	resp.Total = len(resp.Achievements)
+
+	return resp, nil
+}
+
+// ListAchievements returns every registered achievement
+func (s *AchievementService) ListAchievements() []*achievement.Definition {
+	return achievement.All()
+}
+
+// unlock records an achievement and announces it if it is new
+func (s *AchievementService) unlock(ctx context.Context, userID int64, def *achievement.Definition, gameID int64) error {
+	unlocked, err := s.achievementRepo.Unlock(ctx, userID, def.Key, &gameID)
+	if err != nil || unlocked == nil {
+		return err
+	}
+
+	if s.onUnlock != nil {
+		s.onUnlock(ctx, unlocked)
+	}
+	return nil
+}
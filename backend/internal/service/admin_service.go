@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+	"splendor-backend/internal/repository/postgres"
+	"splendor-backend/pkg/database"
+)
+
+var (
+	ErrNotAdmin         = errors.New("admin access required")
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+	ErrRestoreFinished  = errors.New("finished games cannot be restored")
+)
+
+// Audited admin actions
+const (
+	AuditSnapshotCreated  = "snapshot_created"
+	AuditSnapshotRestored = "snapshot_restored"
+	AuditSeasonStarted    = "season_started"
+	AuditSeasonEnded      = "season_ended"
+)
+
+// SnapshotEngine saves and restores full game snapshots
+type SnapshotEngine interface {
+	SaveSnapshot(ctx context.Context, gameID int64, reason string) (*models.GameSnapshot, error)
+	RestoreSnapshot(ctx context.Context, gameID, snapshotID int64) (*models.GameSnapshot, error)
+}
+
+// AdminService backs the admin endpoints. Every change it makes is written
+// to the audit log in the same transaction as the change itself.
+type AdminService struct {
+	db           *database.DB
+	userRepo     *postgres.UserRepository
+	gameRepo     *postgres.GameRepository
+	snapshotRepo *postgres.SnapshotRepository
+	auditRepo    *postgres.AuditRepository
+	engine       SnapshotEngine
+	seasons      *SeasonService
+}
+
+func NewAdminService(db *database.DB, userRepo *postgres.UserRepository, gameRepo *postgres.GameRepository, snapshotRepo *postgres.SnapshotRepository, auditRepo *postgres.AuditRepository, engine SnapshotEngine, seasons *SeasonService) *AdminService {
+	return &AdminService{
+		db:           db,
+		userRepo:     userRepo,
+		gameRepo:     gameRepo,
+		snapshotRepo: snapshotRepo,
+		auditRepo:    auditRepo,
+		engine:       engine,
+		seasons:      seasons,
+	}
+}
+
+// IsAdmin reports whether a user may use the admin endpoints
+func (s *AdminService) IsAdmin(ctx context.Context, userID int64) (bool, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return user.IsAdmin, nil
+}
+
+// ListSnapshots lists a game's snapshots, newest first
+func (s *AdminService) ListSnapshots(ctx context.Context, gameID int64) ([]*models.GameSnapshot, error) {
+	if _, err := s.gameRepo.GetByID(ctx, gameID); err != nil {
+		return nil, ErrGameNotFound
+	}
+	return s.snapshotRepo.ListByGame(ctx, gameID)
+}
+
+// CreateSnapshot takes a manual snapshot of a game
+func (s *AdminService) CreateSnapshot(ctx context.Context, adminID, gameID int64) (*models.GameSnapshot, error) {
+	game, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+	if game.Status == models.GameStatusWaiting {
+		return nil, ErrGameNotInProgress
+	}
+
+	var snapshot *models.GameSnapshot
+	err = s.db.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		snapshot, err = s.engine.SaveSnapshot(ctx, gameID, models.SnapshotManual)
+		if err != nil {
+			return err
+		}
+
+		return s.audit(ctx, adminID, AuditSnapshotCreated, &gameID, map[string]interface{}{
+			"snapshot_id": snapshot.ID,
+			"turn_number": snapshot.TurnNumber,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// RestoreSnapshot puts a game back into the state of one of its snapshots
+// and records who did it and why. Finished games are refused: their results,
+// statistics and ratings are already recorded and would go stale.
+func (s *AdminService) RestoreSnapshot(ctx context.Context, adminID, gameID, snapshotID int64, reason string) (*models.GameSnapshot, error) {
+	game, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+	if game.Status == models.GameStatusCompleted {
+		return nil, ErrRestoreFinished
+	}
+
+	var snapshot *models.GameSnapshot
+	err = s.db.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		snapshot, err = s.engine.RestoreSnapshot(ctx, gameID, snapshotID)
+		if errors.Is(err, gamelogic.ErrSnapshotNotFound) {
+			return ErrSnapshotNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		return s.audit(ctx, adminID, AuditSnapshotRestored, &gameID, map[string]interface{}{
+			"snapshot_id": snapshot.ID,
+			"turn_number": snapshot.TurnNumber,
+			"reason":      reason,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// StartSeason starts a new ranked season
+func (s *AdminService) StartSeason(ctx context.Context, adminID int64, name string) (*models.Season, error) {
+	var season *models.Season
+	err := s.db.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		season, err = s.seasons.StartSeason(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		return s.audit(ctx, adminID, AuditSeasonStarted, nil, map[string]interface{}{
+			"season_id": season.ID,
+			"name":      season.Name,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return season, nil
+}
+
+// EndSeason ends the running season
+func (s *AdminService) EndSeason(ctx context.Context, adminID int64) (*models.Season, error) {
+	var season *models.Season
+	err := s.db.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		season, err = s.seasons.EndSeason(ctx)
+		if err != nil {
+			return err
+		}
+
+		return s.audit(ctx, adminID, AuditSeasonEnded, nil, map[string]interface{}{
+			"season_id": season.ID,
+			"name":      season.Name,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return season, nil
+}
+
+// ListAudit returns audit log entries, newest first
+func (s *AdminService) ListAudit(ctx context.Context, limit, offset int) ([]*models.AuditEntry, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.auditRepo.List(ctx, limit, offset)
+}
+
+func (s *AdminService) audit(ctx context.Context, adminID int64, action string, gameID *int64, details map[string]interface{}) error {
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	return s.auditRepo.Create(ctx, &models.AuditEntry{
+		AdminID: adminID,
+		Action:  action,
+		GameID:  gameID,
+		Details: detailsJSON,
+	})
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+	"splendor-backend/internal/repository/postgres"
+	"splendor-backend/pkg/database"
+)
+
+// analyticsBatchSize caps the games analyzed in one pass of the job
+const analyticsBatchSize = 50
+
+// AnalyticsService derives per-player metrics from the move logs of
+// finished games. A background job analyzes each completed game once, so
+// metrics show up shortly after a game ends.
+type AnalyticsService struct {
+	db            *database.DB
+	gameRepo      *postgres.GameRepository
+	userRepo      *postgres.UserRepository
+	stateRepo     *postgres.StateRepository
+	moveRepo      *postgres.MoveRepository
+	analyticsRepo *postgres.AnalyticsRepository
+}
+
+func NewAnalyticsService(db *database.DB, gameRepo *postgres.GameRepository, userRepo *postgres.UserRepository, stateRepo *postgres.StateRepository, moveRepo *postgres.MoveRepository, analyticsRepo *postgres.AnalyticsRepository) *AnalyticsService {
+	return &AnalyticsService{
+		db:            db,
+		gameRepo:      gameRepo,
+		userRepo:      userRepo,
+		stateRepo:     stateRepo,
+		moveRepo:      moveRepo,
+		analyticsRepo: analyticsRepo,
+	}
+}
+
+// Run analyzes finished games every interval until ctx is cancelled,
+// starting with a pass over games finished while the server was down
+func (s *AnalyticsService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.AnalyzePending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// AnalyzePending analyzes the finished games that have not been analyzed
+// yet, a batch at a time
+func (s *AnalyticsService) AnalyzePending(ctx context.Context) {
+	for {
+		gameIDs, err := s.gameRepo.GetUnanalyzedGames(ctx, analyticsBatchSize)
+		if err != nil {
+			log.Printf("Failed to get games to analyze: %v", err)
+			return
+		}
+
+		analyzed := 0
+		for _, gameID := range gameIDs {
+			if err := s.AnalyzeGame(ctx, gameID); err != nil {
+				log.Printf("Failed to analyze game %d: %v", gameID, err)
+				continue
+			}
+			analyzed++
+		}
+
+		// Failed games stay unanalyzed, so a batch of nothing but failures
+		// would come back unchanged; leave them for the next pass
+		if len(gameIDs) < analyticsBatchSize || analyzed == 0 {
+			return
+		}
+	}
+}
+
+// AnalyzeGame computes and stores every player's analytics for a finished
+// game. A game is analyzed once; later calls do nothing. The game is only
+// marked analyzed together with its analytics, so a failure leaves it to be
+// analyzed again.
+func (s *AnalyticsService) AnalyzeGame(ctx context.Context, gameID int64) error {
+	return s.db.InTx(ctx, func(ctx context.Context) error {
+		claimed, err := s.gameRepo.MarkAnalyzed(ctx, gameID)
+		if err != nil || !claimed {
+			return err
+		}
+
+		players, err := s.gameRepo.GetPlayers(ctx, gameID)
+		if err != nil {
+			return err
+		}
+
+		moves, err := s.moveRepo.GetByGame(ctx, gameID)
+		if err != nil {
+			return err
+		}
+
+		byPlayer := make(map[int64][]*models.GameMove, len(players))
+		for _, move := range moves {
+			byPlayer[move.GamePlayerID] = append(byPlayer[move.GamePlayerID], move)
+		}
+
+		for _, player := range players {
+			final, err := s.stateRepo.GetPlayerState(ctx, player.ID)
+			if err != nil {
+				return err
+			}
+
+			analytics, err := gamelogic.AnalyzePlayer(byPlayer[player.ID], final)
+			if err != nil {
+				return err
+			}
+			analytics.GameID = gameID
+			analytics.UserID = player.UserID
+
+			if err := s.analyticsRepo.Create(ctx, analytics); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetUserAnalytics summarizes a player's analyzed games, with the most
+// recent limit games listed individually
+func (s *AnalyticsService) GetUserAnalytics(ctx context.Context, userID int64, limit int) (*models.UserAnalytics, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	totals, err := s.analyticsRepo.GetTotals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recent, err := s.analyticsRepo.ListByUser(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	purchases := totals.Tier1Purchases + totals.Tier2Purchases + totals.Tier3Purchases
+	analytics := &models.UserAnalytics{
+		UserID:        userID,
+		GamesAnalyzed: totals.Games,
+		NobleGameRate: ratio(totals.GamesWithNoble, totals.Games),
+		TierMix: map[int]float64{
+			1: ratio(totals.Tier1Purchases, purchases),
+			2: ratio(totals.Tier2Purchases, purchases),
+			3: ratio(totals.Tier3Purchases, purchases),
+		},
+		GoldUsageRate:      ratio(totals.GoldPurchases, purchases),
+		AverageGoldSpent:   ratio(totals.GoldSpent, totals.Games),
+		AverageReserves:    ratio(totals.Reserves, totals.Games),
+		ReserveConversion:  ratio(totals.ReservedPurchases, totals.Reserves),
+		AverageTokensAtEnd: ratio(totals.TokensAtEnd, totals.Games),
+		RecentGames:        recent,
+	}
+	if totals.GamesWithNoble > 0 {
+		average := ratio(totals.FirstNobleTurns, totals.GamesWithNoble)
+		analytics.AverageFirstNobleTurn = &average
+	}
+
+	return analytics, nil
+}
+
+// ratio divides two counts, or returns 0 when there is nothing to divide by
+func ratio(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole)
+}
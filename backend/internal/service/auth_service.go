@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"log"
+	"time"
 
 	"splendor-backend/internal/domain/models"
 	"splendor-backend/internal/repository/postgres"
@@ -16,21 +20,28 @@ var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrEmailAlreadyExists = errors.New("email already exists")
 	ErrUsernameAlreadyExists = errors.New("username already exists")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused = errors.New("refresh token was already used or revoked")
+	ErrNotGuest = errors.New("account is already registered")
 )
 
 type AuthService struct {
 	userRepo          *postgres.UserRepository
+	refreshTokenRepo  *postgres.RefreshTokenRepository
 	jwtSecret         string
 	jwtAccessExpiry   int64
 	jwtRefreshExpiry  int64
+	guestRefreshExpiry int64
 }
 
-func NewAuthService(userRepo *postgres.UserRepository, jwtSecret string, accessExpiry, refreshExpiry int64) *AuthService {
+func NewAuthService(userRepo *postgres.UserRepository, refreshTokenRepo *postgres.RefreshTokenRepository, jwtSecret string, accessExpiry, refreshExpiry, guestRefreshExpiry int64) *AuthService {
 	return &AuthService{
 		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
 		jwtSecret:        jwtSecret,
 		jwtAccessExpiry:  accessExpiry,
 		jwtRefreshExpiry: refreshExpiry,
+		guestRefreshExpiry: guestRefreshExpiry,
 	}
 }
 
@@ -71,23 +82,13 @@ func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest)
 		return nil, err
 	}
 
-	// Generate tokens
-	tokenPair, err := jwt.GenerateTokenPair(
-		user.ID,
-		user.Username,
-		s.jwtSecret,
-		s.jwtAccessExpiry,
-		s.jwtRefreshExpiry,
-	)
+	// Each login starts a new token family
+	familyID, err := newTokenID()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate tokens: %w", err)
+		return nil, err
 	}
 
-	return &models.AuthResponse{
-		AccessToken:  tokenPair.AccessToken,
-		RefreshToken: tokenPair.RefreshToken,
-		User:         user,
-	}, nil
+	return s.issueTokens(ctx, user, familyID)
 }
 
 // Login authenticates a user and returns tokens
@@ -103,46 +104,197 @@ func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 		return nil, ErrInvalidCredentials
 	}
 
-	// Generate tokens
-	tokenPair, err := jwt.GenerateTokenPair(
-		user.ID,
-		user.Username,
-		s.jwtSecret,
-		s.jwtAccessExpiry,
-		s.jwtRefreshExpiry,
-	)
+	// Each login starts a new token family
+	familyID, err := newTokenID()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate tokens: %w", err)
+		return nil, err
 	}
 
-	return &models.AuthResponse{
-		AccessToken:  tokenPair.AccessToken,
-		RefreshToken: tokenPair.RefreshToken,
-		User:         user,
-	}, nil
+	return s.issueTokens(ctx, user, familyID)
+}
+
+// Guest starts a guest session under a display name. Guests can join games
+// by room code but not ranked games, and their refresh tokens expire sooner.
+func (s *AuthService) Guest(ctx context.Context, req *models.GuestRequest) (*models.AuthResponse, error) {
+	usernameExists, err := s.userRepo.UsernameExists(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if usernameExists {
+		return nil, ErrUsernameAlreadyExists
+	}
+
+	// Guests get a placeholder email, replaced when they upgrade
+	placeholder, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		Username: req.Username,
+		Email:    "guest-" + placeholder + "@guests.splendor.local",
+	}
+	if err := s.userRepo.CreateGuest(ctx, user); err != nil {
+		return nil, err
+	}
+
+	familyID, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(ctx, user, familyID)
+}
+
+// UpgradeGuest turns a guest into a registered account with an email and
+// password. The account keeps its ID, so its games and stats carry over.
+// The guest session's tokens are revoked and a new login is started.
+func (s *AuthService) UpgradeGuest(ctx context.Context, userID int64, req *models.UpgradeGuestRequest) (*models.AuthResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsGuest {
+		return nil, ErrNotGuest
+	}
+
+	emailExists, err := s.userRepo.EmailExists(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if emailExists {
+		return nil, ErrEmailAlreadyExists
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user.Email = req.Email
+	user.PasswordHash = string(passwordHash)
+	upgraded, err := s.userRepo.UpgradeGuest(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if !upgraded {
+		return nil, ErrNotGuest
+	}
+
+	if err := s.refreshTokenRepo.RevokeUser(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	familyID, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(ctx, user, familyID)
 }
 
-// RefreshToken generates new tokens from a refresh token
+// RefreshToken exchanges a refresh token for a new token pair. Each refresh
+// token works once; presenting a used one again means it was stolen or
+// replayed, so every token of its family is revoked.
 func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*models.AuthResponse, error) {
 	// Validate refresh token
-	claims, err := jwt.ValidateToken(refreshToken, s.jwtSecret)
+	claims, err := jwt.ValidateRefreshToken(refreshToken, s.jwtSecret)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	used, err := s.refreshTokenRepo.Use(ctx, claims.ID)
 	if err != nil {
 		return nil, err
 	}
+	if !used {
+		if err := s.refreshTokenRepo.RevokeFamily(ctx, claims.FamilyID); err != nil {
+			return nil, err
+		}
+		log.Printf("Refresh token reused for user %d, revoked its token family", claims.UserID)
+		return nil, ErrRefreshTokenReused
+	}
 
 	// Get user
 	user, err := s.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return s.issueTokens(ctx, user, claims.FamilyID)
+}
+
+// Logout revokes the refresh token family a refresh token belongs to,
+// ending that login. Access tokens already issued stay valid until they
+// expire.
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	claims, err := jwt.ValidateRefreshToken(refreshToken, s.jwtSecret)
+	if err != nil {
+		return ErrInvalidRefreshToken
+	}
+
+	return s.refreshTokenRepo.RevokeFamily(ctx, claims.FamilyID)
+}
+
+// LogoutAll revokes every refresh token of a user, ending all their logins
+func (s *AuthService) LogoutAll(ctx context.Context, userID int64) error {
+	return s.refreshTokenRepo.RevokeUser(ctx, userID)
+}
+
+// Run deletes expired refresh tokens every interval until ctx is cancelled
+func (s *AuthService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := s.refreshTokenRepo.DeleteExpired(ctx)
+		if err != nil {
+			log.Printf("Failed to delete expired refresh tokens: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Deleted %d expired refresh tokens", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// issueTokens signs a new token pair and stores its refresh token in the
+// given family
+func (s *AuthService) issueTokens(ctx context.Context, user *models.User, familyID string) (*models.AuthResponse, error) {
+	tokenID, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	refreshExpiry := s.jwtRefreshExpiry
+	if user.IsGuest {
+		refreshExpiry = s.guestRefreshExpiry
+	}
+
+	err = s.refreshTokenRepo.Create(ctx, &models.RefreshToken{
+		ID:        tokenID,
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(time.Duration(refreshExpiry) * time.Second),
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Generate new tokens
+	// Generate tokens
 	tokenPair, err := jwt.GenerateTokenPair(
 		user.ID,
 		user.Username,
+		user.IsGuest,
+		tokenID,
+		familyID,
 		s.jwtSecret,
 		s.jwtAccessExpiry,
-		s.jwtRefreshExpiry,
+		refreshExpiry,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate tokens: %w", err)
@@ -155,6 +307,16 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*m
 	}, nil
 }
 
+// newTokenID returns a random hex ID for a refresh token, token family or
+// guest placeholder email
+func newTokenID() (string, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate token ID: %w", err)
+	}
+	return hex.EncodeToString(raw), nil
+}
+
 // GetUserByID retrieves a user by ID
 func (s *AuthService) GetUserByID(ctx context.Context, id int64) (*models.User, error) {
 	return s.userRepo.GetByID(ctx, id)
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"splendor-backend/internal/bot"
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+	"splendor-backend/internal/repository/postgres"
+)
+
+var (
+	ErrBotNotFound      = errors.New("bot not found")
+	ErrBotCannotOwnBots = errors.New("bot accounts cannot register bots")
+	ErrInvalidBotToken  = errors.New("invalid bot token")
+	ErrTurnNotPending   = errors.New("turn is not waiting for a move")
+	ErrIllegalMove      = errors.New("move is not legal")
+)
+
+// botTokenPrefix marks bot tokens so they are easy to tell apart from JWTs
+const botTokenPrefix = "sbt_"
+
+// turnPollInterval is how often a long poll checks for new turns
+const turnPollInterval = 250 * time.Millisecond
+
+// BotService manages external bot accounts, their tokens and the turns they
+// are offered
+type BotService struct {
+	userRepo *postgres.UserRepository
+	botRepo  *postgres.BotRepository
+	engine   GameEngine
+}
+
+func NewBotService(userRepo *postgres.UserRepository, botRepo *postgres.BotRepository, engine GameEngine) *BotService {
+	return &BotService{
+		userRepo: userRepo,
+		botRepo:  botRepo,
+		engine:   engine,
+	}
+}
+
+// CreateBot registers a bot account owned by ownerID and issues its first
+// token. The token is only returned here; the server keeps just its hash.
+func (s *BotService) CreateBot(ctx context.Context, ownerID int64, username string) (*models.CreateBotResponse, error) {
+	owner, err := s.userRepo.GetByID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner.IsBot {
+		return nil, ErrBotCannotOwnBots
+	}
+
+	exists, err := s.userRepo.UsernameExists(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrUsernameAlreadyExists
+	}
+
+	botUser := &models.User{
+		Username:   username,
+		Email:      username + "@bots.splendor.local",
+		BotOwnerID: &ownerID,
+	}
+	if err := s.userRepo.CreateBot(ctx, botUser); err != nil {
+		return nil, err
+	}
+
+	token, err := s.issueToken(ctx, botUser.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CreateBotResponse{
+		Bot:   botUser,
+		Token: token,
+	}, nil
+}
+
+// ListBots returns the bot accounts registered by a user
+func (s *BotService) ListBots(ctx context.Context, ownerID int64) ([]*models.User, error) {
+	return s.botRepo.ListByOwner(ctx, ownerID)
+}
+
+// RotateToken revokes a bot's tokens and issues a new one
+func (s *BotService) RotateToken(ctx context.Context, ownerID, botID int64) (string, error) {
+	botUser, err := s.userRepo.GetByID(ctx, botID)
+	if err != nil || botUser.BotOwnerID == nil || *botUser.BotOwnerID != ownerID {
+		return "", ErrBotNotFound
+	}
+
+	if err := s.botRepo.RevokeTokens(ctx, botID); err != nil {
+		return "", err
+	}
+
+	return s.issueToken(ctx, botID)
+}
+
+// AuthenticateBot returns the bot account holding a token
+func (s *BotService) AuthenticateBot(ctx context.Context, token string) (*models.User, error) {
+	botUser, err := s.botRepo.GetUserByTokenHash(ctx, hashBotToken(token))
+	if err != nil {
+		return nil, ErrInvalidBotToken
+	}
+	return botUser, nil
+}
+
+// PendingTurns returns the turns waiting for a bot's move, each with the
+// bot's view of the game
+func (s *BotService) PendingTurns(ctx context.Context, botID int64) ([]*models.BotTurn, error) {
+	turns, err := s.botRepo.GetPendingTurns(ctx, botID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, turn := range turns {
+		state, err := s.engine.GetGameState(ctx, turn.GameID, botID)
+		if err != nil {
+			log.Printf("Failed to load game %d for bot %d: %v", turn.GameID, botID, err)
+			continue
+		}
+		turn.State = state
+	}
+
+	return turns, nil
+}
+
+// WaitForTurns long-polls for turns waiting on a bot, returning as soon as
+// there is at least one or when wait has passed
+func (s *BotService) WaitForTurns(ctx context.Context, botID int64, wait time.Duration) ([]*models.BotTurn, error) {
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	ticker := time.NewTicker(turnPollInterval)
+	defer ticker.Stop()
+
+	for {
+		turns, err := s.PendingTurns(ctx, botID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return []*models.BotTurn{}, nil
+			}
+			return nil, err
+		}
+		if len(turns) > 0 {
+			return turns, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return turns, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// SubmitMove records a bot's move for a pending turn. The move must be one
+// of the legal actions the bot was offered.
+func (s *BotService) SubmitMove(ctx context.Context, botID, gameID int64, turnNumber int, rawAction json.RawMessage) (*gamelogic.Action, error) {
+	turn, err := s.botRepo.GetTurn(ctx, gameID, botID, turnNumber)
+	if err != nil {
+		return nil, ErrTurnNotPending
+	}
+
+	var legal []gamelogic.Action
+	if err := json.Unmarshal(turn.LegalActions, &legal); err != nil {
+		return nil, err
+	}
+
+	var action gamelogic.Action
+	if err := json.Unmarshal(rawAction, &action); err != nil {
+		return nil, ErrIllegalMove
+	}
+
+	move := bot.MatchLegal(legal, &action)
+	if move == nil {
+		return nil, ErrIllegalMove
+	}
+
+	moveJSON, err := json.Marshal(move)
+	if err != nil {
+		return nil, err
+	}
+
+	answered, err := s.botRepo.AnswerTurn(ctx, gameID, botID, turnNumber, moveJSON)
+	if err != nil {
+		return nil, err
+	}
+	if !answered {
+		return nil, ErrTurnNotPending
+	}
+
+	return move, nil
+}
+
+// issueToken creates and stores a new random token for a bot
+func (s *BotService) issueToken(ctx context.Context, botID int64) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	token := botTokenPrefix + hex.EncodeToString(raw)
+
+	if err := s.botRepo.CreateToken(ctx, botID, hashBotToken(token)); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func hashBotToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
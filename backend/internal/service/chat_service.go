@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/repository/postgres"
+)
+
+var (
+	ErrEmptyMessage    = errors.New("message cannot be empty")
+	ErrMessageTooLong  = errors.New("message is too long")
+	ErrChatRateLimited = errors.New("you are sending messages too quickly")
+	ErrNotInGame       = errors.New("you are not a player in this game")
+	ErrCannotMuteSelf  = errors.New("you cannot mute yourself")
+)
+
+// ProfanityFilter is a hook applied to every chat message before it is
+// stored. It may rewrite the text or reject it with an error.
+type ProfanityFilter interface {
+	Filter(text string) (string, error)
+}
+
+// WordListFilter masks blocked words with asterisks
+type WordListFilter struct {
+	pattern *regexp.Regexp
+}
+
+func NewWordListFilter(words []string) *WordListFilter {
+	quoted := []string{}
+	for _, word := range words {
+		word = strings.TrimSpace(word)
+		if word != "" {
+			quoted = append(quoted, regexp.QuoteMeta(word))
+		}
+	}
+
+	filter := &WordListFilter{}
+	if len(quoted) > 0 {
+		filter.pattern = regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\b`)
+	}
+	return filter
+}
+
+// Filter replaces every case-insensitive whole-word match of a blocked word
+func (f *WordListFilter) Filter(text string) (string, error) {
+	if f.pattern == nil {
+		return text, nil
+	}
+
+	return f.pattern.ReplaceAllStringFunc(text, func(word string) string {
+		return strings.Repeat("*", utf8.RuneCountInString(word))
+	}), nil
+}
+
+type ChatService struct {
+	chatRepo *postgres.ChatRepository
+	gameRepo *postgres.GameRepository
+	filter   ProfanityFilter
+	limiter  *chatRateLimiter
+
+	maxLength int
+}
+
+func NewChatService(chatRepo *postgres.ChatRepository, gameRepo *postgres.GameRepository, filter ProfanityFilter, maxLength, rateLimit int, rateWindow time.Duration) *ChatService {
+	return &ChatService{
+		chatRepo:  chatRepo,
+		gameRepo:  gameRepo,
+		filter:    filter,
+		limiter:   newChatRateLimiter(rateLimit, rateWindow),
+		maxLength: maxLength,
+	}
+}
+
+// SendMessage validates, filters and stores a message from a player. The
+// sender and timestamp are always taken from the server side.
+func (s *ChatService) SendMessage(ctx context.Context, gameID, userID int64, username, text string) (*models.ChatMessage, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrEmptyMessage
+	}
+	if utf8.RuneCountInString(text) > s.maxLength {
+		return nil, ErrMessageTooLong
+	}
+
+	inGame, err := s.gameRepo.IsPlayerInGame(ctx, gameID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !inGame {
+		return nil, ErrNotInGame
+	}
+
+	if !s.limiter.Allow(userID) {
+		return nil, ErrChatRateLimited
+	}
+
+	if s.filter != nil {
+		text, err = s.filter.Filter(text)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	msg := &models.ChatMessage{
+		GameID:   gameID,
+		UserID:   userID,
+		Username: username,
+		Message:  text,
+	}
+
+	if err := s.chatRepo.CreateMessage(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// GetHistory returns a page of chat history as seen by viewerID. Viewers who
+// are not seated in a running game see it with the game's spectator delay,
+// as they do over the WebSocket.
+func (s *ChatService) GetHistory(ctx context.Context, gameID, viewerID, beforeID int64, limit int) ([]*models.ChatMessage, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	game, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+
+	inGame, err := s.gameRepo.IsPlayerInGame(ctx, gameID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	delay := 0
+	if !inGame && game.Status != models.GameStatusCompleted {
+		delay = game.SpectatorDelaySeconds
+	}
+
+	return s.chatRepo.GetMessages(ctx, gameID, viewerID, beforeID, delay, limit)
+}
+
+// MutePlayer hides mutedUserID's messages from userID in a game
+func (s *ChatService) MutePlayer(ctx context.Context, gameID, userID, mutedUserID int64) error {
+	if userID == mutedUserID {
+		return ErrCannotMuteSelf
+	}
+
+	inGame, err := s.gameRepo.IsPlayerInGame(ctx, gameID, userID)
+	if err != nil {
+		return err
+	}
+	if !inGame {
+		return ErrNotInGame
+	}
+
+	return s.chatRepo.AddMute(ctx, gameID, userID, mutedUserID)
+}
+
+// UnmutePlayer removes a mute
+func (s *ChatService) UnmutePlayer(ctx context.Context, gameID, userID, mutedUserID int64) error {
+	return s.chatRepo.RemoveMute(ctx, gameID, userID, mutedUserID)
+}
+
+// GetMutedUsers returns the users muted by userID in a game
+func (s *ChatService) GetMutedUsers(ctx context.Context, gameID, userID int64) ([]int64, error) {
+	return s.chatRepo.GetMutedUsers(ctx, gameID, userID)
+}
+
+// chatRateLimiter allows at most limit messages per user in a sliding window.
+// Users with nothing left in the window are forgotten once per window.
+type chatRateLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	sent   map[int64][]time.Time
+	swept  time.Time
+}
+
+func newChatRateLimiter(limit int, window time.Duration) *chatRateLimiter {
+	return &chatRateLimiter{
+		limit:  limit,
+		window: window,
+		sent:   make(map[int64][]time.Time),
+	}
+}
+
+func (l *chatRateLimiter) Allow(userID int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-l.window)
+
+	if now.Sub(l.swept) >= l.window {
+		for id, times := range l.sent {
+			if len(times) == 0 || !times[len(times)-1].After(cutoff) {
+				delete(l.sent, id)
+			}
+		}
+		l.swept = now
+	}
+
+	recent := l.sent[userID][:0]
+	for _, t := range l.sent[userID] {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+
+	if len(recent) >= l.limit {
+		l.sent[userID] = recent
+		return false
+	}
+
+	l.sent[userID] = append(recent, now)
+	return true
+}
@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/repository/postgres"
+	"splendor-backend/pkg/database"
+)
+
+var (
+	ErrGameNotFinished  = errors.New("game is not finished")
+	ErrAlreadyFinalized = errors.New("game results were already recorded")
+)
+
+// GameOverService runs the game-over pipeline: it records the final
+// standings of a finished game, adds them to the players' statistics and
+// ratings and to the card balance statistics, checks achievements, and
+// reports tournament games to their tournament. Each game is processed once.
+type GameOverService struct {
+	db            *database.DB
+	gameRepo      *postgres.GameRepository
+	stateRepo     *postgres.StateRepository
+	moveRepo      *postgres.MoveRepository
+	resultRepo    *postgres.ResultRepository
+	statsService  *StatsService
+	ratingService *RatingService
+	achievements  *AchievementService
+	tournaments   *TournamentService
+}
+
+func NewGameOverService(db *database.DB, gameRepo *postgres.GameRepository, stateRepo *postgres.StateRepository, moveRepo *postgres.MoveRepository, resultRepo *postgres.ResultRepository, statsService *StatsService, ratingService *RatingService, achievements *AchievementService, tournaments *TournamentService) *GameOverService {
+	return &GameOverService{
+		db:            db,
+		gameRepo:      gameRepo,
+		stateRepo:     stateRepo,
+		moveRepo:      moveRepo,
+		resultRepo:    resultRepo,
+		statsService:  statsService,
+		ratingService: ratingService,
+		achievements:  achievements,
+		tournaments:   tournaments,
+	}
+}
+
+// FinishGame records a finished game's standings and statistics and returns
+// the scoreboard. It returns ErrAlreadyFinalized if the game was processed
+// before, so it is safe to call more than once. The game is only marked
+// finalized together with its results, statistics and ratings, so a failure
+// leaves it to be retried in full.
+func (s *GameOverService) FinishGame(ctx context.Context, gameID int64) (*models.FinalStandings, error) {
+	var game *models.Game
+	var results []*models.GameResult
+
+	err := s.db.InTx(ctx, func(ctx context.Context) error {
+		// The claim locks the game row until the transaction ends, so a
+		// concurrent run waits and then finds it finalized
+		claimed, err := s.gameRepo.MarkFinalized(ctx, gameID)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return ErrAlreadyFinalized
+		}
+
+		game, err = s.gameRepo.GetByID(ctx, gameID)
+		if err != nil {
+			return err
+		}
+
+		results, err = s.rankPlayers(ctx, game)
+		if err != nil {
+			return err
+		}
+
+		for _, result := range results {
+			if err := s.resultRepo.Create(ctx, result); err != nil {
+				return err
+			}
+		}
+
+		if err := s.statsService.UpdateGameStats(ctx, game, results); err != nil {
+			return err
+		}
+
+		if err := s.statsService.UpdateBalanceStats(ctx, gameID); err != nil {
+			return err
+		}
+
+		return s.ratingService.RateGame(ctx, game, results)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The game's own results are stored by now, so a failure to check
+	// achievements or move a tournament on doesn't hold back the scoreboard.
+	// Both are retried: achievements by FinishPending, tournaments by their
+	// own resume pass, which finishes tables of finalized games.
+	if err := s.checkAchievements(ctx, game, results); err != nil {
+		log.Printf("Failed to check achievements of game %d: %v", gameID, err)
+	}
+	if err := s.tournaments.RecordResult(ctx, gameID); err != nil {
+		log.Printf("Failed to record tournament result of game %d: %v", gameID, err)
+	}
+
+	return newFinalStandings(game, results), nil
+}
+
+// FinishPending runs the pipeline for finished games it missed, e.g. because
+// the server stopped right after the last move or an earlier run failed,
+// and checks the achievements of finalized games whose check failed. A game
+// that fails again is logged and left for the next pass.
+func (s *GameOverService) FinishPending(ctx context.Context) ([]*models.FinalStandings, error) {
+	s.checkPendingAchievements(ctx)
+
+	gameIDs, err := s.gameRepo.GetUnfinalizedGames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	finished := []*models.FinalStandings{}
+	for _, gameID := range gameIDs {
+		standings, err := s.FinishGame(ctx, gameID)
+		if errors.Is(err, ErrAlreadyFinalized) {
+			continue
+		}
+		if err != nil {
+			log.Printf("Failed to finish game %d: %v", gameID, err)
+			continue
+		}
+		finished = append(finished, standings)
+	}
+
+	return finished, nil
+}
+
+// checkAchievements checks a finalized game's game-end achievements and
+// records that it did. Unlocking is idempotent, so a retry after a partial
+// check only unlocks what is missing.
+func (s *GameOverService) checkAchievements(ctx context.Context, game *models.Game, results []*models.GameResult) error {
+	if err := s.achievements.CheckGameEnd(ctx, game, results); err != nil {
+		return err
+	}
+	return s.gameRepo.MarkAchievementsChecked(ctx, game.ID)
+}
+
+// checkPendingAchievements retries the achievement check of finalized games
+// whose check failed
+func (s *GameOverService) checkPendingAchievements(ctx context.Context) {
+	gameIDs, err := s.gameRepo.GetUncheckedAchievementGames(ctx)
+	if err != nil {
+		log.Printf("Failed to get games with unchecked achievements: %v", err)
+		return
+	}
+
+	for _, gameID := range gameIDs {
+		game, err := s.gameRepo.GetByID(ctx, gameID)
+		if err != nil {
+			log.Printf("Failed to check achievements of game %d: %v", gameID, err)
+			continue
+		}
+		results, err := s.resultRepo.GetByGame(ctx, gameID)
+		if err != nil {
+			log.Printf("Failed to check achievements of game %d: %v", gameID, err)
+			continue
+		}
+		if err := s.checkAchievements(ctx, game, results); err != nil {
+			log.Printf("Failed to check achievements of game %d: %v", gameID, err)
+		}
+	}
+}
+
+// GetStandings returns the recorded scoreboard of a finished game
+func (s *GameOverService) GetStandings(ctx context.Context, gameID int64) (*models.FinalStandings, error) {
+	game, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+
+	if game.Status != models.GameStatusCompleted {
+		return nil, ErrGameNotFinished
+	}
+
+	results, err := s.resultRepo.GetByGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFinalStandings(game, results), nil
+}
+
+// rankPlayers builds each player's result, ordered by the same rules that
+// pick the winner: most points, then fewest cards bought, then seat order
+func (s *GameOverService) rankPlayers(ctx context.Context, game *models.Game) ([]*models.GameResult, error) {
+	players, err := s.gameRepo.GetPlayers(ctx, game.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	moves, err := s.moveRepo.CountByPlayer(ctx, game.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*models.GameResult, 0, len(players))
+	for _, player := range players {
+		playerState, err := s.stateRepo.GetPlayerState(ctx, player.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		cardsByGem := make(map[string]int)
+		for _, card := range playerState.PurchasedCards {
+			cardsByGem[card.GemType]++
+		}
+
+		result := &models.GameResult{
+			GameID:         game.ID,
+			UserID:         player.UserID,
+			VictoryPoints:  player.VictoryPoints,
+			CardsPurchased: len(playerState.PurchasedCards),
+			NoblesEarned:   len(playerState.Nobles),
+			Moves:          moves[player.ID],
+			CardsByGem:     cardsByGem,
+		}
+		if player.User != nil {
+			result.Username = player.User.Username
+			result.IsBot = player.User.IsBot
+		}
+		results = append(results, result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].VictoryPoints != results[j].VictoryPoints {
+			return results[i].VictoryPoints > results[j].VictoryPoints
+		}
+		return results[i].CardsPurchased < results[j].CardsPurchased
+	})
+
+	// The recorded winner always places first
+	if game.WinnerID != nil {
+		for i, result := range results {
+			if result.UserID == *game.WinnerID {
+				copy(results[1:i+1], results[:i])
+				results[0] = result
+				break
+			}
+		}
+	}
+
+	for i, result := range results {
+		result.Placement = i + 1
+	}
+
+	return results, nil
+}
+
+func newFinalStandings(game *models.Game, results []*models.GameResult) *models.FinalStandings {
+	return &models.FinalStandings{
+		GameID:          game.ID,
+		WinnerID:        game.WinnerID,
+		TurnNumber:      game.TurnNumber,
+		CompletedAt:     game.CompletedAt,
+		DurationSeconds: gameDuration(game.StartedAt, game.CompletedAt),
+		Standings:       results,
+	}
+}
+
+// gameDuration is how long a game ran, in whole seconds
+func gameDuration(startedAt, completedAt *time.Time) int {
+	if startedAt == nil || completedAt == nil {
+		return 0
+	}
+	return int(completedAt.Sub(*startedAt).Seconds())
+}
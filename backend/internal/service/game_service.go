@@ -2,12 +2,18 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
+	"splendor-backend/internal/bot"
 	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
 	"splendor-backend/internal/repository/postgres"
+	"splendor-backend/pkg/database"
 )
 
 var (
@@ -17,42 +23,69 @@ var (
 	ErrNotGameCreator    = errors.New("only the game creator can start the game")
 	ErrNotEnoughPlayers  = errors.New("not enough players to start game")
 	ErrAlreadyInGame     = errors.New("you are already in this game")
+	ErrUnknownStrategy   = errors.New("unknown bot strategy")
+	ErrNotABot           = errors.New("player is not a bot")
+	ErrNotBotOwner       = errors.New("only the bot's owner can seat it")
+	ErrHintsDisabled     = errors.New("hints are not enabled for this game")
+	ErrGameNotInProgress = errors.New("game is not in progress")
+	ErrNotAPlayer        = errors.New("you are not playing in this game")
+	ErrGuestRanked       = errors.New("guests cannot play ranked games")
+	ErrBotsUnranked      = errors.New("bots cannot be seated in ranked games")
 )
 
 type GameService struct {
-	gameRepo *postgres.GameRepository
-	userRepo *postgres.UserRepository
-	engine   GameEngine
+	db         *database.DB
+	gameRepo   *postgres.GameRepository
+	userRepo   *postgres.UserRepository
+	cardRepo   *postgres.CardRepository
+	engine     GameEngine
+	spectators SpectatorCounter
 }
 
 type GameEngine interface {
 	InitializeGame(ctx context.Context, gameID int64) error
-	GetGameState(ctx context.Context, gameID int64) (*models.FullGameState, error)
+	GetGameState(ctx context.Context, gameID, viewerID int64) (*models.FullGameState, error)
 }
 
-func NewGameService(gameRepo *postgres.GameRepository, userRepo *postgres.UserRepository, engine GameEngine) *GameService {
+// SpectatorCounter reports how many spectators are watching games
+type SpectatorCounter interface {
+	GetSpectatorCount(gameID string) int
+	GetSpectatorCounts(gameIDs []string) map[string]int
+}
+
+func NewGameService(db *database.DB, gameRepo *postgres.GameRepository, userRepo *postgres.UserRepository, cardRepo *postgres.CardRepository, engine GameEngine, spectators SpectatorCounter) *GameService {
 	return &GameService{
-		gameRepo: gameRepo,
-		userRepo: userRepo,
-		engine:   engine,
+		db:         db,
+		gameRepo:   gameRepo,
+		userRepo:   userRepo,
+		cardRepo:   cardRepo,
+		engine:     engine,
+		spectators: spectators,
 	}
 }
 
-// CreateGame creates a new game
-func (s *GameService) CreateGame(ctx context.Context, userID int64, numPlayers int) (*models.CreateGameResponse, error) {
+// CreateGame creates a new game. Rooms are always unranked; ranked games
+// are only created by matchmaking and tournaments.
+func (s *GameService) CreateGame(ctx context.Context, userID int64, req *models.CreateGameRequest) (*models.CreateGameResponse, error) {
+	return s.createRoom(ctx, userID, &models.Game{
+		NumPlayers:            req.NumPlayers,
+		SpectatorDelaySeconds: req.SpectatorDelaySeconds,
+		AllowHints:            req.AllowHints,
+	})
+}
+
+// createRoom stores a waiting game under a new room code and seats its
+// creator
+func (s *GameService) createRoom(ctx context.Context, userID int64, game *models.Game) (*models.CreateGameResponse, error) {
 	// Generate unique room code
 	roomCode, err := s.gameRepo.GenerateRoomCode(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create game
-	game := &models.Game{
-		RoomCode:   roomCode,
-		Status:     models.GameStatusWaiting,
-		NumPlayers: numPlayers,
-		CreatedBy:  userID,
-	}
+	game.RoomCode = roomCode
+	game.Status = models.GameStatusWaiting
+	game.CreatedBy = userID
 
 	if err := s.gameRepo.Create(ctx, game); err != nil {
 		return nil, err
@@ -96,6 +129,17 @@ func (s *GameService) JoinGame(ctx context.Context, userID int64, roomCode strin
 		return nil, ErrGameStarted
 	}
 
+	// Guests only play unranked games
+	if game.Ranked {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if user.IsGuest {
+			return nil, ErrGuestRanked
+		}
+	}
+
 	// Check if user is already in game
 	inGame, err := s.gameRepo.IsPlayerInGame(ctx, game.ID, userID)
 	if err != nil {
@@ -114,11 +158,17 @@ func (s *GameService) JoinGame(ctx context.Context, userID int64, roomCode strin
 		return nil, ErrGameFull
 	}
 
+	// Removed bots leave gaps, so the count can be a taken seat
+	position, err := s.gameRepo.GetFreePosition(ctx, game.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Add player
 	gamePlayer := &models.GamePlayer{
 		GameID:         game.ID,
 		UserID:         userID,
-		PlayerPosition: playerCount,
+		PlayerPosition: position,
 		VictoryPoints:  0,
 		IsActive:       true,
 	}
@@ -131,6 +181,202 @@ func (s *GameService) JoinGame(ctx context.Context, userID int64, roomCode strin
 	return s.GetGameByID(ctx, game.ID)
 }
 
+// AddBot seats a bot in a waiting game. Built-in strategies get a new bot
+// account; external bots are seated with the registered account botUserID,
+// which the creator must own. Only the creator can add bots, and only to
+// unranked games.
+func (s *GameService) AddBot(ctx context.Context, gameID, userID int64, strategy string, botUserID int64) (*models.Game, error) {
+	if strategy == "" {
+		strategy = bot.DefaultStrategy
+	}
+	if _, ok := bot.Lookup(strategy); !ok {
+		return nil, ErrUnknownStrategy
+	}
+
+	game, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+
+	if game.CreatedBy != userID {
+		return nil, ErrNotGameCreator
+	}
+
+	if game.Status != models.GameStatusWaiting {
+		return nil, ErrGameStarted
+	}
+
+	if game.Ranked {
+		return nil, ErrBotsUnranked
+	}
+
+	playerCount, err := s.gameRepo.GetPlayerCount(ctx, game.ID)
+	if err != nil {
+		return nil, err
+	}
+	if playerCount >= game.NumPlayers {
+		return nil, ErrGameFull
+	}
+
+	position, err := s.gameRepo.GetFreePosition(ctx, game.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var botUser *models.User
+	if strategy == bot.ExternalStrategyName {
+		botUser, err = s.userRepo.GetByID(ctx, botUserID)
+		if err != nil || botUser.BotOwnerID == nil {
+			return nil, ErrNotABot
+		}
+		if *botUser.BotOwnerID != userID {
+			return nil, ErrNotBotOwner
+		}
+
+		inGame, err := s.gameRepo.IsPlayerInGame(ctx, game.ID, botUser.ID)
+		if err != nil {
+			return nil, err
+		}
+		if inGame {
+			return nil, ErrAlreadyInGame
+		}
+	} else {
+		botUser, err = s.createBotUser(ctx, strategy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	gamePlayer := &models.GamePlayer{
+		GameID:         game.ID,
+		UserID:         botUser.ID,
+		PlayerPosition: position,
+		VictoryPoints:  0,
+		IsActive:       true,
+		BotStrategy:    strategy,
+	}
+
+	if err := s.gameRepo.AddPlayer(ctx, gamePlayer); err != nil {
+		return nil, err
+	}
+
+	return s.GetGameByID(ctx, game.ID)
+}
+
+// RemoveBot unseats a bot from a waiting game. Only the creator can remove
+// bots.
+func (s *GameService) RemoveBot(ctx context.Context, gameID, userID, botUserID int64) (*models.Game, error) {
+	game, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+
+	if game.CreatedBy != userID {
+		return nil, ErrNotGameCreator
+	}
+
+	if game.Status != models.GameStatusWaiting {
+		return nil, ErrGameStarted
+	}
+
+	players, err := s.gameRepo.GetPlayers(ctx, game.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	isBot := false
+	for _, p := range players {
+		if p.UserID == botUserID && p.IsActive && p.BotStrategy != "" {
+			isBot = true
+			break
+		}
+	}
+	if !isBot {
+		return nil, ErrNotABot
+	}
+
+	// Bot seats are deleted outright so the position can be reused
+	if err := s.gameRepo.DeletePlayer(ctx, game.ID, botUserID); err != nil {
+		return nil, err
+	}
+
+	return s.GetGameByID(ctx, game.ID)
+}
+
+// createBotUser creates a bot account with a unique generated name
+func (s *GameService) createBotUser(ctx context.Context, strategy string) (*models.User, error) {
+	for {
+		suffix := make([]byte, 3)
+		if _, err := rand.Read(suffix); err != nil {
+			return nil, err
+		}
+		username := fmt.Sprintf("%s-bot-%s", strategy, hex.EncodeToString(suffix))
+
+		exists, err := s.userRepo.UsernameExists(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			continue
+		}
+
+		botUser := &models.User{
+			Username: username,
+			Email:    username + "@bots.splendor.local",
+		}
+		if err := s.userRepo.CreateBot(ctx, botUser); err != nil {
+			return nil, err
+		}
+		return botUser, nil
+	}
+}
+
+// GetHints evaluates the player's position and suggests their best moves.
+// Hints must be enabled for the game and it must be the player's turn. The
+// first hint flags the game so it is left out of ranked statistics.
+func (s *GameService) GetHints(ctx context.Context, gameID, userID int64, limit int) (*bot.Analysis, error) {
+	game, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+
+	// Ranked games never allow hints, whatever the flag says
+	if !game.AllowHints || game.Ranked {
+		return nil, ErrHintsDisabled
+	}
+
+	if game.Status != models.GameStatusInProgress {
+		return nil, ErrGameNotInProgress
+	}
+
+	state, err := s.engine.GetGameState(ctx, gameID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := state.PlayerStates[userID]; !ok {
+		return nil, ErrNotAPlayer
+	}
+
+	if state.Game.CurrentTurnPlayerID == nil || *state.Game.CurrentTurnPlayerID != userID {
+		return nil, gamelogic.ErrNotYourTurn
+	}
+
+	if !game.HintsUsed {
+		if err := s.gameRepo.MarkHintsUsed(ctx, gameID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Moves are tried with the unseen cards dealt at random
+	cards, err := s.cardRepo.GetAllCards(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return bot.Analyze(state, userID, cards, limit), nil
+}
+
 // LeaveGame removes a player from a game
 func (s *GameService) LeaveGame(ctx context.Context, gameID, userID int64) error {
 	game, err := s.gameRepo.GetByID(ctx, gameID)
@@ -143,7 +389,9 @@ func (s *GameService) LeaveGame(ctx context.Context, gameID, userID int64) error
 		return errors.New("cannot leave a game that has started")
 	}
 
-	return s.gameRepo.RemovePlayer(ctx, gameID, userID)
+	// Nothing has been played yet, so the seat is dropped and its position
+	// freed for the next player to join
+	return s.gameRepo.DeletePlayer(ctx, gameID, userID)
 }
 
 // StartGame starts a game
@@ -197,6 +445,36 @@ func (s *GameService) StartGame(ctx context.Context, gameID, userID int64) (*mod
 	return s.GetGameByID(ctx, game.ID)
 }
 
+// CreateStartedGame creates a game for a fixed set of players, seats them in
+// the given order and starts it. The first player is the game's creator. It
+// runs in one transaction, so a failure leaves no half-built game behind.
+func (s *GameService) CreateStartedGame(ctx context.Context, userIDs []int64, ranked bool) (*models.Game, error) {
+	var game *models.Game
+	err := s.db.InTx(ctx, func(ctx context.Context) error {
+		created, err := s.createRoom(ctx, userIDs[0], &models.Game{
+			NumPlayers: len(userIDs),
+			Ranked:     ranked,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, userID := range userIDs[1:] {
+			if _, err := s.JoinGame(ctx, userID, created.RoomCode); err != nil {
+				return err
+			}
+		}
+
+		game, err = s.StartGame(ctx, created.Game.ID, userIDs[0])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return game, nil
+}
+
 // GetGameByID retrieves a game by ID with all players
 func (s *GameService) GetGameByID(ctx context.Context, gameID int64) (*models.Game, error) {
 	game, err := s.gameRepo.GetByID(ctx, gameID)
@@ -217,6 +495,8 @@ func (s *GameService) GetGameByID(ctx context.Context, gameID int64) (*models.Ga
 		game.Creator = creator
 	}
 
+	game.SpectatorCount = s.spectators.GetSpectatorCount(strconv.FormatInt(game.ID, 10))
+
 	return game, nil
 }
 
@@ -252,7 +532,9 @@ func (s *GameService) ListGames(ctx context.Context, status *models.GameStatus,
 	}
 
 	// Populate players for each game
-	for _, game := range games {
+	gameIDs := make([]string, len(games))
+	for i, game := range games {
+		gameIDs[i] = strconv.FormatInt(game.ID, 10)
 		players, err := s.gameRepo.GetPlayers(ctx, game.ID)
 		if err == nil {
 			game.Players = players
@@ -265,6 +547,11 @@ func (s *GameService) ListGames(ctx context.Context, status *models.GameStatus,
 		}
 	}
 
+	spectators := s.spectators.GetSpectatorCounts(gameIDs)
+	for _, game := range games {
+		game.SpectatorCount = spectators[strconv.FormatInt(game.ID, 10)]
+	}
+
 	return &models.GameListResponse{
 		Games: games,
 		Total: total,
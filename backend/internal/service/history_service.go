@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/repository/postgres"
+)
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrSameUser     = errors.New("cannot compare a player with themselves")
+)
+
+// HistoryService serves finished games: a player's match history, their
+// records against other players, and the scoreboard and timeline of a
+// single game
+type HistoryService struct {
+	gameRepo   *postgres.GameRepository
+	userRepo   *postgres.UserRepository
+	moveRepo   *postgres.MoveRepository
+	resultRepo *postgres.ResultRepository
+}
+
+func NewHistoryService(gameRepo *postgres.GameRepository, userRepo *postgres.UserRepository, moveRepo *postgres.MoveRepository, resultRepo *postgres.ResultRepository) *HistoryService {
+	return &HistoryService{
+		gameRepo:   gameRepo,
+		userRepo:   userRepo,
+		moveRepo:   moveRepo,
+		resultRepo: resultRepo,
+	}
+}
+
+// ListUserGames returns a user's finished games, newest first
+func (s *HistoryService) ListUserGames(ctx context.Context, userID int64, filter *models.MatchHistoryFilter) (*models.MatchHistoryResponse, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	entries, total, err := s.resultRepo.ListByUser(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) > 0 {
+		gameIDs := make([]int64, len(entries))
+		for i, entry := range entries {
+			gameIDs[i] = entry.GameID
+		}
+
+		opponents, err := s.resultRepo.GetOpponents(ctx, gameIDs, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if o, ok := opponents[entry.GameID]; ok {
+				entry.Opponents = o
+			}
+			entry.DurationSeconds = gameDuration(entry.StartedAt, entry.CompletedAt)
+		}
+	}
+
+	return &models.MatchHistoryResponse{
+		Games: entries,
+		Total: total,
+	}, nil
+}
+
+// GetSummary returns a finished game's scoreboard and turn-by-turn timeline
+func (s *HistoryService) GetSummary(ctx context.Context, gameID int64) (*models.GameSummary, error) {
+	game, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+
+	if game.Status != models.GameStatusCompleted {
+		return nil, ErrGameNotFinished
+	}
+
+	results, err := s.resultRepo.GetByGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline, err := s.moveRepo.GetTimeline(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GameSummary{
+		FinalStandings: newFinalStandings(game, results),
+		RoomCode:       game.RoomCode,
+		StartedAt:      game.StartedAt,
+		Timeline:       timeline,
+	}, nil
+}
+
+// GetHeadToHead returns how userID has done against opponentID in the games
+// they both finished
+func (s *HistoryService) GetHeadToHead(ctx context.Context, userID, opponentID int64) (*models.HeadToHead, error) {
+	if userID == opponentID {
+		return nil, ErrSameUser
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+	opponent, err := s.userRepo.GetByID(ctx, opponentID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	h, err := s.resultRepo.GetHeadToHead(ctx, userID, opponentID)
+	if err != nil {
+		return nil, err
+	}
+	h.OpponentUsername = opponent.Username
+
+	return h, nil
+}
+
+// GetRivals returns userID's records against the human opponents they have
+// played most
+func (s *HistoryService) GetRivals(ctx context.Context, userID int64, limit int) ([]*models.HeadToHead, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if limit <= 0 || limit > 50 {
+		limit = 10
+	}
+
+	return s.resultRepo.GetRivals(ctx, userID, limit)
+}
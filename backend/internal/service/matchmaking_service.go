@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/repository/postgres"
+	"splendor-backend/pkg/database"
+)
+
+var ErrNotQueued = errors.New("you are not in the matchmaking queue")
+
+// MatchFunc is called with every game the matchmaker creates and starts
+type MatchFunc func(ctx context.Context, game *models.Game)
+
+// MatchmakingConfig sets how far apart in rating matched players may be.
+// The window starts at BaseWindow and grows by WindowGrowth per second of
+// waiting, up to MaxWindow.
+type MatchmakingConfig struct {
+	BaseWindow   float64
+	WindowGrowth float64
+	MaxWindow    float64
+	Interval     time.Duration // Time between matching passes
+}
+
+type queueMode struct {
+	numPlayers int
+	ranked     bool
+}
+
+// errGroupTaken rolls back a match whose players were claimed elsewhere
+var errGroupTaken = errors.New("group was claimed by another matching pass")
+
+// MatchmakingService groups queued players of similar rating into games.
+// The queue is kept in Postgres, so it survives restarts and every server
+// instance can run matching passes over it.
+type MatchmakingService struct {
+	db        *database.DB
+	queueRepo *postgres.MatchmakingRepository
+	games     *GameService
+	ratings   *RatingService
+	config    MatchmakingConfig
+	onMatch   MatchFunc
+}
+
+func NewMatchmakingService(db *database.DB, queueRepo *postgres.MatchmakingRepository, games *GameService, ratings *RatingService, config MatchmakingConfig) *MatchmakingService {
+	return &MatchmakingService{
+		db:        db,
+		queueRepo: queueRepo,
+		games:     games,
+		ratings:   ratings,
+		config:    config,
+	}
+}
+
+// OnMatch sets the callback used to announce created games
+func (s *MatchmakingService) OnMatch(fn MatchFunc) {
+	s.onMatch = fn
+}
+
+// Join puts a player in the queue for a player count and mode. Joining
+// again replaces the earlier request.
+func (s *MatchmakingService) Join(ctx context.Context, userID int64, req *models.JoinQueueRequest) (*models.QueueStatus, error) {
+	rating, err := s.ratings.CurrentRating(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &models.QueueEntry{
+		UserID:     userID,
+		NumPlayers: req.NumPlayers,
+		Ranked:     req.Ranked,
+		Rating:     rating,
+		JoinedAt:   time.Now(),
+	}
+	if err := s.queueRepo.Join(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return s.status(ctx, entry, time.Now())
+}
+
+// Leave takes a player out of the queue
+func (s *MatchmakingService) Leave(ctx context.Context, userID int64) error {
+	left, err := s.queueRepo.Leave(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !left {
+		return ErrNotQueued
+	}
+	return nil
+}
+
+// Status reports whether a player is waiting, and the game they were put in
+// once matched
+func (s *MatchmakingService) Status(ctx context.Context, userID int64) (*models.QueueStatus, error) {
+	entry, err := s.queueRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return &models.QueueStatus{Status: models.QueueIdle}, nil
+	}
+
+	return s.status(ctx, entry, time.Now())
+}
+
+func (s *MatchmakingService) status(ctx context.Context, entry *models.QueueEntry, now time.Time) (*models.QueueStatus, error) {
+	if entry.GameID != nil {
+		return &models.QueueStatus{Status: models.QueueMatched, GameID: *entry.GameID}, nil
+	}
+
+	queued, err := s.queueRepo.CountWaiting(ctx, entry.NumPlayers, entry.Ranked)
+	if err != nil {
+		return nil, err
+	}
+
+	joinedAt := entry.JoinedAt
+	return &models.QueueStatus{
+		Status:      models.QueueWaiting,
+		NumPlayers:  entry.NumPlayers,
+		Ranked:      entry.Ranked,
+		Rating:      entry.Rating,
+		Window:      s.window(entry, now),
+		JoinedAt:    &joinedAt,
+		WaitSeconds: int(now.Sub(joinedAt).Seconds()),
+		Queued:      queued,
+	}, nil
+}
+
+// Run makes a matching pass every interval until ctx is done
+func (s *MatchmakingService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.matchAll(ctx)
+		}
+	}
+}
+
+// matchAll forms every group it can out of the queue and starts a game for
+// each. A group is claimed, matched and its game created in one
+// transaction, so a failure leaves no half-built game and the players keep
+// their places, and a group another instance is matching is skipped.
+func (s *MatchmakingService) matchAll(ctx context.Context) {
+	entries, err := s.queueRepo.GetWaiting(ctx)
+	if err != nil {
+		log.Printf("Failed to load matchmaking queue: %v", err)
+		return
+	}
+
+	for _, group := range s.takeGroups(entries, time.Now()) {
+		var game *models.Game
+		err := s.db.InTx(ctx, func(ctx context.Context) error {
+			userIDs := make([]int64, len(group))
+			for i, entry := range group {
+				userIDs[i] = entry.UserID
+			}
+
+			claimed, err := s.queueRepo.Claim(ctx, userIDs)
+			if err != nil {
+				return err
+			}
+			if !claimed {
+				return errGroupTaken
+			}
+
+			game, err = s.startMatch(ctx, userIDs, group[0].Ranked)
+			if err != nil {
+				return err
+			}
+
+			return s.queueRepo.SetMatched(ctx, userIDs, game.ID)
+		})
+		if err == errGroupTaken {
+			continue
+		}
+		if err != nil {
+			log.Printf("Failed to start matched game: %v", err)
+			continue
+		}
+
+		if s.onMatch != nil {
+			s.onMatch(ctx, game)
+		}
+	}
+}
+
+// takeGroups forms full groups out of the waiting players. The
+// longest-waiting player of each mode anchors a group and is matched with
+// the closest-rated players that both sides' windows accept.
+func (s *MatchmakingService) takeGroups(queue []*models.QueueEntry, now time.Time) [][]*models.QueueEntry {
+	byMode := make(map[queueMode][]*models.QueueEntry)
+	for _, entry := range queue {
+		mode := queueMode{numPlayers: entry.NumPlayers, ranked: entry.Ranked}
+		byMode[mode] = append(byMode[mode], entry)
+	}
+
+	groups := [][]*models.QueueEntry{}
+	for mode, entries := range byMode {
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].JoinedAt.Before(entries[j].JoinedAt)
+		})
+
+		taken := make(map[int64]bool)
+		for _, anchor := range entries {
+			if taken[anchor.UserID] {
+				continue
+			}
+
+			candidates := []*models.QueueEntry{}
+			for _, other := range entries {
+				if other == anchor || taken[other.UserID] {
+					continue
+				}
+				diff := math.Abs(other.Rating - anchor.Rating)
+				if diff <= s.window(anchor, now) && diff <= s.window(other, now) {
+					candidates = append(candidates, other)
+				}
+			}
+			if len(candidates) < mode.numPlayers-1 {
+				continue
+			}
+
+			sort.SliceStable(candidates, func(i, j int) bool {
+				return math.Abs(candidates[i].Rating-anchor.Rating) < math.Abs(candidates[j].Rating-anchor.Rating)
+			})
+
+			group := append([]*models.QueueEntry{anchor}, candidates[:mode.numPlayers-1]...)
+			for _, entry := range group {
+				taken[entry.UserID] = true
+			}
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
+
+// window is the rating difference a queued player currently accepts
+func (s *MatchmakingService) window(entry *models.QueueEntry, now time.Time) float64 {
+	waited := now.Sub(entry.JoinedAt).Seconds()
+	return math.Min(s.config.BaseWindow+s.config.WindowGrowth*waited, s.config.MaxWindow)
+}
+
+// startMatch creates a game for a group, seats it in random order and
+// starts it
+func (s *MatchmakingService) startMatch(ctx context.Context, userIDs []int64, ranked bool) (*models.Game, error) {
+	seats := append([]int64{}, userIDs...)
+	rand.Shuffle(len(seats), func(i, j int) {
+		seats[i], seats[j] = seats[j], seats[i]
+	})
+
+	return s.games.CreateStartedGame(ctx, seats, ranked)
+}
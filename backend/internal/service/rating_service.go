@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/rating"
+	"splendor-backend/internal/repository/postgres"
+)
+
+// isRated reports whether a finished game changes ratings: it must be ranked
+// and have at least two players, none of them bots. Bot seats can only be
+// left over from before bots were kept out of ranked games. Hint use is not
+// checked: ranked games never allow hints, and in other games it changes
+// nothing, so player statistics count hinted games like any other.
+func isRated(game *models.Game, results []*models.GameResult) bool {
+	if !game.Ranked || len(results) < 2 {
+		return false
+	}
+	for _, result := range results {
+		if result.IsBot {
+			return false
+		}
+	}
+	return true
+}
+
+// RatingService keeps Elo skill ratings. Only ranked games are rated, since
+// undo and hints are available in the others.
+type RatingService struct {
+	ratingRepo *postgres.RatingRepository
+	userRepo   *postgres.UserRepository
+	seasons    *SeasonService
+	config     rating.Config
+}
+
+func NewRatingService(ratingRepo *postgres.RatingRepository, userRepo *postgres.UserRepository, seasons *SeasonService, config rating.Config) *RatingService {
+	return &RatingService{
+		ratingRepo: ratingRepo,
+		userRepo:   userRepo,
+		seasons:    seasons,
+		config:     config,
+	}
+}
+
+// RateGame updates the ratings of everyone in a finished game from their
+// placements, if the game is rated
+func (s *RatingService) RateGame(ctx context.Context, game *models.Game, results []*models.GameResult) error {
+	if !isRated(game, results) {
+		return nil
+	}
+
+	userIDs := make([]int64, len(results))
+	for i, result := range results {
+		userIDs[i] = result.UserID
+	}
+
+	current, err := s.ratingRepo.GetRatings(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+
+	players := make([]rating.Player, len(results))
+	for i, result := range results {
+		players[i] = rating.Player{
+			UserID:    result.UserID,
+			Rating:    s.config.Initial,
+			Placement: result.Placement,
+		}
+		if r, ok := current[result.UserID]; ok {
+			players[i].Rating = r.Rating
+			players[i].Games = r.GamesRated
+		}
+	}
+
+	// Games count toward the season running when they finish
+	seasonID, err := s.seasons.ActiveSeasonID(ctx)
+	if err != nil {
+		return err
+	}
+
+	updated := s.config.Update(players)
+	for _, p := range players {
+		err := s.ratingRepo.ApplyChange(ctx, &models.RatingChange{
+			UserID:       p.UserID,
+			GameID:       game.ID,
+			SeasonID:     seasonID,
+			RatingBefore: p.Rating,
+			RatingAfter:  updated[p.UserID],
+			Placement:    p.Placement,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CurrentRating returns a player's rating, or the initial rating if they
+// have no rated games
+func (s *RatingService) CurrentRating(ctx context.Context, userID int64) (float64, error) {
+	current, err := s.ratingRepo.GetRating(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if current == nil {
+		return s.config.Initial, nil
+	}
+	return current.Rating, nil
+}
+
+// GetUserRating returns a player's rating with their most recent changes.
+// Players without rated games get the initial, provisional rating.
+func (s *RatingService) GetUserRating(ctx context.Context, userID int64, limit int) (*models.RatingResponse, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	current, err := s.ratingRepo.GetRating(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		current = &models.PlayerRating{
+			UserID:     userID,
+			Rating:     s.config.Initial,
+			PeakRating: s.config.Initial,
+		}
+	}
+	current.Provisional = s.config.IsProvisional(current.GamesRated)
+
+	history, err := s.ratingRepo.GetHistory(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RatingResponse{
+		Rating:  current,
+		History: history,
+	}, nil
+}
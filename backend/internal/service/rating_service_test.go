@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+
+	"splendor-backend/internal/domain/models"
+)
+
+func TestIsRated(t *testing.T) {
+	humans := []*models.GameResult{{UserID: 1, Placement: 1}, {UserID: 2, Placement: 2}}
+	withBot := []*models.GameResult{{UserID: 1, Placement: 1}, {UserID: 2, Placement: 2, IsBot: true}}
+
+	tests := []struct {
+		name    string
+		game    *models.Game
+		results []*models.GameResult
+		want    bool
+	}{
+		{"ranked", &models.Game{Ranked: true}, humans, true},
+		{"casual", &models.Game{}, humans, false},
+		{"casual with hints", &models.Game{AllowHints: true, HintsUsed: true}, humans, false},
+		{"ranked with a bot seat", &models.Game{Ranked: true}, withBot, false},
+		{"ranked with one player", &models.Game{Ranked: true}, humans[:1], false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRated(tt.game, tt.results); got != tt.want {
+				t.Errorf("isRated = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/repository/postgres"
+	"splendor-backend/pkg/database"
+)
+
+var (
+	ErrSeasonActive   = errors.New("a season is already running")
+	ErrNoActiveSeason = errors.New("no season is running")
+	ErrSeasonNotFound = errors.New("season not found")
+)
+
+// SeasonService runs ranked seasons. Starting a season softly resets every
+// rating toward the initial rating; ending one archives its final standings.
+type SeasonService struct {
+	db         *database.DB
+	seasonRepo *postgres.SeasonRepository
+	ratingRepo *postgres.RatingRepository
+
+	initialRating float64
+	resetFactor   float64 // Share of a rating's distance from initial kept at a reset
+	minGames      int     // Rated games in the season needed to be ranked
+}
+
+func NewSeasonService(db *database.DB, seasonRepo *postgres.SeasonRepository, ratingRepo *postgres.RatingRepository, initialRating, resetFactor float64, minGames int) *SeasonService {
+	return &SeasonService{
+		db:            db,
+		seasonRepo:    seasonRepo,
+		ratingRepo:    ratingRepo,
+		initialRating: initialRating,
+		resetFactor:   resetFactor,
+		minGames:      minGames,
+	}
+}
+
+// StartSeason starts a new season and softly resets all ratings. Both
+// happen in one transaction, so a season never starts without its reset.
+func (s *SeasonService) StartSeason(ctx context.Context, name string) (*models.Season, error) {
+	season := &models.Season{Name: name}
+	err := s.db.InTx(ctx, func(ctx context.Context) error {
+		if err := s.seasonRepo.Create(ctx, season); err != nil {
+			if errors.Is(err, postgres.ErrSeasonActive) {
+				return ErrSeasonActive
+			}
+			return err
+		}
+
+		return s.ratingRepo.SoftReset(ctx, s.initialRating, s.resetFactor)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return season, nil
+}
+
+// EndSeason ends the running season and archives its final standings, in
+// one transaction
+func (s *SeasonService) EndSeason(ctx context.Context) (*models.Season, error) {
+	var season *models.Season
+	err := s.db.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		season, err = s.seasonRepo.GetActive(ctx)
+		if err != nil {
+			return err
+		}
+		if season == nil {
+			return ErrNoActiveSeason
+		}
+
+		ended, err := s.seasonRepo.End(ctx, season)
+		if err != nil {
+			return err
+		}
+		if !ended {
+			return ErrNoActiveSeason
+		}
+
+		return s.seasonRepo.ArchiveStandings(ctx, season.ID, s.minGames)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return season, nil
+}
+
+// ActiveSeasonID returns the ID of the running season, or nil if none runs
+func (s *SeasonService) ActiveSeasonID(ctx context.Context) (*int64, error) {
+	season, err := s.seasonRepo.GetActive(ctx)
+	if err != nil || season == nil {
+		return nil, err
+	}
+	return &season.ID, nil
+}
+
+// ListSeasons returns all seasons, newest first
+func (s *SeasonService) ListSeasons(ctx context.Context) ([]*models.Season, error) {
+	return s.seasonRepo.List(ctx)
+}
+
+// GetLeaderboard returns the standings of a season, given by ID or as
+// "current": live standings while it runs, the archived ones after it ends
+func (s *SeasonService) GetLeaderboard(ctx context.Context, season string, limit, offset int) (*models.Season, []*models.LeaderboardEntry, error) {
+	var found *models.Season
+	if season == "current" {
+		active, err := s.seasonRepo.GetActive(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if active == nil {
+			return nil, nil, ErrNoActiveSeason
+		}
+		found = active
+	} else {
+		id, err := strconv.ParseInt(season, 10, 64)
+		if err != nil {
+			return nil, nil, ErrSeasonNotFound
+		}
+		found, err = s.seasonRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, nil, ErrSeasonNotFound
+		}
+	}
+
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	var entries []*models.LeaderboardEntry
+	var err error
+	if found.EndedAt == nil {
+		entries, err = s.seasonRepo.GetStandings(ctx, found.ID, s.minGames, limit, offset)
+	} else {
+		entries, err = s.seasonRepo.GetArchivedStandings(ctx, found.ID, limit, offset)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return found, entries, nil
+}
@@ -4,16 +4,23 @@ import (
 	"context"
 
 	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/rating"
 	"splendor-backend/internal/repository/postgres"
 )
 
 type StatsService struct {
 	statsRepo *postgres.StatsRepository
+	ratings   rating.Config
+
+	// Rated games a player needs to appear on the leaderboard
+	leaderboardMinGames int
 }
 
-func NewStatsService(statsRepo *postgres.StatsRepository) *StatsService {
+func NewStatsService(statsRepo *postgres.StatsRepository, ratings rating.Config, leaderboardMinGames int) *StatsService {
 	return &StatsService{
-		statsRepo: statsRepo,
+		statsRepo:           statsRepo,
+		ratings:             ratings,
+		leaderboardMinGames: leaderboardMinGames,
 	}
 }
 
@@ -22,31 +29,62 @@ func (s *StatsService) GetUserStats(ctx context.Context, userID int64) (*models.
 	return s.statsRepo.GetUserStats(ctx, userID)
 }
 
-// GetLeaderboard retrieves the leaderboard
+// GetLeaderboard retrieves the leaderboard, ordered by rating
 func (s *StatsService) GetLeaderboard(ctx context.Context, limit, offset int) ([]*models.LeaderboardEntry, error) {
-	return s.statsRepo.GetLeaderboard(ctx, limit, offset)
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	entries, err := s.statsRepo.GetLeaderboard(ctx, s.leaderboardMinGames, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, entry := range entries {
+		entry.Rank = offset + i + 1
+		entry.Provisional = s.ratings.IsProvisional(entry.GamesRated)
+	}
+
+	return entries, nil
 }
 
-// UpdateGameStats updates statistics after a game ends
-func (s *StatsService) UpdateGameStats(ctx context.Context, game *models.Game, players []*models.GamePlayer) error {
-	for _, player := range players {
-		stats, err := s.statsRepo.GetUserStats(ctx, player.UserID)
+// UpdateGameStats adds a finished game's results to each player's
+// statistics. The results must already be stored, since the favorite gem
+// type is worked out from all of a player's results. Every finished game
+// counts, hinted ones included; only ratings are limited to ranked games,
+// which never allow hints.
+func (s *StatsService) UpdateGameStats(ctx context.Context, game *models.Game, results []*models.GameResult) error {
+	for _, result := range results {
+		stats, err := s.statsRepo.GetUserStats(ctx, result.UserID)
 		if err != nil {
 			// Create new stats if not exists
 			stats = &models.GameStatistics{
-				UserID: player.UserID,
+				UserID: result.UserID,
 			}
 		}
 
 		stats.TotalGames++
-		if game.WinnerID != nil && *game.WinnerID == player.UserID {
+		if game.WinnerID != nil && *game.WinnerID == result.UserID {
 			stats.TotalWins++
 		} else {
 			stats.TotalLosses++
 		}
 
-		// Update average points
-		stats.AveragePoints = float64(stats.TotalWins*player.VictoryPoints+int(stats.AveragePoints)*stats.TotalLosses) / float64(stats.TotalGames)
+		// Running averages over all counted games
+		n := float64(stats.TotalGames)
+		stats.AveragePoints += (float64(result.VictoryPoints) - stats.AveragePoints) / n
+		stats.AverageMovesPerGame += (float64(result.Moves) - stats.AverageMovesPerGame) / n
+
+		stats.TotalNoblesEarned += result.NoblesEarned
+		stats.TotalCardsPurchased += result.CardsPurchased
+
+		stats.FavoriteGemType, err = s.statsRepo.GetFavoriteGemType(ctx, result.UserID)
+		if err != nil {
+			return err
+		}
 
 		if err := s.statsRepo.UpdateStats(ctx, stats); err != nil {
 			return err
@@ -55,3 +93,56 @@ func (s *StatsService) UpdateGameStats(ctx context.Context, game *models.Game, p
 
 	return nil
 }
+
+// UpdateBalanceStats adds a finished game to the card, noble and seat
+// statistics. Unlike player statistics these count every finished game.
+func (s *StatsService) UpdateBalanceStats(ctx context.Context, gameID int64) error {
+	return s.statsRepo.AddGameBalance(ctx, gameID)
+}
+
+// GetCardStats returns the balance statistics of all finished games: how
+// often each card is bought and each noble claimed, how their owners fare,
+// and the win rate of each seat by player count
+func (s *StatsService) GetCardStats(ctx context.Context) (*models.CardStatsResponse, error) {
+	seats, err := s.statsRepo.GetSeatStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every finished game has exactly one first seat
+	games := 0
+	for _, seat := range seats {
+		seat.WinRate = ratio(seat.Wins, seat.Games)
+		if seat.SeatPosition == 0 {
+			games += seat.Games
+		}
+	}
+
+	cards, err := s.statsRepo.GetCardStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, card := range cards {
+		card.PurchaseRate = ratio(card.Purchases, games)
+		card.BuyerWinRate = ratio(card.WinnerPurchases, card.Purchases)
+		if card.ExpectedWins > 0 {
+			card.WinLift = float64(card.WinnerPurchases) / card.ExpectedWins
+		}
+	}
+
+	nobles, err := s.statsRepo.GetNobleStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, noble := range nobles {
+		noble.ClaimRate = ratio(noble.Claims, games)
+		noble.ClaimerWinRate = ratio(noble.WinnerClaims, noble.Claims)
+	}
+
+	return &models.CardStatsResponse{
+		Games:  games,
+		Cards:  cards,
+		Nobles: nobles,
+		Seats:  seats,
+	}, nil
+}
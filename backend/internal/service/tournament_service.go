@@ -0,0 +1,757 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"sort"
+	"time"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/repository/postgres"
+	"splendor-backend/pkg/database"
+)
+
+var (
+	ErrTournamentNotFound     = errors.New("tournament not found")
+	ErrTournamentStarted      = errors.New("tournament has already started")
+	ErrAlreadyRegistered      = errors.New("you are already registered")
+	ErrNotRegistered          = errors.New("you are not registered")
+	ErrNotOrganizer           = errors.New("only the organizer can start the tournament")
+	ErrTournamentNotStarted   = errors.New("tournament has not started")
+	ErrInvalidTournamentRules = errors.New("only swiss tournaments have a round count")
+)
+
+// defaultSwissRounds is used when a Swiss tournament is created without a
+// round count
+const defaultSwissRounds = 3
+
+// staleSeating is how long a table may stay claimed without a game before
+// it is released and seated again
+const staleSeating = 5 * time.Minute
+
+// TableFunc is called with every game a tournament round creates
+type TableFunc func(ctx context.Context, tournament *models.Tournament, game *models.Game)
+
+// TournamentService runs tournaments. Once the organizer starts one, every
+// round is seated automatically: a game is created and started for each
+// table, and the next round is seated when the last table of the current
+// one finishes.
+//
+// Swiss tournaments play a fixed number of rounds. Each round groups players
+// with similar match points while avoiding repeat meetings. A seat earns the
+// table size minus its placement in match points, so a 4-player table pays
+// 3, 2, 1 and 0. Smaller tables are scaled to the same range, so a win is
+// worth the same wherever a player was seated.
+//
+// Elimination tournaments advance each table's winner until one player is
+// left. Tables are filled by seed so the strongest players meet last.
+type TournamentService struct {
+	db             *database.DB
+	tournamentRepo *postgres.TournamentRepository
+	games          *GameService
+	ratings        *RatingService
+	onTable        TableFunc
+}
+
+func NewTournamentService(db *database.DB, tournamentRepo *postgres.TournamentRepository, games *GameService, ratings *RatingService) *TournamentService {
+	return &TournamentService{
+		db:             db,
+		tournamentRepo: tournamentRepo,
+		games:          games,
+		ratings:        ratings,
+	}
+}
+
+// OnTableStarted sets the callback used to announce tournament games
+func (s *TournamentService) OnTableStarted(fn TableFunc) {
+	s.onTable = fn
+}
+
+// Create opens a tournament for registration
+func (s *TournamentService) Create(ctx context.Context, userID int64, req *models.CreateTournamentRequest) (*models.Tournament, error) {
+	rounds := req.Rounds
+	if req.Format == models.TournamentSwiss && rounds == 0 {
+		rounds = defaultSwissRounds
+	}
+	if req.Format == models.TournamentElimination && rounds != 0 {
+		return nil, ErrInvalidTournamentRules
+	}
+
+	t := &models.Tournament{
+		Name:      req.Name,
+		Format:    req.Format,
+		TableSize: req.TableSize,
+		Rounds:    rounds,
+		Ranked:    req.Ranked,
+		CreatedBy: userID,
+	}
+	if err := s.tournamentRepo.Create(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// List returns tournaments, newest first, optionally by status
+func (s *TournamentService) List(ctx context.Context, status string, limit, offset int) ([]*models.Tournament, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	return s.tournamentRepo.List(ctx, status, limit, offset)
+}
+
+// Get returns a tournament with its players and tables
+func (s *TournamentService) Get(ctx context.Context, tournamentID int64) (*models.Tournament, error) {
+	t, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, ErrTournamentNotFound
+	}
+
+	t.Players, err = s.tournamentRepo.GetPlayers(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Tables, err = s.tournamentRepo.GetTables(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Register signs a player up for a tournament that has not started
+func (s *TournamentService) Register(ctx context.Context, tournamentID, userID int64) (*models.Tournament, error) {
+	t, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, ErrTournamentNotFound
+	}
+	if t.Status != models.TournamentRegistration {
+		return nil, ErrTournamentStarted
+	}
+
+	added, err := s.tournamentRepo.AddPlayer(ctx, tournamentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, ErrAlreadyRegistered
+	}
+
+	return s.Get(ctx, tournamentID)
+}
+
+// Unregister withdraws a player from a tournament that has not started
+func (s *TournamentService) Unregister(ctx context.Context, tournamentID, userID int64) error {
+	t, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		return ErrTournamentNotFound
+	}
+	if t.Status != models.TournamentRegistration {
+		return ErrTournamentStarted
+	}
+
+	removed, err := s.tournamentRepo.RemovePlayer(ctx, tournamentID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrNotRegistered
+	}
+
+	return nil
+}
+
+// Start closes registration, seeds the players by rating and seats the
+// first round. Only the organizer can start a tournament.
+func (s *TournamentService) Start(ctx context.Context, tournamentID, userID int64) (*models.Tournament, error) {
+	t, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, ErrTournamentNotFound
+	}
+	if t.CreatedBy != userID {
+		return nil, ErrNotOrganizer
+	}
+	if t.Status != models.TournamentRegistration {
+		return nil, ErrTournamentStarted
+	}
+
+	players, err := s.tournamentRepo.GetPlayers(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(players) < 2 {
+		return nil, ErrNotEnoughPlayers
+	}
+
+	ratings := make(map[int64]float64, len(players))
+	for _, player := range players {
+		ratings[player.UserID], err = s.ratings.CurrentRating(ctx, player.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Registration order breaks rating ties
+	sort.SliceStable(players, func(i, j int) bool {
+		return ratings[players[i].UserID] > ratings[players[j].UserID]
+	})
+
+	err = s.db.InTx(ctx, func(ctx context.Context) error {
+		started, err := s.tournamentRepo.Start(ctx, t)
+		if err != nil {
+			return err
+		}
+		if !started {
+			return ErrTournamentStarted
+		}
+
+		for i, player := range players {
+			if err := s.tournamentRepo.SetSeed(ctx, tournamentID, player.UserID, i+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A failure here leaves the round partly seated; Run seats the rest
+	if err := s.advance(ctx, tournamentID); err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, tournamentID)
+}
+
+// RecordResult collects a finished game's result if it was a tournament
+// game. When it was the last table of its round, the next round is seated
+// or the tournament is completed.
+func (s *TournamentService) RecordResult(ctx context.Context, gameID int64) error {
+	table, err := s.tournamentRepo.FinishTableByGame(ctx, gameID)
+	if err != nil || table == nil {
+		return err
+	}
+
+	return s.advance(ctx, table.TournamentID)
+}
+
+// Run resumes stalled tournaments every interval until ctx is cancelled.
+// A tournament stalls when seating a round fails partway or a finished
+// game is never reported to it; both are picked up here.
+func (s *TournamentService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.ResumeAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ResumeAll brings every running tournament up to date
+func (s *TournamentService) ResumeAll(ctx context.Context) {
+	if err := s.tournamentRepo.ReleaseStaleTables(ctx, staleSeating); err != nil {
+		log.Printf("Failed to release stale tournament tables: %v", err)
+	}
+	if err := s.tournamentRepo.FinishCompletedTables(ctx); err != nil {
+		log.Printf("Failed to finish completed tournament tables: %v", err)
+	}
+
+	ids, err := s.tournamentRepo.GetInProgressIDs(ctx)
+	if err != nil {
+		log.Printf("Failed to get running tournaments: %v", err)
+		return
+	}
+
+	for _, id := range ids {
+		if err := s.advance(ctx, id); err != nil {
+			log.Printf("Failed to resume tournament %d: %v", id, err)
+		}
+	}
+}
+
+// GetStandings ranks a tournament's players by its format's rules
+func (s *TournamentService) GetStandings(ctx context.Context, tournamentID int64) ([]*models.TournamentStanding, error) {
+	t, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		return nil, ErrTournamentNotFound
+	}
+	if t.Status == models.TournamentRegistration {
+		return nil, ErrTournamentNotStarted
+	}
+
+	players, err := s.tournamentRepo.GetPlayers(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := s.tournamentRepo.GetTables(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.tournamentRepo.GetResults(ctx, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Format == models.TournamentElimination {
+		return eliminationStandings(players, tables, results), nil
+	}
+	return swissStandings(t.TableSize, players, tables, results), nil
+}
+
+// advance brings a running tournament up to date. It seats any table of
+// the current round that is missing, and once every table of the round has
+// finished it moves on to the next round or completes the tournament. Every
+// step is claimed, so it is safe to run concurrently and to repeat.
+func (s *TournamentService) advance(ctx context.Context, tournamentID int64) error {
+	t, err := s.tournamentRepo.GetByID(ctx, tournamentID)
+	if err != nil {
+		return err
+	}
+	if t.Status != models.TournamentInProgress {
+		return nil
+	}
+
+	players, err := s.tournamentRepo.GetPlayers(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	tables, err := s.tournamentRepo.GetTables(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	results, err := s.tournamentRepo.GetResults(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+
+	// The current round's tables are recomputed from the rounds before it,
+	// so a partly seated round comes out the same and only gaps are filled
+	groups := roundTables(t, players, tables, results, t.CurrentRound)
+	current := []*models.TournamentTable{}
+	for _, table := range tables {
+		if table.Round == t.CurrentRound {
+			current = append(current, table)
+		}
+	}
+	if len(current) < len(groups) {
+		return s.seatRound(ctx, t, groups, current)
+	}
+
+	for _, table := range current {
+		if table.Status == models.TableSeating || table.Status == models.TablePlaying {
+			return nil
+		}
+	}
+
+	if t.Format == models.TournamentSwiss && t.CurrentRound >= t.Rounds {
+		standings := swissStandings(t.TableSize, players, tables, results)
+		_, err := s.tournamentRepo.Complete(ctx, t.ID, t.CurrentRound, &standings[0].UserID)
+		return err
+	}
+
+	if t.Format == models.TournamentElimination {
+		remaining := eliminationEntrants(players, tables, results, t.CurrentRound+1)
+		if len(remaining) <= 1 {
+			var winnerID *int64
+			if len(remaining) == 1 {
+				winnerID = &remaining[0].UserID
+			}
+			_, err := s.tournamentRepo.Complete(ctx, t.ID, t.CurrentRound, winnerID)
+			return err
+		}
+	}
+
+	advanced, err := s.tournamentRepo.AdvanceRound(ctx, t.ID, t.CurrentRound)
+	if err != nil || !advanced {
+		return err
+	}
+
+	return s.advance(ctx, t.ID)
+}
+
+// roundTables splits the players of a round into tables. It only looks at
+// the rounds before it, so it gives the same tables however often it runs.
+// Elimination tables are filled by seed; Swiss tables group players by
+// their standings after the earlier rounds.
+func roundTables(t *models.Tournament, players []*models.TournamentPlayer, tables []*models.TournamentTable, results []*models.TournamentResult, round int) [][]int64 {
+	if t.Format == models.TournamentElimination {
+		entrants := eliminationEntrants(players, tables, results, round)
+		userIDs := make([]int64, len(entrants))
+		for i, player := range entrants {
+			userIDs[i] = player.UserID
+		}
+		return snakeTables(userIDs, t.TableSize)
+	}
+
+	earlier := []*models.TournamentTable{}
+	for _, table := range tables {
+		if table.Round < round {
+			earlier = append(earlier, table)
+		}
+	}
+	earlierResults := []*models.TournamentResult{}
+	for _, result := range results {
+		if result.Round < round {
+			earlierResults = append(earlierResults, result)
+		}
+	}
+
+	standings := swissStandings(t.TableSize, players, earlier, earlierResults)
+	userIDs := make([]int64, len(standings))
+	for i, standing := range standings {
+		userIDs[i] = standing.UserID
+	}
+	return swissTables(userIDs, t.TableSize, earlier)
+}
+
+// eliminationEntrants returns the players left in an elimination round, in
+// seed order: everyone in round 1, then the winners and byes of the round
+// before
+func eliminationEntrants(players []*models.TournamentPlayer, tables []*models.TournamentTable, results []*models.TournamentResult, round int) []*models.TournamentPlayer {
+	if round == 1 {
+		return players
+	}
+
+	winners := make(map[int64]bool)
+	for _, result := range results {
+		if result.Round == round-1 && result.Placement == 1 {
+			winners[result.UserID] = true
+		}
+	}
+	for _, table := range tables {
+		if table.Round == round-1 && table.Status == models.TableBye {
+			winners[table.PlayerIDs[0]] = true
+		}
+	}
+
+	entrants := []*models.TournamentPlayer{}
+	for _, player := range players {
+		if winners[player.UserID] {
+			entrants = append(entrants, player)
+		}
+	}
+	return entrants
+}
+
+// seatRound creates the tables of t's current round that are not seated
+// yet and starts a game at each. Each table is claimed before its game is
+// created; if the game can't be created the claim is released, so the
+// table is seated on a later pass.
+func (s *TournamentService) seatRound(ctx context.Context, t *models.Tournament, groups [][]int64, seated []*models.TournamentTable) error {
+	exists := make(map[int]bool, len(seated))
+	for _, table := range seated {
+		exists[table.TableNumber] = true
+	}
+
+	for i, group := range groups {
+		if exists[i+1] {
+			continue
+		}
+
+		table := &models.TournamentTable{
+			TournamentID: t.ID,
+			Round:        t.CurrentRound,
+			TableNumber:  i + 1,
+			PlayerIDs:    group,
+			Status:       models.TableSeating,
+		}
+		if len(group) == 1 {
+			table.Status = models.TableBye
+		}
+
+		claimed, err := s.tournamentRepo.ClaimTable(ctx, table)
+		if err != nil {
+			return err
+		}
+		if !claimed || table.Status == models.TableBye {
+			continue
+		}
+
+		seats := make([]int64, len(group))
+		copy(seats, group)
+		rand.Shuffle(len(seats), func(i, j int) {
+			seats[i], seats[j] = seats[j], seats[i]
+		})
+
+		game, err := s.games.CreateStartedGame(ctx, seats, t.Ranked)
+		if err != nil {
+			if err := s.tournamentRepo.ReleaseTable(ctx, table.ID); err != nil {
+				log.Printf("Failed to release table %d of tournament %d: %v", table.TableNumber, t.ID, err)
+			}
+			return err
+		}
+
+		if err := s.tournamentRepo.SeatTable(ctx, table.ID, game.ID); err != nil {
+			return err
+		}
+
+		if s.onTable != nil {
+			s.onTable(ctx, t, game)
+		}
+	}
+
+	return nil
+}
+
+// tableSizes splits n players into as few tables of at most size seats as
+// possible, with sizes differing by at most one and larger tables first
+func tableSizes(n, size int) []int {
+	count := (n + size - 1) / size
+	sizes := make([]int, count)
+	for i := range sizes {
+		sizes[i] = n / count
+		if i < n%count {
+			sizes[i]++
+		}
+	}
+	return sizes
+}
+
+// snakeTables deals seeded players across as few tables as possible in a
+// snake (1-2-3-3-2-1), so each table gets a fair mix of seeds
+func snakeTables(userIDs []int64, size int) [][]int64 {
+	count := (len(userIDs) + size - 1) / size
+	groups := make([][]int64, count)
+
+	for i, userID := range userIDs {
+		table := i % count
+		if (i/count)%2 == 1 {
+			table = count - 1 - table
+		}
+		groups[table] = append(groups[table], userID)
+	}
+
+	return groups
+}
+
+// swissTables groups players in standings order so each table holds players
+// with similar match points, preferring players who have not met yet. When
+// a player must sit out, it is the lowest ranked player without a bye.
+func swissTables(userIDs []int64, size int, earlier []*models.TournamentTable) [][]int64 {
+	met := make(map[[2]int64]int)
+	hadBye := make(map[int64]bool)
+	for _, table := range earlier {
+		if table.Status == models.TableBye {
+			hadBye[table.PlayerIDs[0]] = true
+			continue
+		}
+		for _, a := range table.PlayerIDs {
+			for _, b := range table.PlayerIDs {
+				if a != b {
+					met[[2]int64{a, b}]++
+				}
+			}
+		}
+	}
+
+	pool := make([]int64, len(userIDs))
+	copy(pool, userIDs)
+
+	sizes := tableSizes(len(pool), size)
+	var byeGroup []int64
+	if sizes[len(sizes)-1] == 1 {
+		sizes = sizes[:len(sizes)-1]
+		bye := len(pool) - 1
+		for i := len(pool) - 1; i >= 0; i-- {
+			if !hadBye[pool[i]] {
+				bye = i
+				break
+			}
+		}
+		byeGroup = []int64{pool[bye]}
+		pool = append(pool[:bye], pool[bye+1:]...)
+	}
+
+	groups := make([][]int64, 0, len(sizes)+1)
+	for _, seats := range sizes {
+		group := []int64{pool[0]}
+		pool = pool[1:]
+
+		for len(group) < seats {
+			best, bestMet := 0, -1
+			for i, candidate := range pool {
+				repeats := 0
+				for _, seated := range group {
+					repeats += met[[2]int64{seated, candidate}]
+				}
+				if bestMet == -1 || repeats < bestMet {
+					best, bestMet = i, repeats
+				}
+				if repeats == 0 {
+					break
+				}
+			}
+			group = append(group, pool[best])
+			pool = append(pool[:best], pool[best+1:]...)
+		}
+
+		groups = append(groups, group)
+	}
+
+	if byeGroup != nil {
+		groups = append(groups, byeGroup)
+	}
+	return groups
+}
+
+// swissStandings ranks players by match points, then Buchholz, then victory
+// points, then seed. A bye is worth a win at a full table.
+//
+// When players don't divide evenly into full tables, some tables are short.
+// Their match points are scaled to a full table's range, so winning a
+// 2-player table pays the same as winning a 4-player one.
+func swissStandings(tableSize int, players []*models.TournamentPlayer, tables []*models.TournamentTable, results []*models.TournamentResult) []*models.TournamentStanding {
+	standings, byUser := newStandings(players)
+
+	seats := make(map[int64]int)
+	for _, table := range tables {
+		seats[table.ID] = len(table.PlayerIDs)
+	}
+
+	for _, result := range results {
+		standing := byUser[result.UserID]
+		if standing == nil {
+			continue
+		}
+		standing.MatchPoints += matchPoints(tableSize, seats[result.TableID], result.Placement)
+		standing.VictoryPoints += result.VictoryPoints
+		standing.GamesPlayed++
+		if result.Placement == 1 {
+			standing.Wins++
+		}
+	}
+
+	for _, table := range tables {
+		if table.Status != models.TableBye {
+			continue
+		}
+		if standing := byUser[table.PlayerIDs[0]]; standing != nil {
+			standing.MatchPoints += float64(tableSize - 1)
+			standing.Byes++
+		}
+	}
+
+	for _, table := range tables {
+		if table.Status != models.TableFinished {
+			continue
+		}
+		for _, a := range table.PlayerIDs {
+			for _, b := range table.PlayerIDs {
+				if a != b && byUser[a] != nil && byUser[b] != nil {
+					byUser[a].Buchholz += byUser[b].MatchPoints
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.MatchPoints != b.MatchPoints {
+			return a.MatchPoints > b.MatchPoints
+		}
+		if a.Buchholz != b.Buchholz {
+			return a.Buchholz > b.Buchholz
+		}
+		if a.VictoryPoints != b.VictoryPoints {
+			return a.VictoryPoints > b.VictoryPoints
+		}
+		return a.Seed < b.Seed
+	})
+
+	return rankStandings(standings)
+}
+
+// matchPoints is what a placement at a table of seats players earns, scaled
+// so a table of any size pays from tableSize-1 for a win down to 0
+func matchPoints(tableSize, seats, placement int) float64 {
+	if seats < 2 {
+		return 0
+	}
+	return float64((tableSize-1)*(seats-placement)) / float64(seats-1)
+}
+
+// eliminationStandings ranks players by the round they reached, then by
+// their placement and points in it, then seed
+func eliminationStandings(players []*models.TournamentPlayer, tables []*models.TournamentTable, results []*models.TournamentResult) []*models.TournamentStanding {
+	standings, byUser := newStandings(players)
+
+	reached := make(map[int64]int)
+	lastPlacement := make(map[int64]int)
+	lastPoints := make(map[int64]int)
+
+	for _, table := range tables {
+		for _, userID := range table.PlayerIDs {
+			reached[userID] = max(reached[userID], table.Round)
+		}
+		if table.Status == models.TableBye {
+			byUser[table.PlayerIDs[0]].Byes++
+			lastPlacement[table.PlayerIDs[0]] = 1
+		}
+	}
+
+	for _, result := range results {
+		standing := byUser[result.UserID]
+		if standing == nil {
+			continue
+		}
+		standing.VictoryPoints += result.VictoryPoints
+		standing.GamesPlayed++
+		if result.Placement == 1 {
+			standing.Wins++
+		} else {
+			standing.EliminatedRound = result.Round
+		}
+		if result.Round == reached[result.UserID] {
+			lastPlacement[result.UserID] = result.Placement
+			lastPoints[result.UserID] = result.VictoryPoints
+		}
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i].UserID, standings[j].UserID
+		if reached[a] != reached[b] {
+			return reached[a] > reached[b]
+		}
+		if lastPlacement[a] != lastPlacement[b] {
+			return lastPlacement[a] < lastPlacement[b]
+		}
+		if lastPoints[a] != lastPoints[b] {
+			return lastPoints[a] > lastPoints[b]
+		}
+		return standings[i].Seed < standings[j].Seed
+	})
+
+	return rankStandings(standings)
+}
+
+func newStandings(players []*models.TournamentPlayer) ([]*models.TournamentStanding, map[int64]*models.TournamentStanding) {
+	standings := make([]*models.TournamentStanding, 0, len(players))
+	byUser := make(map[int64]*models.TournamentStanding, len(players))
+	for _, player := range players {
+		standing := &models.TournamentStanding{
+			UserID:   player.UserID,
+			Username: player.Username,
+			Seed:     player.Seed,
+		}
+		standings = append(standings, standing)
+		byUser[player.UserID] = standing
+	}
+	return standings, byUser
+}
+
+func rankStandings(standings []*models.TournamentStanding) []*models.TournamentStanding {
+	for i, standing := range standings {
+		standing.Rank = i + 1
+	}
+	return standings
+}
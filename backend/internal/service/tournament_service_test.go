@@ -0,0 +1,351 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"splendor-backend/internal/domain/models"
+)
+
+// seededPlayers returns players with user IDs 1..n, seeded in that order
+func seededPlayers(n int) []*models.TournamentPlayer {
+	players := make([]*models.TournamentPlayer, n)
+	for i := range players {
+		players[i] = &models.TournamentPlayer{UserID: int64(i + 1), Seed: i + 1}
+	}
+	return players
+}
+
+func standingIDs(standings []*models.TournamentStanding) []int64 {
+	ids := make([]int64, len(standings))
+	for i, standing := range standings {
+		ids[i] = standing.UserID
+	}
+	return ids
+}
+
+func TestTableSizes(t *testing.T) {
+	tests := []struct {
+		n, size int
+		want    []int
+	}{
+		{1, 4, []int{1}},
+		{4, 4, []int{4}},
+		{5, 4, []int{3, 2}},
+		{8, 4, []int{4, 4}},
+		{9, 4, []int{3, 3, 3}},
+		{10, 4, []int{4, 3, 3}},
+		{5, 2, []int{2, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		if got := tableSizes(tt.n, tt.size); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("tableSizes(%d, %d) = %v, want %v", tt.n, tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestSnakeTables(t *testing.T) {
+	tests := []struct {
+		name    string
+		userIDs []int64
+		size    int
+		want    [][]int64
+	}{
+		{
+			name:    "two tables of four",
+			userIDs: []int64{1, 2, 3, 4, 5, 6, 7, 8},
+			size:    4,
+			want:    [][]int64{{1, 4, 5, 8}, {2, 3, 6, 7}},
+		},
+		{
+			name:    "three tables of two",
+			userIDs: []int64{1, 2, 3, 4, 5, 6},
+			size:    2,
+			want:    [][]int64{{1, 6}, {2, 5}, {3, 4}},
+		},
+		{
+			name:    "top seed gets the bye",
+			userIDs: []int64{1, 2, 3},
+			size:    2,
+			want:    [][]int64{{1}, {2, 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := snakeTables(tt.userIDs, tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSwissTables(t *testing.T) {
+	tests := []struct {
+		name    string
+		userIDs []int64
+		size    int
+		earlier []*models.TournamentTable
+		want    [][]int64
+	}{
+		{
+			name:    "first round in standings order",
+			userIDs: []int64{1, 2, 3, 4, 5, 6, 7, 8},
+			size:    4,
+			want:    [][]int64{{1, 2, 3, 4}, {5, 6, 7, 8}},
+		},
+		{
+			name:    "avoids a rematch",
+			userIDs: []int64{1, 2, 3, 4},
+			size:    2,
+			earlier: []*models.TournamentTable{
+				{Round: 1, PlayerIDs: []int64{1, 2}, Status: models.TableFinished},
+				{Round: 1, PlayerIDs: []int64{3, 4}, Status: models.TableFinished},
+			},
+			want: [][]int64{{1, 3}, {2, 4}},
+		},
+		{
+			name:    "takes the fewest repeats when all have met",
+			userIDs: []int64{1, 2, 3, 4},
+			size:    2,
+			earlier: []*models.TournamentTable{
+				{Round: 1, PlayerIDs: []int64{1, 2}, Status: models.TableFinished},
+				{Round: 2, PlayerIDs: []int64{1, 3}, Status: models.TableFinished},
+				{Round: 3, PlayerIDs: []int64{1, 2}, Status: models.TableFinished},
+				{Round: 3, PlayerIDs: []int64{1, 4}, Status: models.TableFinished},
+			},
+			want: [][]int64{{1, 3}, {2, 4}},
+		},
+		{
+			name:    "lowest ranked player sits out",
+			userIDs: []int64{1, 2, 3},
+			size:    2,
+			want:    [][]int64{{1, 2}, {3}},
+		},
+		{
+			name:    "no second bye",
+			userIDs: []int64{1, 2, 3},
+			size:    2,
+			earlier: []*models.TournamentTable{
+				{Round: 1, PlayerIDs: []int64{1, 2}, Status: models.TableFinished},
+				{Round: 1, PlayerIDs: []int64{3}, Status: models.TableBye},
+			},
+			want: [][]int64{{1, 3}, {2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := swissTables(tt.userIDs, tt.size, tt.earlier); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchPoints(t *testing.T) {
+	tests := []struct {
+		tableSize, seats, placement int
+		want                        float64
+	}{
+		{4, 4, 1, 3},
+		{4, 4, 2, 2},
+		{4, 4, 4, 0},
+		{4, 3, 1, 3},
+		{4, 3, 2, 1.5},
+		{4, 2, 1, 3},
+		{4, 2, 2, 0},
+		{4, 1, 1, 0},
+	}
+
+	for _, tt := range tests {
+		if got := matchPoints(tt.tableSize, tt.seats, tt.placement); got != tt.want {
+			t.Errorf("matchPoints(%d, %d, %d) = %v, want %v", tt.tableSize, tt.seats, tt.placement, got, tt.want)
+		}
+	}
+}
+
+func TestSwissStandings(t *testing.T) {
+	tests := []struct {
+		name        string
+		tableSize   int
+		players     int
+		tables      []*models.TournamentTable
+		results     []*models.TournamentResult
+		want        []int64
+		matchPoints []float64
+	}{
+		{
+			name:      "victory points break a Buchholz tie",
+			tableSize: 2,
+			players:   4,
+			tables: []*models.TournamentTable{
+				{ID: 1, Round: 1, PlayerIDs: []int64{1, 2}, Status: models.TableFinished},
+				{ID: 2, Round: 1, PlayerIDs: []int64{3, 4}, Status: models.TableFinished},
+			},
+			results: []*models.TournamentResult{
+				{TableID: 1, Round: 1, UserID: 2, Placement: 1, VictoryPoints: 15},
+				{TableID: 1, Round: 1, UserID: 1, Placement: 2, VictoryPoints: 10},
+				{TableID: 2, Round: 1, UserID: 3, Placement: 1, VictoryPoints: 16},
+				{TableID: 2, Round: 1, UserID: 4, Placement: 2, VictoryPoints: 12},
+			},
+			want:        []int64{3, 2, 4, 1},
+			matchPoints: []float64{1, 1, 0, 0},
+		},
+		{
+			name:      "Buchholz ahead of victory points",
+			tableSize: 2,
+			players:   4,
+			tables: []*models.TournamentTable{
+				{ID: 1, Round: 1, PlayerIDs: []int64{1, 2}, Status: models.TableFinished},
+				{ID: 2, Round: 1, PlayerIDs: []int64{3, 4}, Status: models.TableFinished},
+				{ID: 3, Round: 2, PlayerIDs: []int64{1, 3}, Status: models.TableFinished},
+				{ID: 4, Round: 2, PlayerIDs: []int64{2, 4}, Status: models.TableFinished},
+			},
+			results: []*models.TournamentResult{
+				{TableID: 1, Round: 1, UserID: 1, Placement: 1, VictoryPoints: 15},
+				{TableID: 1, Round: 1, UserID: 2, Placement: 2, VictoryPoints: 14},
+				{TableID: 2, Round: 1, UserID: 3, Placement: 1, VictoryPoints: 15},
+				{TableID: 2, Round: 1, UserID: 4, Placement: 2, VictoryPoints: 8},
+				{TableID: 3, Round: 2, UserID: 3, Placement: 1, VictoryPoints: 15},
+				{TableID: 3, Round: 2, UserID: 1, Placement: 2, VictoryPoints: 5},
+				{TableID: 4, Round: 2, UserID: 2, Placement: 1, VictoryPoints: 15},
+				{TableID: 4, Round: 2, UserID: 4, Placement: 2, VictoryPoints: 10},
+			},
+			want:        []int64{3, 1, 2, 4},
+			matchPoints: []float64{2, 1, 1, 0},
+		},
+		{
+			name:      "short tables scaled and byes paid as wins",
+			tableSize: 4,
+			players:   6,
+			tables: []*models.TournamentTable{
+				{ID: 1, Round: 1, PlayerIDs: []int64{1, 2, 3}, Status: models.TableFinished},
+				{ID: 2, Round: 1, PlayerIDs: []int64{4, 5}, Status: models.TableFinished},
+				{ID: 3, Round: 1, PlayerIDs: []int64{6}, Status: models.TableBye},
+			},
+			results: []*models.TournamentResult{
+				{TableID: 1, Round: 1, UserID: 1, Placement: 1, VictoryPoints: 15},
+				{TableID: 1, Round: 1, UserID: 2, Placement: 2, VictoryPoints: 12},
+				{TableID: 1, Round: 1, UserID: 3, Placement: 3, VictoryPoints: 9},
+				{TableID: 2, Round: 1, UserID: 5, Placement: 1, VictoryPoints: 16},
+				{TableID: 2, Round: 1, UserID: 4, Placement: 2, VictoryPoints: 11},
+			},
+			want:        []int64{1, 5, 6, 2, 3, 4},
+			matchPoints: []float64{3, 3, 3, 1.5, 0, 0},
+		},
+		{
+			name:      "seed breaks a full tie",
+			tableSize: 4,
+			players:   3,
+			want:      []int64{1, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standings := swissStandings(tt.tableSize, seededPlayers(tt.players), tt.tables, tt.results)
+			if got := standingIDs(standings); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got order %v, want %v", got, tt.want)
+			}
+			for i, standing := range standings {
+				if standing.Rank != i+1 {
+					t.Errorf("user %d: rank %d, want %d", standing.UserID, standing.Rank, i+1)
+				}
+				if tt.matchPoints != nil && standing.MatchPoints != tt.matchPoints[i] {
+					t.Errorf("user %d: %v match points, want %v", standing.UserID, standing.MatchPoints, tt.matchPoints[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEliminationEntrants(t *testing.T) {
+	players := seededPlayers(5)
+	tables := []*models.TournamentTable{
+		{ID: 1, Round: 1, PlayerIDs: []int64{1}, Status: models.TableBye},
+		{ID: 2, Round: 1, PlayerIDs: []int64{2, 5}, Status: models.TableFinished},
+		{ID: 3, Round: 1, PlayerIDs: []int64{3, 4}, Status: models.TableFinished},
+		{ID: 4, Round: 2, PlayerIDs: []int64{1}, Status: models.TableBye},
+		{ID: 5, Round: 2, PlayerIDs: []int64{3, 5}, Status: models.TableFinished},
+	}
+	results := []*models.TournamentResult{
+		{TableID: 2, Round: 1, UserID: 5, Placement: 1},
+		{TableID: 2, Round: 1, UserID: 2, Placement: 2},
+		{TableID: 3, Round: 1, UserID: 3, Placement: 1},
+		{TableID: 3, Round: 1, UserID: 4, Placement: 2},
+		{TableID: 5, Round: 2, UserID: 5, Placement: 1},
+		{TableID: 5, Round: 2, UserID: 3, Placement: 2},
+	}
+
+	tests := []struct {
+		round int
+		want  []int64
+	}{
+		{1, []int64{1, 2, 3, 4, 5}},
+		{2, []int64{1, 3, 5}},
+		{3, []int64{1, 5}},
+	}
+
+	for _, tt := range tests {
+		entrants := eliminationEntrants(players, tables, results, tt.round)
+		got := make([]int64, len(entrants))
+		for i, player := range entrants {
+			got[i] = player.UserID
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("round %d: got %v, want %v", tt.round, got, tt.want)
+		}
+	}
+}
+
+func TestRoundTables(t *testing.T) {
+	players := seededPlayers(5)
+	tables := []*models.TournamentTable{
+		{ID: 1, Round: 1, PlayerIDs: []int64{1}, Status: models.TableBye},
+		{ID: 2, Round: 1, PlayerIDs: []int64{2, 5}, Status: models.TableFinished},
+		{ID: 3, Round: 1, PlayerIDs: []int64{3, 4}, Status: models.TableFinished},
+	}
+	results := []*models.TournamentResult{
+		{TableID: 2, Round: 1, UserID: 5, Placement: 1, VictoryPoints: 15},
+		{TableID: 2, Round: 1, UserID: 2, Placement: 2, VictoryPoints: 11},
+		{TableID: 3, Round: 1, UserID: 3, Placement: 1, VictoryPoints: 16},
+		{TableID: 3, Round: 1, UserID: 4, Placement: 2, VictoryPoints: 9},
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		round  int
+		want   [][]int64
+	}{
+		{
+			name:   "elimination seeds round one",
+			format: models.TournamentElimination,
+			round:  1,
+			want:   [][]int64{{1}, {2, 5}, {3, 4}},
+		},
+		{
+			name:   "elimination seats the winners",
+			format: models.TournamentElimination,
+			round:  2,
+			want:   [][]int64{{1}, {3, 5}},
+		},
+		{
+			name:   "swiss pairs by standings",
+			format: models.TournamentSwiss,
+			round:  2,
+			want:   [][]int64{{3, 5}, {1, 2}, {4}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tournament := &models.Tournament{Format: tt.format, TableSize: 2}
+			if got := roundTables(tournament, players, tables, results, tt.round); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
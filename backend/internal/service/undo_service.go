@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"splendor-backend/internal/domain/models"
+	"splendor-backend/internal/gamelogic"
+	"splendor-backend/internal/repository/postgres"
+)
+
+var (
+	ErrUndoDisabled   = errors.New("undo is disabled in ranked games")
+	ErrNothingToUndo  = errors.New("there is no move to undo")
+	ErrNotYourMove    = errors.New("only the player who made the last move can take it back")
+	ErrUndoTooLate    = errors.New("the next player has already acted")
+	ErrNoUndoRequest  = errors.New("there is no open undo request")
+	ErrOwnUndoRequest = errors.New("you cannot answer your own undo request")
+)
+
+// UndoEngine restores the state from before a game's last move
+type UndoEngine interface {
+	UndoMove(ctx context.Context, gameID, moveID int64) error
+}
+
+// UndoService runs take-back requests: the player who just moved asks, every
+// other player approves or denies, and the move is undone once all approve.
+// Bots always approve.
+type UndoService struct {
+	gameRepo *postgres.GameRepository
+	moveRepo *postgres.MoveRepository
+	engine   UndoEngine
+}
+
+func NewUndoService(gameRepo *postgres.GameRepository, moveRepo *postgres.MoveRepository, engine UndoEngine) *UndoService {
+	return &UndoService{
+		gameRepo: gameRepo,
+		moveRepo: moveRepo,
+		engine:   engine,
+	}
+}
+
+// RequestUndo asks to take back userID's last move. It is only allowed in
+// unranked games and before the next player acts.
+func (s *UndoService) RequestUndo(ctx context.Context, gameID, userID int64) (*models.UndoRequest, error) {
+	game, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, ErrGameNotFound
+	}
+
+	if game.Ranked {
+		return nil, ErrUndoDisabled
+	}
+
+	if game.Status != models.GameStatusInProgress {
+		return nil, ErrGameNotInProgress
+	}
+
+	move, err := s.moveRepo.GetLast(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if move == nil {
+		return nil, ErrNothingToUndo
+	}
+	if move.UserID != userID {
+		return nil, ErrNotYourMove
+	}
+	if game.TurnNumber != move.MoveNumber+1 {
+		return nil, ErrUndoTooLate
+	}
+
+	players, err := s.gameRepo.GetPlayers(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bots have no say, so they approve up front
+	approvals := []int64{}
+	for _, p := range players {
+		if p.IsActive && p.UserID != userID && p.BotStrategy != "" {
+			approvals = append(approvals, p.UserID)
+		}
+	}
+
+	req := &models.UndoRequest{
+		GameID:      gameID,
+		MoveID:      move.ID,
+		RequestedBy: userID,
+		Approvals:   approvals,
+		Status:      models.UndoPending,
+	}
+	if err := s.moveRepo.CreateUndoRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return s.applyIfApproved(ctx, req, players)
+}
+
+// RespondUndo records a player's answer to the open undo request. A single
+// denial closes the request.
+func (s *UndoService) RespondUndo(ctx context.Context, gameID, userID int64, approve bool) (*models.UndoRequest, error) {
+	req, err := s.moveRepo.GetUndoRequest(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, ErrNoUndoRequest
+	}
+
+	if req.RequestedBy == userID {
+		return nil, ErrOwnUndoRequest
+	}
+
+	players, err := s.gameRepo.GetPlayers(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	seated := false
+	for _, p := range players {
+		if p.UserID == userID && p.IsActive {
+			seated = true
+			break
+		}
+	}
+	if !seated {
+		return nil, ErrNotAPlayer
+	}
+
+	if !approve {
+		if err := s.moveRepo.DeleteUndoRequest(ctx, gameID); err != nil {
+			return nil, err
+		}
+		req.Status = models.UndoDenied
+		return req, nil
+	}
+
+	req.Approvals, err = s.moveRepo.AddUndoApproval(ctx, gameID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyIfApproved(ctx, req, players)
+}
+
+// applyIfApproved undoes the move once every other active player approved
+func (s *UndoService) applyIfApproved(ctx context.Context, req *models.UndoRequest, players []*models.GamePlayer) (*models.UndoRequest, error) {
+	approved := make(map[int64]bool, len(req.Approvals))
+	for _, id := range req.Approvals {
+		approved[id] = true
+	}
+
+	for _, p := range players {
+		if p.IsActive && p.UserID != req.RequestedBy && !approved[p.UserID] {
+			return req, nil
+		}
+	}
+
+	err := s.engine.UndoMove(ctx, req.GameID, req.MoveID)
+	if errors.Is(err, gamelogic.ErrMoveNotUndoable) {
+		s.moveRepo.DeleteUndoRequest(ctx, req.GameID)
+		return nil, ErrUndoTooLate
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Removing the move also removed the request
+	req.Status = models.UndoApplied
+	return req, nil
+}
@@ -0,0 +1,11 @@
+-- Migration: Add spill table for the WebSocket backplane
+-- Broadcasts larger than the NOTIFY payload limit are stored here and
+-- relayed between backend instances by reference
+
+CREATE TABLE IF NOT EXISTS ws_broadcasts (
+    id BIGSERIAL PRIMARY KEY,
+    payload BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ws_broadcasts_created_at ON ws_broadcasts(created_at);
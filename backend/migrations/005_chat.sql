@@ -0,0 +1,24 @@
+-- Migration: Add in-game chat
+-- Messages are stamped server-side and stored per game; players can mute
+-- other players within a game
+
+CREATE TABLE IF NOT EXISTS chat_messages (
+    id BIGSERIAL PRIMARY KEY,
+    game_id BIGINT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    message TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_game_id ON chat_messages(game_id, id);
+
+CREATE TABLE IF NOT EXISTS chat_mutes (
+    id BIGSERIAL PRIMARY KEY,
+    game_id BIGINT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    muted_user_id BIGINT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(game_id, user_id, muted_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_mutes_game_user ON chat_mutes(game_id, user_id);
@@ -0,0 +1,9 @@
+-- Migration: Add spectator mode support
+-- Games can delay what spectators see, and blind reserves are tracked so
+-- they can be hidden from anyone but their owner
+
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS spectator_delay_seconds INT NOT NULL DEFAULT 0;
+
+ALTER TABLE player_state
+ADD COLUMN IF NOT EXISTS blind_reserved JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,9 @@
+-- Migration: Add bot players
+-- Bots are user rows flagged is_bot; their seat records which strategy
+-- plays for them
+
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS is_bot BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE game_players
+ADD COLUMN IF NOT EXISTS bot_strategy VARCHAR(32);
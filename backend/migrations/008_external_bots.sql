@@ -0,0 +1,31 @@
+-- Migration: External bot protocol
+-- Registered bot accounts belong to a user and authenticate with tokens;
+-- turns offered to external bots wait in bot_turns for a reply
+
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS bot_owner_id BIGINT REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE TABLE IF NOT EXISTS bot_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash CHAR(64) UNIQUE NOT NULL, -- SHA-256 of the token, hex encoded
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_bot_tokens_user_id ON bot_tokens(user_id);
+
+CREATE TABLE IF NOT EXISTS bot_turns (
+    id BIGSERIAL PRIMARY KEY,
+    game_id BIGINT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    turn_number INT NOT NULL,
+    legal_actions JSONB NOT NULL,
+    action JSONB, -- The bot's reply, NULL until it answers
+    deadline TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(game_id, turn_number)
+);
+
+CREATE INDEX idx_bot_turns_user_id ON bot_turns(user_id);
@@ -0,0 +1,9 @@
+-- Migration: Move hints
+-- Hints are opt-in per game; games where a hint was used are flagged so
+-- they can be left out of ranked statistics
+
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS allow_hints BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS hints_used BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,18 @@
+-- Migration: Undo requests
+-- Moves are logged with a snapshot of what they changed so the last move
+-- can be taken back; ranked games don't allow it
+
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS ranked BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE game_moves
+ADD COLUMN IF NOT EXISTS state_before JSONB;
+
+-- At most one open request per game; it goes away with the move it targets
+CREATE TABLE IF NOT EXISTS undo_requests (
+    game_id BIGINT PRIMARY KEY REFERENCES games(id) ON DELETE CASCADE,
+    move_id BIGINT NOT NULL REFERENCES game_moves(id) ON DELETE CASCADE,
+    requested_by BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    approvals BIGINT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
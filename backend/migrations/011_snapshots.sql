@@ -0,0 +1,28 @@
+-- Migration: Game snapshots and admin audit log
+-- The engine stores full snapshots of a game every few moves and at key
+-- events; admins can restore a game to one, and every restore is audited
+
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS game_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    game_id BIGINT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    turn_number INT NOT NULL,
+    reason VARCHAR(32) NOT NULL, -- interval, start, finish, undo, pre_restore, manual
+    state JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_snapshots_game_id ON game_snapshots(game_id, id);
+
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    admin_id BIGINT NOT NULL REFERENCES users(id),
+    action VARCHAR(64) NOT NULL,
+    game_id BIGINT REFERENCES games(id) ON DELETE SET NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_audit_log_created_at ON admin_audit_log(created_at DESC);
@@ -0,0 +1,27 @@
+-- Migration: Game results
+-- Final standings of every finished game, and a marker so the game-over
+-- pipeline (statistics, standings, notifications) runs once per game
+
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS finalized_at TIMESTAMP;
+
+-- Games finished before this migration never had their statistics counted;
+-- mark them done rather than counting them without move logs
+UPDATE games SET finalized_at = completed_at
+WHERE status = 'completed' AND finalized_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS game_results (
+    id BIGSERIAL PRIMARY KEY,
+    game_id BIGINT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    placement INT NOT NULL,
+    victory_points INT NOT NULL,
+    cards_purchased INT NOT NULL,
+    nobles_earned INT NOT NULL,
+    moves INT NOT NULL,
+    cards_by_gem JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (game_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_results_user_id ON game_results(user_id);
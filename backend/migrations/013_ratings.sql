@@ -0,0 +1,25 @@
+-- Migration: Skill ratings
+-- Elo ratings from ranked games, with the change each game made
+
+CREATE TABLE IF NOT EXISTS player_ratings (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    rating DOUBLE PRECISION NOT NULL,
+    peak_rating DOUBLE PRECISION NOT NULL,
+    games_rated INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_ratings_rating ON player_ratings(rating DESC);
+
+CREATE TABLE IF NOT EXISTS rating_history (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    game_id BIGINT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    rating_before DOUBLE PRECISION NOT NULL,
+    rating_after DOUBLE PRECISION NOT NULL,
+    placement INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, game_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_rating_history_user_id ON rating_history(user_id, id DESC);
@@ -0,0 +1,29 @@
+-- Migration: Seasons
+-- Ranked seasons with their own standings; final standings are archived
+-- when a season ends
+
+CREATE TABLE IF NOT EXISTS seasons (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ended_at TIMESTAMP
+);
+
+-- At most one season runs at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_seasons_active ON seasons((ended_at IS NULL)) WHERE ended_at IS NULL;
+
+ALTER TABLE rating_history
+ADD COLUMN IF NOT EXISTS season_id BIGINT REFERENCES seasons(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_rating_history_season_id ON rating_history(season_id);
+
+CREATE TABLE IF NOT EXISTS season_standings (
+    season_id BIGINT NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rank INT NOT NULL,
+    rating DOUBLE PRECISION NOT NULL,
+    games_rated INT NOT NULL,
+    wins INT NOT NULL,
+    average_points DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (season_id, user_id)
+);
@@ -0,0 +1,42 @@
+-- Migration: Tournaments
+-- Organized tournaments: Swiss rounds of 2-4 player tables, or single
+-- elimination where each table's winner advances
+
+CREATE TABLE IF NOT EXISTS tournaments (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    format VARCHAR(20) NOT NULL,
+    table_size INT NOT NULL,
+    rounds INT NOT NULL DEFAULT 0,
+    current_round INT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'registration',
+    ranked BOOLEAN NOT NULL DEFAULT false,
+    winner_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_by BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    CONSTRAINT chk_tournament_format CHECK (format IN ('swiss', 'elimination')),
+    CONSTRAINT chk_tournament_status CHECK (status IN ('registration', 'in_progress', 'completed')),
+    CONSTRAINT chk_tournament_table_size CHECK (table_size BETWEEN 2 AND 4)
+);
+
+CREATE TABLE IF NOT EXISTS tournament_players (
+    tournament_id BIGINT NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    seed INT NOT NULL DEFAULT 0,
+    registered_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tournament_id, user_id)
+);
+
+-- A table seats players for one round; a table of one is a bye
+CREATE TABLE IF NOT EXISTS tournament_tables (
+    id BIGSERIAL PRIMARY KEY,
+    tournament_id BIGINT NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE,
+    round INT NOT NULL,
+    table_number INT NOT NULL,
+    game_id BIGINT UNIQUE REFERENCES games(id) ON DELETE SET NULL,
+    player_ids BIGINT[] NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'playing',
+    UNIQUE (tournament_id, round, table_number)
+);
@@ -0,0 +1,31 @@
+-- Migration: Game analytics
+-- Per-player metrics computed from each finished game's move log by the
+-- analytics job, and a marker so each game is analyzed once
+
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS analyzed_at TIMESTAMP;
+
+-- Games without a move log have nothing to analyze
+UPDATE games SET analyzed_at = completed_at
+WHERE status = 'completed' AND analyzed_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM game_moves m WHERE m.game_id = games.id);
+
+CREATE TABLE IF NOT EXISTS game_analytics (
+    id BIGSERIAL PRIMARY KEY,
+    game_id BIGINT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    turns INT NOT NULL,
+    first_noble_turn INT,
+    tier1_purchases INT NOT NULL DEFAULT 0,
+    tier2_purchases INT NOT NULL DEFAULT 0,
+    tier3_purchases INT NOT NULL DEFAULT 0,
+    gold_purchases INT NOT NULL DEFAULT 0,
+    gold_spent INT NOT NULL DEFAULT 0,
+    reserves INT NOT NULL DEFAULT 0,
+    reserved_purchases INT NOT NULL DEFAULT 0,
+    tokens_at_end INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (game_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_analytics_user_id ON game_analytics(user_id);
@@ -0,0 +1,59 @@
+-- Migration: Card and noble statistics
+-- Running totals across finished games: how often each card is bought and
+-- by whom, how often each noble is claimed, and wins by seat. The game-over
+-- pipeline adds each game once.
+
+CREATE TABLE IF NOT EXISTS card_stats (
+    card_id BIGINT PRIMARY KEY REFERENCES development_cards(id) ON DELETE CASCADE,
+    purchases INT NOT NULL DEFAULT 0,
+    winner_purchases INT NOT NULL DEFAULT 0,
+    expected_wins DOUBLE PRECISION NOT NULL DEFAULT 0, -- Sum of 1/players over purchases
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS noble_stats (
+    noble_id BIGINT PRIMARY KEY REFERENCES nobles(id) ON DELETE CASCADE,
+    claims INT NOT NULL DEFAULT 0,
+    winner_claims INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Seat 0 moves first
+CREATE TABLE IF NOT EXISTS seat_stats (
+    num_players INT NOT NULL,
+    seat_position INT NOT NULL,
+    games INT NOT NULL DEFAULT 0,
+    wins INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (num_players, seat_position)
+);
+
+-- Count the games the pipeline has already processed; the rest are added
+-- when it gets to them
+CREATE TEMP TABLE finished_seats AS
+SELECT gp.game_id, gp.player_position, ps.purchased_cards, ps.nobles,
+       COALESCE(gp.user_id = g.winner_id, false) AS won,
+       COUNT(*) OVER (PARTITION BY gp.game_id) AS seats
+FROM game_players gp
+JOIN games g ON g.id = gp.game_id
+JOIN player_state ps ON ps.game_player_id = gp.id
+WHERE g.status = 'completed' AND g.finalized_at IS NOT NULL;
+
+INSERT INTO card_stats (card_id, purchases, winner_purchases, expected_wins)
+SELECT (card->>'id')::BIGINT, COUNT(*), COUNT(*) FILTER (WHERE won), SUM(1.0 / seats)
+FROM finished_seats, jsonb_array_elements(purchased_cards) AS card
+GROUP BY 1
+ON CONFLICT (card_id) DO NOTHING;
+
+INSERT INTO noble_stats (noble_id, claims, winner_claims)
+SELECT (noble->>'id')::BIGINT, COUNT(*), COUNT(*) FILTER (WHERE won)
+FROM finished_seats, jsonb_array_elements(nobles) AS noble
+GROUP BY 1
+ON CONFLICT (noble_id) DO NOTHING;
+
+INSERT INTO seat_stats (num_players, seat_position, games, wins)
+SELECT seats, player_position, COUNT(*), COUNT(*) FILTER (WHERE won)
+FROM finished_seats
+GROUP BY 1, 2
+ON CONFLICT (num_players, seat_position) DO NOTHING;
+
+DROP TABLE finished_seats;
@@ -0,0 +1,11 @@
+-- Migration: Achievements
+-- Achievements each player has unlocked. The achievements themselves are
+-- defined in code (internal/achievement) and referenced by key.
+
+CREATE TABLE IF NOT EXISTS user_achievements (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    achievement_key VARCHAR(50) NOT NULL,
+    game_id BIGINT REFERENCES games(id) ON DELETE SET NULL, -- The game it was unlocked in
+    unlocked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, achievement_key)
+);
@@ -0,0 +1,17 @@
+-- Migration: Refresh Tokens
+-- Refresh tokens issued to users, keyed by the token's ID (jti). Each token
+-- is used once: refreshing marks it used and issues the next token in the
+-- same family. A used token presented again revokes the whole family.
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id CHAR(32) PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id CHAR(32) NOT NULL, -- Shared by every token rotated from one login
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    used_at TIMESTAMP, -- Set when the token was exchanged for the next one
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
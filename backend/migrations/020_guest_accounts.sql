@@ -0,0 +1,7 @@
+-- Migration: Guest accounts
+-- Guests play under a display name without registering. They get a
+-- placeholder email and no password until they upgrade, keeping their ID
+-- and with it their games and stats.
+
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS is_guest BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,7 @@
+-- Migration: Resumable tournament seating
+-- A table is claimed with status 'seating' before its game is created, so a
+-- round can be seated again after a failure without seating a table twice.
+-- created_at lets tables abandoned mid-seating be released.
+
+ALTER TABLE tournament_tables
+ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
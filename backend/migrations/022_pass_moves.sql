@@ -0,0 +1,6 @@
+-- Migration: Passed turns
+-- A bot that cannot move has its turn passed, recorded as a 'pass' move.
+
+ALTER TABLE game_moves DROP CONSTRAINT IF EXISTS chk_move_type;
+ALTER TABLE game_moves
+ADD CONSTRAINT chk_move_type CHECK (move_type IN ('take_gems', 'reserve_card', 'purchase_card', 'pass'));
@@ -0,0 +1,5 @@
+-- Migration: Rename the random bot strategy
+-- The strategy seated as 'random' is now the 'easy' difficulty; seats saved
+-- under the old name would otherwise fall back to the default strategy.
+
+UPDATE game_players SET bot_strategy = 'easy' WHERE bot_strategy = 'random';
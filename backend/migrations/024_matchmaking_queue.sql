@@ -0,0 +1,17 @@
+-- Migration: Matchmaking queue
+-- The queue is kept in Postgres so every server instance matches from the
+-- same queue. A matching pass locks the rows it groups with
+-- FOR UPDATE SKIP LOCKED; once matched, a row keeps the game it was put in
+-- until the player queues again.
+
+CREATE TABLE IF NOT EXISTS matchmaking_queue (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    num_players INTEGER NOT NULL,
+    ranked BOOLEAN NOT NULL DEFAULT false,
+    rating DOUBLE PRECISION NOT NULL,
+    joined_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    game_id BIGINT REFERENCES games(id) ON DELETE CASCADE,
+    CONSTRAINT chk_queue_num_players CHECK (num_players BETWEEN 2 AND 4)
+);
+
+CREATE INDEX IF NOT EXISTS idx_matchmaking_queue_waiting ON matchmaking_queue(joined_at) WHERE game_id IS NULL;
@@ -0,0 +1,14 @@
+-- Migration: Spectator presence for the WebSocket backplane
+-- Each backend instance records how many spectators of each game are
+-- connected to it, refreshed every few seconds, so spectator counts cover
+-- every instance. Rows of an instance that stopped refreshing are ignored.
+
+CREATE TABLE IF NOT EXISTS ws_presence (
+    instance_id VARCHAR(32) NOT NULL,
+    game_id VARCHAR(32) NOT NULL,
+    spectators INTEGER NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (instance_id, game_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ws_presence_game_id ON ws_presence(game_id);
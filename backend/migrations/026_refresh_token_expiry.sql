@@ -0,0 +1,4 @@
+-- Migration: Index refresh tokens by expiry
+-- Expired refresh tokens are deleted periodically by the server.
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires_at ON refresh_tokens(expires_at);
@@ -0,0 +1,10 @@
+-- Migration: Free the seats of players who left a waiting game
+-- Leaving a game that has not started now deletes the seat. Seats left
+-- behind as inactive still held their position, so the game could not be
+-- filled again.
+
+DELETE FROM game_players gp
+USING games g
+WHERE gp.game_id = g.id
+  AND g.status = 'waiting'
+  AND gp.is_active = false;
@@ -0,0 +1,10 @@
+-- Migration: Track which finished games had their achievements checked
+-- Achievements are checked after a game's results are recorded. A game
+-- whose check failed keeps achievements_checked_at unset and is retried.
+
+ALTER TABLE games
+ADD COLUMN IF NOT EXISTS achievements_checked_at TIMESTAMP;
+
+-- Games finalized before this migration were checked at the time
+UPDATE games SET achievements_checked_at = finalized_at
+WHERE finalized_at IS NOT NULL AND achievements_checked_at IS NULL;
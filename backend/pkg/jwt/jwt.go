@@ -12,9 +12,19 @@ var (
 	ErrExpiredToken = errors.New("token has expired")
 )
 
+// Token types, carried in the "typ" claim so one kind cannot stand in for
+// the other
+const (
+	TokenAccess  = "access"
+	TokenRefresh = "refresh"
+)
+
 type Claims struct {
 	UserID   int64  `json:"user_id"`
 	Username string `json:"username"`
+	Type     string `json:"typ"`
+	Guest    bool   `json:"guest,omitempty"`
+	FamilyID string `json:"fam,omitempty"` // Refresh tokens only: the login they were rotated from
 	jwt.RegisteredClaims
 }
 
@@ -23,15 +33,30 @@ type TokenPair struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
-func GenerateTokenPair(userID int64, username, secret string, accessExpirySeconds, refreshExpirySeconds int64) (*TokenPair, error) {
+// GenerateTokenPair signs an access token and a refresh token. The refresh
+// token's ID (jti) and family let the server look it up, rotate it and
+// revoke it. Guest tokens are marked so routes can turn guests away.
+func GenerateTokenPair(userID int64, username string, guest bool, refreshID, familyID, secret string, accessExpirySeconds, refreshExpirySeconds int64) (*TokenPair, error) {
 	// Generate access token
-	accessToken, err := generateToken(userID, username, secret, time.Duration(accessExpirySeconds)*time.Second)
+	accessToken, err := generateToken(&Claims{
+		UserID:   userID,
+		Username: username,
+		Type:     TokenAccess,
+		Guest:    guest,
+	}, secret, time.Duration(accessExpirySeconds)*time.Second)
 	if err != nil {
 		return nil, err
 	}
 
 	// Generate refresh token
-	refreshToken, err := generateToken(userID, username, secret, time.Duration(refreshExpirySeconds)*time.Second)
+	refreshToken, err := generateToken(&Claims{
+		UserID:           userID,
+		Username:         username,
+		Type:             TokenRefresh,
+		Guest:            guest,
+		FamilyID:         familyID,
+		RegisteredClaims: jwt.RegisteredClaims{ID: refreshID},
+	}, secret, time.Duration(refreshExpirySeconds)*time.Second)
 	if err != nil {
 		return nil, err
 	}
@@ -42,21 +67,33 @@ func GenerateTokenPair(userID int64, username, secret string, accessExpirySecond
 	}, nil
 }
 
-func generateToken(userID int64, username, secret string, expiry time.Duration) (string, error) {
-	claims := &Claims{
-		UserID:   userID,
-		Username: username,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiry)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
-	}
+func generateToken(claims *Claims, secret string, expiry time.Duration) (string, error) {
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(expiry))
+	claims.IssuedAt = jwt.NewNumericDate(time.Now())
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(secret))
 }
 
+// ValidateToken validates an access token. Refresh tokens are rejected.
 func ValidateToken(tokenString, secret string) (*Claims, error) {
+	return parseToken(tokenString, secret, TokenAccess)
+}
+
+// ValidateRefreshToken validates a refresh token's signature, expiry and
+// type. Whether it is still usable is up to the server's records.
+func ValidateRefreshToken(tokenString, secret string) (*Claims, error) {
+	claims, err := parseToken(tokenString, secret, TokenRefresh)
+	if err != nil {
+		return nil, err
+	}
+	if claims.ID == "" || claims.FamilyID == "" {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func parseToken(tokenString, secret, tokenType string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, ErrInvalidToken
@@ -71,7 +108,7 @@ func ValidateToken(tokenString, secret string) (*Claims, error) {
 		return nil, ErrInvalidToken
 	}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+	if claims, ok := token.Claims.(*Claims); ok && token.Valid && claims.Type == tokenType {
 		return claims, nil
 	}
 
@@ -0,0 +1,116 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret"
+
+func signed(t *testing.T, claims *Claims, secret string, expiry time.Duration) string {
+	t.Helper()
+	token, err := generateToken(claims, secret, expiry)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestValidateTokenTypes(t *testing.T) {
+	pair, err := GenerateTokenPair(1, "alice", false, "jti-1", "fam-1", testSecret, 60, 60)
+	if err != nil {
+		t.Fatalf("failed to generate token pair: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		token    func(t *testing.T) string
+		validate func(tokenString, secret string) (*Claims, error)
+		wantErr  error
+	}{
+		{
+			name:     "access token as access token",
+			token:    func(t *testing.T) string { return pair.AccessToken },
+			validate: ValidateToken,
+		},
+		{
+			name:     "refresh token as refresh token",
+			token:    func(t *testing.T) string { return pair.RefreshToken },
+			validate: ValidateRefreshToken,
+		},
+		{
+			name:     "refresh token as access token",
+			token:    func(t *testing.T) string { return pair.RefreshToken },
+			validate: ValidateToken,
+			wantErr:  ErrInvalidToken,
+		},
+		{
+			name:     "access token as refresh token",
+			token:    func(t *testing.T) string { return pair.AccessToken },
+			validate: ValidateRefreshToken,
+			wantErr:  ErrInvalidToken,
+		},
+		{
+			name: "untyped token as access token",
+			token: func(t *testing.T) string {
+				return signed(t, &Claims{UserID: 1}, testSecret, time.Minute)
+			},
+			validate: ValidateToken,
+			wantErr:  ErrInvalidToken,
+		},
+		{
+			name: "untyped token as refresh token",
+			token: func(t *testing.T) string {
+				return signed(t, &Claims{UserID: 1, FamilyID: "fam-1", RegisteredClaims: jwt.RegisteredClaims{ID: "jti-1"}}, testSecret, time.Minute)
+			},
+			validate: ValidateRefreshToken,
+			wantErr:  ErrInvalidToken,
+		},
+		{
+			name: "refresh token without jti",
+			token: func(t *testing.T) string {
+				return signed(t, &Claims{UserID: 1, Type: TokenRefresh, FamilyID: "fam-1"}, testSecret, time.Minute)
+			},
+			validate: ValidateRefreshToken,
+			wantErr:  ErrInvalidToken,
+		},
+		{
+			name: "refresh token without fam",
+			token: func(t *testing.T) string {
+				return signed(t, &Claims{UserID: 1, Type: TokenRefresh, RegisteredClaims: jwt.RegisteredClaims{ID: "jti-1"}}, testSecret, time.Minute)
+			},
+			validate: ValidateRefreshToken,
+			wantErr:  ErrInvalidToken,
+		},
+		{
+			name: "wrong secret",
+			token: func(t *testing.T) string {
+				return signed(t, &Claims{UserID: 1, Type: TokenAccess}, "other-secret", time.Minute)
+			},
+			validate: ValidateToken,
+			wantErr:  ErrInvalidToken,
+		},
+		{
+			name: "expired refresh token",
+			token: func(t *testing.T) string {
+				return signed(t, &Claims{UserID: 1, Type: TokenRefresh, FamilyID: "fam-1", RegisteredClaims: jwt.RegisteredClaims{ID: "jti-1"}}, testSecret, -time.Minute)
+			},
+			validate: ValidateRefreshToken,
+			wantErr:  ErrExpiredToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := tt.validate(tt.token(t), testSecret)
+			if err != tt.wantErr {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && claims.UserID != 1 {
+				t.Errorf("got user %d, want 1", claims.UserID)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package websocket
+
+import (
+	"context"
+	"sync"
+)
+
+// Backplane fans broadcast messages out to every server instance so that
+// clients connected to any replica receive them
+type Backplane interface {
+	// Publish sends a message to all instances, including this one
+	Publish(ctx context.Context, message *BroadcastMessage) error
+
+	// Subscribe registers the handler that delivers messages to local clients
+	Subscribe(ctx context.Context, handler func(*BroadcastMessage)) error
+
+	// SetSpectators records this instance's spectator count per game, so
+	// other instances can include them in their counts
+	SetSpectators(ctx context.Context, counts map[string]int) error
+
+	// RemoteSpectators sums each game's spectators on the other live
+	// instances. Games nobody watches elsewhere may be missing from the map.
+	RemoteSpectators(ctx context.Context, gameIDs []string) (map[string]int, error)
+
+	// Close stops the backplane and releases its resources
+	Close() error
+}
+
+// LocalBackplane delivers messages within a single process. It is the
+// default when only one backend instance is running.
+type LocalBackplane struct {
+	mu      sync.RWMutex
+	handler func(*BroadcastMessage)
+}
+
+func NewLocalBackplane() *LocalBackplane {
+	return &LocalBackplane{}
+}
+
+// Publish hands the message straight to the local handler
+func (b *LocalBackplane) Publish(ctx context.Context, message *BroadcastMessage) error {
+	b.mu.RLock()
+	handler := b.handler
+	b.mu.RUnlock()
+
+	if handler != nil {
+		handler(message)
+	}
+	return nil
+}
+
+// Subscribe sets the local delivery handler
+func (b *LocalBackplane) Subscribe(ctx context.Context, handler func(*BroadcastMessage)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handler = handler
+	return nil
+}
+
+// SetSpectators is a no-op, as there are no other instances to tell
+func (b *LocalBackplane) SetSpectators(ctx context.Context, counts map[string]int) error {
+	return nil
+}
+
+// RemoteSpectators is always zero for a single instance
+func (b *LocalBackplane) RemoteSpectators(ctx context.Context, gameIDs []string) (map[string]int, error) {
+	return map[string]int{}, nil
+}
+
+// Close is a no-op for the in-process backplane
+func (b *LocalBackplane) Close() error {
+	return nil
+}
@@ -0,0 +1,191 @@
+package websocket
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// maxLagDuration is how long a client may stay lagging before eviction
+	maxLagDuration = 30 * time.Second
+
+	// sweepInterval is how often lagging clients are checked for recovery
+	sweepInterval = 2 * time.Second
+
+	// presenceInterval is how often spectator counts are shared with the
+	// other instances
+	presenceInterval = 5 * time.Second
+)
+
+// clientFlow tracks a client's delivery state. It is only accessed with the
+// hub's write lock held.
+type clientFlow struct {
+	// lagging clients have a full buffer and only receive snapshots
+	lagging      bool
+	laggingSince time.Time
+
+	// pendingSnapshot is the latest snapshot held back while lagging
+	pendingSnapshot []byte
+
+	// evicted is set once the client's Send channel has been closed
+	evicted bool
+}
+
+// RoomMetrics counts delivery outcomes for one room on this instance
+type RoomMetrics struct {
+	Clients    int   `json:"clients"`
+	Spectators int   `json:"spectators"`
+	Lagging    int   `json:"lagging"`
+	Delivered  int64 `json:"delivered"`
+	Dropped    int64 `json:"dropped"`
+	Downgraded int64 `json:"downgraded"`
+	Recovered  int64 `json:"recovered"`
+	Evicted    int64 `json:"evicted"`
+}
+
+// resyncMessage tells a recovered client to refetch the game state
+var resyncMessage, _ = json.Marshal(&Message{
+	Type:    "resync",
+	Payload: map[string]interface{}{"reason": "slow_consumer"},
+})
+
+// deliver queues a message for a client without ever blocking the hub. A
+// client whose buffer is full is downgraded to snapshot-only delivery:
+// incremental messages are dropped and only the latest snapshot is kept until
+// the client drains its buffer. Must be called with h.mu held for writing.
+func (h *Hub) deliver(client *Client, message []byte, snapshot bool) {
+	if client.flow.evicted {
+		return
+	}
+
+	metrics := h.roomMetrics(client.GameID)
+
+	if client.flow.lagging {
+		if snapshot {
+			client.flow.pendingSnapshot = message
+		} else {
+			metrics.Dropped++
+		}
+		h.recover(client)
+		return
+	}
+
+	select {
+	case client.Send <- message:
+		metrics.Delivered++
+	default:
+		client.flow.lagging = true
+		client.flow.laggingSince = time.Now()
+		metrics.Dropped++
+		metrics.Downgraded++
+		if snapshot {
+			client.flow.pendingSnapshot = message
+		}
+	}
+}
+
+// recover restores a lagging client once its buffer has drained to half,
+// sending the held snapshot or a resync notice first. Clients that stay
+// lagging for too long are evicted. Must be called with h.mu held for writing.
+func (h *Hub) recover(client *Client) {
+	if len(client.Send) > cap(client.Send)/2 {
+		if time.Since(client.flow.laggingSince) > maxLagDuration {
+			h.evict(client)
+		}
+		return
+	}
+
+	message := client.flow.pendingSnapshot
+	if message == nil {
+		message = resyncMessage
+	}
+
+	select {
+	case client.Send <- message:
+		metrics := h.roomMetrics(client.GameID)
+		metrics.Delivered++
+		metrics.Recovered++
+		client.flow.lagging = false
+		client.flow.pendingSnapshot = nil
+	default:
+	}
+}
+
+// evict removes a client from its room and closes its Send channel. It is
+// safe to call more than once. Must be called with h.mu held for writing.
+func (h *Hub) evict(client *Client) {
+	if client.flow.evicted {
+		return
+	}
+	h.roomMetrics(client.GameID).Evicted++
+	h.removeClient(client)
+}
+
+// removeClient deletes the client from its room and closes its Send channel
+// exactly once. Must be called with h.mu held for writing.
+func (h *Hub) removeClient(client *Client) {
+	if clients, ok := h.games[client.GameID]; ok {
+		delete(clients, client)
+
+		// Clean up empty game rooms
+		if len(clients) == 0 {
+			delete(h.games, client.GameID)
+			delete(h.spectatorDelays, client.GameID)
+			delete(h.metrics, client.GameID)
+		}
+	}
+
+	if !client.flow.evicted {
+		client.flow.evicted = true
+		close(client.Send)
+	}
+}
+
+// sweepLagging retries recovery for lagging clients that have not received
+// a broadcast recently
+func (h *Hub) sweepLagging() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, clients := range h.games {
+		for client := range clients {
+			if client.flow.lagging {
+				h.recover(client)
+			}
+		}
+	}
+}
+
+// roomMetrics returns the counters for a room, creating them on first use.
+// Must be called with h.mu held for writing.
+func (h *Hub) roomMetrics(gameID string) *RoomMetrics {
+	metrics, ok := h.metrics[gameID]
+	if !ok {
+		metrics = &RoomMetrics{}
+		h.metrics[gameID] = metrics
+	}
+	return metrics
+}
+
+// Metrics returns a snapshot of delivery metrics for every room with
+// connected clients on this instance
+func (h *Hub) Metrics() map[string]RoomMetrics {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	result := make(map[string]RoomMetrics, len(h.metrics))
+	for gameID, metrics := range h.metrics {
+		snapshot := *metrics
+		for client := range h.games[gameID] {
+			snapshot.Clients++
+			if client.Spectator {
+				snapshot.Spectators++
+			}
+			if client.flow.lagging {
+				snapshot.Lagging++
+			}
+		}
+		result[gameID] = snapshot
+	}
+	return result
+}
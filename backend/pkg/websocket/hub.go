@@ -1,7 +1,28 @@
 package websocket
 
 import (
+	"context"
+	"log"
+	"strconv"
 	"sync"
+	"time"
+)
+
+// LobbyRoom is the room key of the global lobby channel. Lobby clients are
+// registered like game clients but under this key.
+const LobbyRoom = "lobby"
+
+// BotRoom is the room key of an external bot's private channel, where it is
+// told about turns it has to play
+func BotRoom(userID int64) string {
+	return "bot:" + strconv.FormatInt(userID, 10)
+}
+
+// Broadcast audiences
+const (
+	AudienceAll        = ""
+	AudiencePlayers    = "players"
+	AudienceSpectators = "spectators"
 )
 
 // Message represents a WebSocket message
@@ -12,12 +33,55 @@ type Message struct {
 
 // Client represents a WebSocket client connection
 type Client struct {
-	ID     string
-	GameID string
-	UserID int64
-	Conn   any // Will be *websocket.Conn
-	Send   chan []byte
-	Hub    *Hub
+	ID       string
+	GameID   string
+	UserID   int64
+	Username string
+	Conn     any // Will be *websocket.Conn
+	Send     chan []byte
+	Hub      *Hub
+
+	// Spectators are connected users who are not seated in the game
+	Spectator bool
+
+	// Users whose chat messages this client does not receive
+	muted   map[int64]bool
+	mutedMu sync.RWMutex
+
+	// Delivery state for slow-consumer handling
+	flow clientFlow
+}
+
+// SetMuted replaces the set of users muted by this client
+func (c *Client) SetMuted(userIDs []int64) {
+	c.mutedMu.Lock()
+	defer c.mutedMu.Unlock()
+
+	c.muted = make(map[int64]bool, len(userIDs))
+	for _, id := range userIDs {
+		c.muted[id] = true
+	}
+}
+
+func (c *Client) setMute(userID int64, muted bool) {
+	c.mutedMu.Lock()
+	defer c.mutedMu.Unlock()
+
+	if c.muted == nil {
+		c.muted = make(map[int64]bool)
+	}
+	if muted {
+		c.muted[userID] = true
+	} else {
+		delete(c.muted, userID)
+	}
+}
+
+func (c *Client) isMuted(userID int64) bool {
+	c.mutedMu.RLock()
+	defer c.mutedMu.RUnlock()
+
+	return c.muted[userID]
 }
 
 // Hub maintains active clients and broadcasts messages
@@ -34,26 +98,84 @@ type Hub struct {
 	// Broadcast messages to all clients in a game
 	broadcast chan *BroadcastMessage
 
+	// Backplane relaying broadcasts between server instances
+	backplane Backplane
+
+	// Delay applied to spectator delivery, by game ID
+	spectatorDelays map[string]time.Duration
+
+	// Delivery metrics, by game ID
+	metrics map[string]*RoomMetrics
+
 	// Mutex for thread-safe operations
 	mu sync.RWMutex
 }
 
 // BroadcastMessage contains a message and target game ID
 type BroadcastMessage struct {
-	GameID  string
-	Message []byte
+	GameID  string `json:"game_id"`
+	Message []byte `json:"message,omitempty"`
+
+	// SenderID marks user-authored messages so muting clients can skip them
+	SenderID int64 `json:"sender_id,omitempty"`
+
+	// Audience restricts delivery to players or spectators
+	Audience string `json:"audience,omitempty"`
+
+	// UserID restricts delivery to one user's connections, for messages
+	// carrying that user's private view of the game
+	UserID int64 `json:"user_id,omitempty"`
+
+	// Snapshot messages carry full state and are kept for lagging clients
+	Snapshot bool `json:"snapshot,omitempty"`
+
+	// Mute carries a mute change to every instance instead of a message
+	Mute *MuteUpdate `json:"mute,omitempty"`
+
+	// delayed marks a spectator delivery whose delay has already elapsed
+	delayed bool
 }
 
-func NewHub() *Hub {
+// MuteUpdate changes whether UserID receives MutedUserID's messages
+type MuteUpdate struct {
+	UserID      int64 `json:"user_id"`
+	MutedUserID int64 `json:"muted_user_id"`
+	Muted       bool  `json:"muted"`
+}
+
+// NewHub creates a hub that fans broadcasts out through the given backplane.
+// A nil backplane keeps delivery within this process.
+func NewHub(backplane Backplane) *Hub {
+	if backplane == nil {
+		backplane = NewLocalBackplane()
+	}
+
 	return &Hub{
 		games:      make(map[string]map[*Client]bool),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
 		broadcast:  make(chan *BroadcastMessage),
+		backplane:  backplane,
+
+		spectatorDelays: make(map[string]time.Duration),
+		metrics:         make(map[string]*RoomMetrics),
 	}
 }
 
 func (h *Hub) Run() {
+	// Messages from any instance are queued for local delivery
+	err := h.backplane.Subscribe(context.Background(), func(message *BroadcastMessage) {
+		h.broadcast <- message
+	})
+	if err != nil {
+		log.Printf("Failed to subscribe to WebSocket backplane: %v", err)
+	}
+
+	go h.sharePresence()
+
+	sweep := time.NewTicker(sweepInterval)
+	defer sweep.Stop()
+
 	for {
 		select {
 		case client := <-h.register:
@@ -64,6 +186,9 @@ func (h *Hub) Run() {
 
 		case message := <-h.broadcast:
 			h.broadcastToGame(message)
+
+		case <-sweep.C:
+			h.sweepLagging()
 		}
 	}
 }
@@ -84,32 +209,69 @@ func (h *Hub) unregisterClient(client *Client) {
 
 	if clients, ok := h.games[client.GameID]; ok {
 		if _, ok := clients[client]; ok {
-			delete(clients, client)
-			close(client.Send)
-
-			// Clean up empty game rooms
-			if len(clients) == 0 {
-				delete(h.games, client.GameID)
-			}
+			h.removeClient(client)
 		}
 	}
 }
 
 func (h *Hub) broadcastToGame(message *BroadcastMessage) {
-	h.mu.RLock()
-	defer h.mu.RUnlock()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delay := h.spectatorDelays[message.GameID]
+	delaySpectators := false
 
 	if clients, ok := h.games[message.GameID]; ok {
 		for client := range clients {
-			select {
-			case client.Send <- message.Message:
-			default:
-				// Client send buffer is full, close it
-				close(client.Send)
-				delete(clients, client)
+			if message.Mute != nil {
+				if client.UserID == message.Mute.UserID {
+					client.setMute(message.Mute.MutedUserID, message.Mute.Muted)
+				}
+				continue
+			}
+
+			if !message.wants(client) {
+				continue
+			}
+
+			if client.Spectator && delay > 0 && !message.delayed {
+				delaySpectators = true
+				continue
+			}
+
+			if message.SenderID != 0 && client.isMuted(message.SenderID) {
+				continue
 			}
+
+			h.deliver(client, message.Message, message.Snapshot)
 		}
 	}
+
+	// Spectators get the same message once the game's delay has passed
+	if delaySpectators {
+		delayed := *message
+		delayed.Audience = AudienceSpectators
+		delayed.delayed = true
+		time.AfterFunc(delay, func() {
+			h.broadcast <- &delayed
+		})
+	}
+}
+
+// wants reports whether the client is in the message's audience
+func (m *BroadcastMessage) wants(client *Client) bool {
+	if m.UserID != 0 && (client.UserID != m.UserID || client.Spectator) {
+		return false
+	}
+
+	switch m.Audience {
+	case AudiencePlayers:
+		return !client.Spectator
+	case AudienceSpectators:
+		return client.Spectator
+	default:
+		return true
+	}
 }
 
 // RegisterClient registers a new client
@@ -122,11 +284,151 @@ func (h *Hub) UnregisterClient(client *Client) {
 	h.unregister <- client
 }
 
-// BroadcastToGame sends a message to all clients in a specific game
+// BroadcastToGame sends a message to all clients in a specific game on
+// every server instance
 func (h *Hub) BroadcastToGame(gameID string, message []byte) {
-	h.broadcast <- &BroadcastMessage{
+	err := h.backplane.Publish(context.Background(), &BroadcastMessage{
 		GameID:  gameID,
 		Message: message,
+	})
+	if err != nil {
+		log.Printf("Failed to publish broadcast for game %s: %v", gameID, err)
+	}
+}
+
+// BroadcastFromUser sends a user-authored message to a game. Clients that
+// muted the sender do not receive it.
+func (h *Hub) BroadcastFromUser(gameID string, senderID int64, message []byte) {
+	err := h.backplane.Publish(context.Background(), &BroadcastMessage{
+		GameID:   gameID,
+		Message:  message,
+		SenderID: senderID,
+	})
+	if err != nil {
+		log.Printf("Failed to publish broadcast for game %s: %v", gameID, err)
+	}
+}
+
+// UpdateMute applies a mute change to the user's connections on every instance
+func (h *Hub) UpdateMute(gameID string, userID, mutedUserID int64, muted bool) {
+	err := h.backplane.Publish(context.Background(), &BroadcastMessage{
+		GameID: gameID,
+		Mute: &MuteUpdate{
+			UserID:      userID,
+			MutedUserID: mutedUserID,
+			Muted:       muted,
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to publish mute update for game %s: %v", gameID, err)
+	}
+}
+
+// BroadcastToLobby sends a message to every client on the lobby channel
+func (h *Hub) BroadcastToLobby(message []byte) {
+	h.BroadcastToGame(LobbyRoom, message)
+}
+
+// BroadcastToSpectators sends a state snapshot only to a game's spectators,
+// after the game's spectator delay
+func (h *Hub) BroadcastToSpectators(gameID string, message []byte) {
+	err := h.backplane.Publish(context.Background(), &BroadcastMessage{
+		GameID:   gameID,
+		Message:  message,
+		Audience: AudienceSpectators,
+		Snapshot: true,
+	})
+	if err != nil {
+		log.Printf("Failed to publish broadcast for game %s: %v", gameID, err)
+	}
+}
+
+// SendToUser sends a state snapshot to one seated user's connections in a
+// game on every server instance
+func (h *Hub) SendToUser(gameID string, userID int64, message []byte) {
+	err := h.backplane.Publish(context.Background(), &BroadcastMessage{
+		GameID:   gameID,
+		Message:  message,
+		Audience: AudiencePlayers,
+		UserID:   userID,
+		Snapshot: true,
+	})
+	if err != nil {
+		log.Printf("Failed to publish message for user %d in game %s: %v", userID, gameID, err)
+	}
+}
+
+// SetSpectatorDelay sets how long spectators of a game wait for messages
+func (h *Hub) SetSpectatorDelay(gameID string, delay time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.spectatorDelays[gameID] = delay
+}
+
+// SendToClient queues a message for one client if it is still connected
+func (h *Hub) SendToClient(client *Client, message []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if clients, ok := h.games[client.GameID]; ok && clients[client] {
+		h.deliver(client, message, false)
+	}
+}
+
+// GetSpectatorCount returns the number of spectators watching a game on
+// any instance. Other instances' counts are at most presenceInterval old.
+func (h *Hub) GetSpectatorCount(gameID string) int {
+	return h.GetSpectatorCounts([]string{gameID})[gameID]
+}
+
+// GetSpectatorCounts returns the spectator count of each game, asking the
+// other instances for all of them at once
+func (h *Hub) GetSpectatorCounts(gameIDs []string) map[string]int {
+	counts := make(map[string]int, len(gameIDs))
+	h.mu.RLock()
+	for _, gameID := range gameIDs {
+		count := 0
+		for client := range h.games[gameID] {
+			if client.Spectator {
+				count++
+			}
+		}
+		counts[gameID] = count
+	}
+	h.mu.RUnlock()
+
+	remote, err := h.backplane.RemoteSpectators(context.Background(), gameIDs)
+	if err != nil {
+		log.Printf("Failed to count spectators on other instances: %v", err)
+	}
+	for gameID, count := range remote {
+		counts[gameID] += count
+	}
+	return counts
+}
+
+// sharePresence publishes this instance's spectator counts every
+// presenceInterval
+func (h *Hub) sharePresence() {
+	ticker := time.NewTicker(presenceInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		h.mu.RLock()
+		counts := make(map[string]int)
+		for gameID, clients := range h.games {
+			for client := range clients {
+				if client.Spectator {
+					counts[gameID]++
+				}
+			}
+		}
+		h.mu.RUnlock()
+
+		if err := h.backplane.SetSpectators(context.Background(), counts); err != nil {
+			log.Printf("Failed to share spectator counts: %v", err)
+		}
 	}
 }
 
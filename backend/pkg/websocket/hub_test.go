@@ -0,0 +1,112 @@
+package websocket
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitFor polls until cond holds, failing the test after a few seconds
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// flood broadcasts to the game and messages the client directly from several
+// goroutines at once, the way concurrent game events reach the hub
+func flood(hub *Hub, gameID string, client *Client) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				message := []byte(fmt.Sprintf(`{"type":"move","payload":"%d-%d"}`, i, j))
+				hub.BroadcastToGame(gameID, message)
+				hub.SendToClient(client, message)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestHubEvictsClientThatNeverReads(t *testing.T) {
+	const gameID = "1"
+
+	hub := NewHub(nil)
+	go hub.Run()
+
+	// The reader keeps the room open and its metrics around
+	reader := &Client{ID: "reader", GameID: gameID, UserID: 1, Send: make(chan []byte, 1024)}
+	go func() {
+		for range reader.Send {
+		}
+	}()
+	hub.RegisterClient(reader)
+
+	stalled := &Client{ID: "stalled", GameID: gameID, UserID: 2, Send: make(chan []byte, 4)}
+	hub.RegisterClient(stalled)
+
+	snapshot := []byte(`{"type":"state"}`)
+	hub.SendToUser(gameID, stalled.UserID, snapshot)
+
+	// Fill the stalled client's buffer until it is downgraded
+	flood(hub, gameID, stalled)
+	waitFor(t, "the client to lag", func() bool {
+		hub.mu.RLock()
+		defer hub.mu.RUnlock()
+		return stalled.flow.lagging
+	})
+
+	// Pretend it has lagged for too long, so the next message evicts it
+	hub.mu.Lock()
+	stalled.flow.laggingSince = time.Now().Add(-2 * maxLagDuration)
+	hub.mu.Unlock()
+
+	flood(hub, gameID, stalled)
+	waitFor(t, "the client to be evicted", func() bool {
+		return hub.Metrics()[gameID].Evicted > 0
+	})
+
+	// Its read loop giving up unregisters it while messages keep coming
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		flood(hub, gameID, stalled)
+	}()
+	go func() {
+		defer wg.Done()
+		hub.UnregisterClient(stalled)
+	}()
+	wg.Wait()
+
+	// Registering waits for the hub to finish the last broadcast
+	hub.RegisterClient(&Client{ID: "sync", GameID: "2", Send: make(chan []byte, 1)})
+
+	if got := hub.GetGameClientCount(gameID); got != 1 {
+		t.Errorf("room has %d clients, want 1", got)
+	}
+	if got := hub.Metrics()[gameID].Evicted; got != 1 {
+		t.Errorf("evicted %d times, want 1", got)
+	}
+
+	// The snapshot arrived before anything else and the channel is closed
+	var received [][]byte
+	for message := range stalled.Send {
+		received = append(received, message)
+	}
+	if len(received) == 0 || string(received[0]) != string(snapshot) {
+		t.Fatalf("first message was not the snapshot: %q", received)
+	}
+	if len(received) > cap(stalled.Send) {
+		t.Errorf("received %d messages through a buffer of %d", len(received), cap(stalled.Send))
+	}
+}
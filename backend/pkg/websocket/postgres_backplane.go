@@ -0,0 +1,312 @@
+package websocket
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"splendor-backend/pkg/database"
+)
+
+const (
+	// postgresChannel is the LISTEN/NOTIFY channel shared by all instances
+	postgresChannel = "splendor_ws_broadcast"
+
+	// maxNotifyPayload keeps NOTIFY payloads under Postgres' 8000 byte limit.
+	// Larger messages are stored in ws_broadcasts and sent by reference.
+	maxNotifyPayload = 7000
+
+	// broadcastRetention is how long spilled payloads are kept for listeners
+	broadcastRetention = time.Minute
+
+	// presenceTTL is how long an instance's spectator counts stay valid
+	// without a refresh, so counts of a crashed instance age out
+	presenceTTL = 3 * presenceInterval
+)
+
+// postgresEnvelope is the NOTIFY payload exchanged between instances
+type postgresEnvelope struct {
+	Origin  string            `json:"origin"`
+	Message *BroadcastMessage `json:"message,omitempty"`
+	Ref     int64             `json:"ref,omitempty"`
+}
+
+// PostgresBackplane relays broadcasts between instances using Postgres
+// LISTEN/NOTIFY. Each instance delivers its own messages locally and ignores
+// its own notifications.
+type PostgresBackplane struct {
+	db         *database.DB
+	instanceID string
+
+	mu      sync.RWMutex
+	handler func(*BroadcastMessage)
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	lastCleanup time.Time
+}
+
+func NewPostgresBackplane(db *database.DB) *PostgresBackplane {
+	id := make([]byte, 8)
+	rand.Read(id)
+
+	return &PostgresBackplane{
+		db:         db,
+		instanceID: hex.EncodeToString(id),
+	}
+}
+
+// Publish delivers the message locally and notifies all other instances
+func (b *PostgresBackplane) Publish(ctx context.Context, message *BroadcastMessage) error {
+	b.mu.RLock()
+	handler := b.handler
+	b.mu.RUnlock()
+
+	if handler != nil {
+		handler(message)
+	}
+
+	payload, err := json.Marshal(&postgresEnvelope{Origin: b.instanceID, Message: message})
+	if err != nil {
+		return fmt.Errorf("failed to marshal broadcast: %w", err)
+	}
+
+	if len(payload) > maxNotifyPayload {
+		payload, err = b.spill(ctx, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := b.db.Exec(ctx, "SELECT pg_notify($1, $2)", postgresChannel, string(payload)); err != nil {
+		return fmt.Errorf("failed to notify instances: %w", err)
+	}
+
+	return nil
+}
+
+// spill stores an oversized message and returns an envelope referencing it
+func (b *PostgresBackplane) spill(ctx context.Context, message *BroadcastMessage) ([]byte, error) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal broadcast: %w", err)
+	}
+
+	var ref int64
+	err = b.db.QueryRow(ctx,
+		"INSERT INTO ws_broadcasts (payload) VALUES ($1) RETURNING id",
+		data,
+	).Scan(&ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store broadcast: %w", err)
+	}
+
+	b.cleanup(ctx)
+
+	return json.Marshal(&postgresEnvelope{Origin: b.instanceID, Ref: ref})
+}
+
+// cleanup removes spilled payloads that every listener has had time to read
+func (b *PostgresBackplane) cleanup(ctx context.Context) {
+	b.mu.Lock()
+	if time.Since(b.lastCleanup) < broadcastRetention {
+		b.mu.Unlock()
+		return
+	}
+	b.lastCleanup = time.Now()
+	b.mu.Unlock()
+
+	_, err := b.db.Exec(ctx,
+		"DELETE FROM ws_broadcasts WHERE created_at < CURRENT_TIMESTAMP - make_interval(secs => $1)",
+		broadcastRetention.Seconds(),
+	)
+	if err != nil {
+		log.Printf("Failed to clean up ws_broadcasts: %v", err)
+	}
+}
+
+// Subscribe starts listening for notifications from other instances
+func (b *PostgresBackplane) Subscribe(ctx context.Context, handler func(*BroadcastMessage)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.cancel != nil {
+		return fmt.Errorf("backplane already subscribed")
+	}
+
+	listenCtx, cancel := context.WithCancel(ctx)
+	b.handler = handler
+	b.cancel = cancel
+	b.done = make(chan struct{})
+
+	go b.listen(listenCtx)
+
+	return nil
+}
+
+// listen keeps a dedicated connection on the channel, reconnecting on failure
+func (b *PostgresBackplane) listen(ctx context.Context) {
+	defer close(b.done)
+
+	for {
+		if err := b.listenOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("WebSocket backplane listener error: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (b *PostgresBackplane) listenOnce(ctx context.Context) error {
+	conn, err := b.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+postgresChannel); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var envelope postgresEnvelope
+		if err := json.Unmarshal([]byte(notification.Payload), &envelope); err != nil {
+			log.Printf("Failed to unmarshal backplane message: %v", err)
+			continue
+		}
+
+		if envelope.Origin == b.instanceID {
+			continue
+		}
+
+		message := envelope.Message
+		if envelope.Ref > 0 {
+			message, err = b.load(ctx, envelope.Ref)
+			if err != nil {
+				log.Printf("Failed to load spilled broadcast %d: %v", envelope.Ref, err)
+				continue
+			}
+		}
+
+		if message == nil {
+			continue
+		}
+
+		b.mu.RLock()
+		handler := b.handler
+		b.mu.RUnlock()
+
+		if handler != nil {
+			handler(message)
+		}
+	}
+}
+
+// load reads a spilled payload using the pool, not the listening connection
+func (b *PostgresBackplane) load(ctx context.Context, ref int64) (*BroadcastMessage, error) {
+	var data []byte
+	if err := b.db.QueryRow(ctx, "SELECT payload FROM ws_broadcasts WHERE id = $1", ref).Scan(&data); err != nil {
+		return nil, err
+	}
+
+	message := &BroadcastMessage{}
+	if err := json.Unmarshal(data, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// SetSpectators replaces this instance's rows in ws_presence
+func (b *PostgresBackplane) SetSpectators(ctx context.Context, counts map[string]int) error {
+	gameIDs := make([]string, 0, len(counts))
+	spectators := make([]int32, 0, len(counts))
+	for gameID, count := range counts {
+		gameIDs = append(gameIDs, gameID)
+		spectators = append(spectators, int32(count))
+	}
+
+	return b.db.InTx(ctx, func(ctx context.Context) error {
+		_, err := b.db.Exec(ctx,
+			"DELETE FROM ws_presence WHERE instance_id = $1 OR updated_at < CURRENT_TIMESTAMP - make_interval(secs => $2)",
+			b.instanceID, presenceTTL.Seconds(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to clear presence: %w", err)
+		}
+
+		_, err = b.db.Exec(ctx, `
+			INSERT INTO ws_presence (instance_id, game_id, spectators)
+			SELECT $1, game_id, spectators FROM unnest($2::text[], $3::int[]) AS p(game_id, spectators)
+		`, b.instanceID, gameIDs, spectators)
+		if err != nil {
+			return fmt.Errorf("failed to record presence: %w", err)
+		}
+		return nil
+	})
+}
+
+// RemoteSpectators sums the spectators other live instances recorded for
+// the given games in one query
+func (b *PostgresBackplane) RemoteSpectators(ctx context.Context, gameIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(gameIDs))
+	if len(gameIDs) == 0 {
+		return counts, nil
+	}
+
+	rows, err := b.db.Query(ctx, `
+		SELECT game_id, COALESCE(SUM(spectators), 0) FROM ws_presence
+		WHERE game_id = ANY($1) AND instance_id <> $2
+		  AND updated_at >= CURRENT_TIMESTAMP - make_interval(secs => $3)
+		GROUP BY game_id
+	`, gameIDs, b.instanceID, presenceTTL.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to count spectators: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var gameID string
+		var count int
+		if err := rows.Scan(&gameID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan spectator count: %w", err)
+		}
+		counts[gameID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count spectators: %w", err)
+	}
+	return counts, nil
+}
+
+// Close stops the listener and waits for it to exit
+func (b *PostgresBackplane) Close() error {
+	b.mu.Lock()
+	cancel := b.cancel
+	done := b.done
+	b.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+
+	// Our spectators are gone with us
+	if _, err := b.db.Exec(context.Background(), "DELETE FROM ws_presence WHERE instance_id = $1", b.instanceID); err != nil {
+		log.Printf("Failed to clear presence: %v", err)
+	}
+	return nil
+}
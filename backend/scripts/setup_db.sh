@@ -20,11 +20,10 @@ echo "📦 Creating database..."
 createdb splendor 2>/dev/null || echo "Database already exists"
 
 echo "📝 Running migrations..."
-echo "  → 001_initial_schema.sql"
-psql "$DB_URL" -f migrations/001_initial_schema.sql
-
-echo "  → 002_seed_cards_and_nobles.sql"
-psql "$DB_URL" -f migrations/002_seed_cards_and_nobles.sql
+for migration in migrations/*.sql; do
+    echo "  → $(basename "$migration")"
+    psql "$DB_URL" -f "$migration"
+done
 
 echo ""
 echo "✅ Database setup complete!"
@@ -51,12 +51,16 @@ export default function ActionPanel({ playerState, availableGems, onTakeGems }:
   const totalSelected = Object.values(selectedGems).reduce((a, b) => a + b, 0)
   const selectedTypes = Object.keys(selectedGems).length
 
+  const totalPlayerGems = Object.values(playerState.gems).reduce((a, b) => a + b, 0)
+
+  // Fewer than 3 different only when the bank or the 10 gem limit allows no more
+  const colorsInBank = ['diamond', 'sapphire', 'emerald', 'ruby', 'onyx'].filter((g) => (availableGems[g] || 0) > 0).length
+  const maxDifferent = Math.min(3, colorsInBank, 10 - totalPlayerGems)
+
   // Can take 3 different or 2 same (if 4+ available)
-  const canSubmit = (totalSelected === 3 && selectedTypes === 3) ||
+  const canSubmit = (totalSelected === selectedTypes && selectedTypes > 0 && selectedTypes === maxDifferent) ||
                      (totalSelected === 2 && selectedTypes === 1 && availableGems[Object.keys(selectedGems)[0]] >= 4)
 
-  const totalPlayerGems = Object.values(playerState.gems).reduce((a, b) => a + b, 0)
-
   return (
     <motion.div
       initial={{ opacity: 0, y: 20 }}
@@ -20,7 +20,7 @@ export default function GameRules() {
             <span>Take Gems</span>
           </h4>
           <ul className="text-white/80 space-y-1 text-xs">
-            <li>• Take 3 different colored gems, OR</li>
+            <li>• Take 3 different colored gems (fewer if no more fit), OR</li>
             <li>• Take 2 same colored gems (if 4+ available)</li>
             <li>• Maximum 10 gems total in hand</li>
             <li>• Gold can only be obtained by reserving</li>
@@ -8,13 +8,17 @@ export function useFullGameState(gameId: number) {
   const [gameState, setGameState] = useState<FullGameState | null>(null)
   const [loading, setLoading] = useState(true)
   const [error, setError] = useState<string | null>(null)
+  // Set when the game has a spectator delay: the server then sends the board
+  // over the WebSocket instead
+  const [watching, setWatching] = useState(false)
+  const ready = gameState !== null || watching
 
   useEffect(() => {
     loadGameState()
   }, [gameId])
 
   useEffect(() => {
-    if (gameState) {
+    if (ready) {
       const token = localStorage.getItem('access_token')
       if (token) {
         connect(gameId, token)
@@ -24,17 +28,25 @@ export function useFullGameState(gameId: number) {
     return () => {
       disconnect()
     }
-  }, [gameState])
+  }, [gameId, ready])
 
   // Handle WebSocket messages
   useEffect(() => {
     if (!lastMessage) return
 
     switch (lastMessage.type) {
+      case 'state':
+      case 'spectator_state':
+        setGameState(lastMessage.payload)
+        setLoading(false)
+        break
+
       case 'game_update':
       case 'player_event':
       case 'game_end':
-        loadGameState()
+        if (!watching) {
+          loadGameState()
+        }
         break
 
       case 'error':
@@ -50,6 +62,10 @@ export function useFullGameState(gameId: number) {
       setGameState(state)
       setError(null)
     } catch (err: any) {
+      if (err.response?.status === 403) {
+        setWatching(true)
+        return
+      }
       setError(err.response?.data?.error || 'Failed to load game state')
     } finally {
       setLoading(false)
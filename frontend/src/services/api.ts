@@ -23,6 +23,21 @@ api.interceptors.request.use(
   }
 )
 
+// Refresh tokens work once, so concurrent 401s share a single refresh
+// instead of each spending the same token
+let refreshing: Promise<string> | null = null
+
+const refreshAccessToken = async (refreshToken: string): Promise<string> => {
+  const response = await axios.post(`${API_URL}/api/v1/auth/refresh`, {
+    refresh_token: refreshToken,
+  })
+
+  const { access_token, refresh_token: newRefreshToken } = response.data
+  localStorage.setItem('access_token', access_token)
+  localStorage.setItem('refresh_token', newRefreshToken)
+  return access_token
+}
+
 // Response interceptor to handle token refresh
 api.interceptors.response.use(
   (response) => response,
@@ -35,13 +50,12 @@ api.interceptors.response.use(
       try {
         const refreshToken = localStorage.getItem('refresh_token')
         if (refreshToken) {
-          const response = await axios.post(`${API_URL}/api/v1/auth/refresh`, {
-            refresh_token: refreshToken,
-          })
-
-          const { access_token, refresh_token: newRefreshToken } = response.data
-          localStorage.setItem('access_token', access_token)
-          localStorage.setItem('refresh_token', newRefreshToken)
+          if (!refreshing) {
+            refreshing = refreshAccessToken(refreshToken).finally(() => {
+              refreshing = null
+            })
+          }
+          const access_token = await refreshing
 
           originalRequest.headers.Authorization = `Bearer ${access_token}`
           return api(originalRequest)
@@ -38,6 +38,11 @@ export const authService = {
   },
 
   logout() {
+    // Revoke the login on the server too; local tokens are cleared either way
+    const refreshToken = localStorage.getItem('refresh_token')
+    if (refreshToken) {
+      api.post('/auth/logout', { refresh_token: refreshToken }).catch(() => {})
+    }
     localStorage.removeItem('access_token')
     localStorage.removeItem('refresh_token')
   },
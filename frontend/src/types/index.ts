@@ -3,7 +3,7 @@ export type GemType = 'diamond' | 'sapphire' | 'emerald' | 'ruby' | 'onyx' | 'go
 
 export type GameStatus = 'waiting' | 'in_progress' | 'completed'
 
-export type MoveType = 'take_gems' | 'reserve_card' | 'purchase_card'
+export type MoveType = 'take_gems' | 'reserve_card' | 'purchase_card' | 'pass'
 
 export interface User {
   id: number